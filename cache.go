@@ -1,8 +1,10 @@
 package validator
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -50,6 +52,23 @@ func (sc *structCache) Set(key reflect.Type, value *cStruct) {
 	sc.m.Store(nm)
 }
 
+func (sc *structCache) Delete(key reflect.Type) {
+
+	m := sc.m.Load().(map[reflect.Type]*cStruct)
+
+	if _, ok := m[key]; !ok {
+		return
+	}
+
+	nm := make(map[reflect.Type]*cStruct, len(m))
+	for k, v := range m {
+		if k != key {
+			nm[k] = v
+		}
+	}
+	sc.m.Store(nm)
+}
+
 type tagCache struct {
 	lock sync.Mutex
 	m    atomic.Value // map[string]*cTag
@@ -73,9 +92,11 @@ func (tc *tagCache) Set(key string, value *cTag) {
 }
 
 type cStruct struct {
-	name   string
-	fields []*cField
-	fn     StructLevelFuncCtx
+	name     string
+	fields   []*cField
+	fn       StructLevelFuncCtx
+	mayCycle bool // the struct may contain itself, through pointers, collections or interfaces
+	hasMods  bool // a field has modifiers, applied before any field is validated
 }
 
 type cField struct {
@@ -83,46 +104,93 @@ type cField struct {
 	name       string
 	altName    string
 	namesEqual bool
+	skip       bool // only validated within the groups it has tags for
 	cTags      *cTag
+	groups     map[string]*cTag // tags by group, a nil *cTag means skipped within the group
+	mods       *cMod            // modifiers, applied regardless of groups and skip
+}
+
+// groupCTags returns the tags of the first of groups the field has tags for.
+func (f *cField) groupCTags(groups []string) (ct *cTag, found bool) {
+
+	for i := 0; i < len(groups); i++ {
+		if ct, found = f.groups[groups[i]]; found {
+			return
+		}
+	}
+
+	return
 }
 
 type cTag struct {
-	tag            string
-	aliasTag       string
-	actualAliasTag string
-	param          string
-	keys           *cTag // only populated when using tag's 'keys' and 'endkeys' for map key validation
-	next           *cTag
-	fn             FuncCtx
-	typeof         tagType
-	hasTag         bool
-	hasAlias       bool
-	hasParam       bool // true if parameter used eg. eq= where the equal sign has been set
-	isBlockEnd     bool // indicates the current tag represents the last validation in the block
+	tag                  string
+	aliasTag             string
+	actualAliasTag       string
+	param                string
+	keys                 *cTag // only populated when using tag's 'keys' and 'endkeys' for map key validation
+	next                 *cTag
+	fn                   FuncCtx
+	typeof               tagType
+	hasTag               bool
+	hasAlias             bool
+	hasParam             bool // true if parameter used eg. eq= where the equal sign has been set
+	isBlockEnd           bool // indicates the current tag represents the last validation in the block
+	runValidationWhenNil bool
+	isConditional        bool      // required_with, excluded_if etc., see conditionalTags
+	usesRegex            bool      // baked in validation matching a regex, see Limits.MaxRegexInput
+	isBakedIn            bool      // baked in validation, not replaced by a custom one
+	expr                 *exprNode // compiled expression of the expr tag
+}
+
+func (v *Validate) extractStructCache(current reflect.Value, sName string) (*cStruct, error) {
+
+	cs, errs := v.extractStruct(current.Type(), sName, false)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	return cs, nil
 }
 
-func (v *Validate) extractStructCache(current reflect.Value, sName string) *cStruct {
+// extractStruct parses the tags of each of typ's fields and caches the struct when they're all valid. Unless
+// allErrs it stops at the first invalid tag, otherwise returning every invalid tag, along with the struct of
+// the valid fields, uncached, so that Compile can check the types reachable from them.
+func (v *Validate) extractStruct(typ reflect.Type, sName string, allErrs bool) (*cStruct, InvalidTagErrors) {
 
 	v.structCache.lock.Lock()
 	defer v.structCache.lock.Unlock() // leave as defer! because if inner panics, it will never get unlocked otherwise!
 
-	typ := current.Type()
-
 	// could have been multiple trying to access, but once first is done this ensures struct
 	// isn't parsed again.
 	cs, ok := v.structCache.Get(typ)
 	if ok {
-		return cs
+		return cs, nil
 	}
 
-	cs = &cStruct{name: sName, fields: make([]*cField, 0), fn: v.structLevelFuncs[typ]}
+	cs = &cStruct{name: sName, fields: make([]*cField, 0), fn: v.structLevelFuncs[typ], mayCycle: v.hasCustomFuncs || mayCycle(typ)}
+
+	if selfFn := selfValidationFunc(typ); selfFn != nil {
+
+		if registeredFn := cs.fn; registeredFn != nil {
+			cs.fn = func(ctx context.Context, sl StructLevel) {
+				registeredFn(ctx, sl)
+				selfFn(ctx, sl)
+			}
+		} else {
+			cs.fn = selfFn
+		}
+	}
 
-	numFields := current.NumField()
+	numFields := typ.NumField()
 
 	var ctag *cTag
 	var fld reflect.StructField
 	var tag string
+	var modTag string
+	var groupTags map[string]string
 	var customName string
+	var err *InvalidTagError
+	var errs InvalidTagErrors
 
 	for i := 0; i < numFields; i++ {
 
@@ -132,9 +200,11 @@ func (v *Validate) extractStructCache(current reflect.Value, sName string) *cStr
 			continue
 		}
 
-		tag = fld.Tag.Get(v.tagName)
+		tag = v.fieldTag(typ, fld)
+		modTag = fld.Tag.Get(v.modTagName)
+		groupTags = extractGroupTags(fld.Tag, v.tagName)
 
-		if tag == skipValidationTag {
+		if tag == skipValidationTag && len(groupTags) == 0 && len(modTag) == 0 {
 			continue
 		}
 
@@ -152,32 +222,79 @@ func (v *Validate) extractStructCache(current reflect.Value, sName string) *cStr
 		// NOTE: cannot use shared tag cache, because tags may be equal, but things like alias may be different
 		// and so only struct level caching can be used instead of combined with Field tag caching
 
-		if len(tag) > 0 {
-			ctag, _ = v.parseFieldTagsRecursive(tag, fld.Name, "", false)
+		if len(tag) > 0 && tag != skipValidationTag {
+			if ctag, _, err = v.parseFieldTagsRecursive(tag, fld.Name, "", false); err == nil {
+				err = checkExprTags(ctag, typ, fld.Name)
+			}
+
+			if err != nil {
+				err.Namespace = customName
+				if errs = append(errs, err); !allErrs {
+					return nil, errs
+				}
+				continue
+			}
 		} else {
 			// even if field doesn't have validations need cTag for traversing to potential inner/nested
 			// elements of the field.
 			ctag = new(cTag)
 		}
 
-		cs.fields = append(cs.fields, &cField{
+		cf := &cField{
 			idx:        i,
 			name:       fld.Name,
 			altName:    customName,
+			skip:       tag == skipValidationTag,
 			cTags:      ctag,
 			namesEqual: fld.Name == customName,
-		})
+		}
+
+		if len(groupTags) > 0 {
+			if cf.groups, err = v.parseGroupTags(groupTags, fld.Name); err == nil {
+				for _, gct := range cf.groups {
+					if err = checkExprTags(gct, typ, fld.Name); err != nil {
+						break
+					}
+				}
+			}
+
+			if err != nil {
+				err.Namespace = customName
+				if errs = append(errs, err); !allErrs {
+					return nil, errs
+				}
+				continue
+			}
+		}
+
+		if len(modTag) > 0 {
+
+			if cf.mods, err = v.parseModTag(modTag, fld.Name); err != nil {
+				err.Namespace = customName
+				if errs = append(errs, err); !allErrs {
+					return nil, errs
+				}
+				continue
+			}
+
+			cs.hasMods = true
+		}
+
+		cs.fields = append(cs.fields, cf)
+	}
+
+	if len(errs) > 0 {
+		return cs, errs
 	}
 
 	v.structCache.Set(typ, cs)
 
-	return cs
+	return cs, nil
 }
 
-func (v *Validate) parseFieldTagsRecursive(tag string, fieldName string, alias string, hasAlias bool) (firstCtag *cTag, current *cTag) {
+func (v *Validate) parseFieldTagsRecursive(tag string, fieldName string, alias string, hasAlias bool) (firstCtag *cTag, current *cTag, err *InvalidTagError) {
 
 	var t string
-	var ok bool
 	noAlias := len(alias) == 0
 	tags := strings.Split(tag, tagSeparator)
 
@@ -192,9 +309,14 @@ func (v *Validate) parseFieldTagsRecursive(tag string, fieldName string, alias s
 		// check map for alias and process new tags, otherwise process as usual
 		if tagsVal, found := v.aliases[t]; found {
 			if i == 0 {
-				firstCtag, current = v.parseFieldTagsRecursive(tagsVal, fieldName, t, true)
+				if firstCtag, current, err = v.parseFieldTagsRecursive(tagsVal, fieldName, t, true); err != nil {
+					return
+				}
 			} else {
-				next, curr := v.parseFieldTagsRecursive(tagsVal, fieldName, t, true)
+				next, curr, err := v.parseFieldTagsRecursive(tagsVal, fieldName, t, true)
+				if err != nil {
+					return nil, nil, err
+				}
 				current.next, current = next, curr
 
 			}
@@ -223,7 +345,7 @@ func (v *Validate) parseFieldTagsRecursive(tag string, fieldName string, alias s
 			current.typeof = typeKeys
 
 			if i == 0 || prevTag != typeDive {
-				panic(fmt.Sprintf("'%s' tag must be immediately preceded by the '%s' tag", keysTag, diveTag))
+				return nil, nil, &InvalidTagError{Tag: keysTag, Reason: fmt.Sprintf("'%s' tag must be immediately preceded by the '%s' tag", keysTag, diveTag)}
 			}
 
 			current.typeof = typeKeys
@@ -244,7 +366,9 @@ func (v *Validate) parseFieldTagsRecursive(tag string, fieldName string, alias s
 				}
 			}
 
-			current.keys, _ = v.parseFieldTagsRecursive(string(b[:len(b)-1]), fieldName, "", false)
+			if current.keys, _, err = v.parseFieldTagsRecursive(string(b[:len(b)-1]), fieldName, "", false); err != nil {
+				return nil, nil, err
+			}
 			continue
 
 		case endKeysTag:
@@ -253,7 +377,7 @@ func (v *Validate) parseFieldTagsRecursive(tag string, fieldName string, alias s
 			// if there are more in tags then there was no keysTag defined
 			// and an error should be thrown
 			if i != len(tags)-1 {
-				panic(keysTagNotDefined)
+				return nil, nil, &InvalidTagError{Tag: endKeysTag, Reason: keysTagNotDefined}
 			}
 			return
 
@@ -275,8 +399,15 @@ func (v *Validate) parseFieldTagsRecursive(tag string, fieldName string, alias s
 				current.typeof = typeIsDefault
 			}
 
-			// if a pipe character is needed within the param you must use the utf8Pipe representation "0x7C"
-			orVals := strings.Split(t, orSeparator)
+			// if a pipe character is needed within the param you must use the utf8Pipe representation "0x7C",
+			// except for the '||' operator of an expression
+			var orVals []string
+
+			if strings.Contains(t, exprTag+tagKeySeparator) {
+				orVals = splitExprOr(t)
+			} else {
+				orVals = strings.Split(t, orSeparator)
+			}
 
 			for j := 0; j < len(orVals); j++ {
 
@@ -297,11 +428,17 @@ func (v *Validate) parseFieldTagsRecursive(tag string, fieldName string, alias s
 
 				current.tag = vals[0]
 				if len(current.tag) == 0 {
-					panic(strings.TrimSpace(fmt.Sprintf(invalidValidation, fieldName)))
+					return nil, nil, &InvalidTagError{Tag: t, Reason: strings.TrimSpace(fmt.Sprintf(invalidValidation, fieldName))}
 				}
 
-				if current.fn, ok = v.validations[current.tag]; !ok {
-					panic(strings.TrimSpace(fmt.Sprintf(undefinedValidation, current.tag, fieldName)))
+				if wrapper, ok := v.validations[current.tag]; ok {
+					current.fn = wrapper.fn
+					current.runValidationWhenNil = wrapper.runValidationOnNil
+					_, current.isConditional = conditionalTags[current.tag]
+					current.usesRegex = wrapper.usesRegex
+					current.isBakedIn = wrapper.bakedIn
+				} else {
+					return nil, nil, &InvalidTagError{Tag: current.tag, Reason: strings.TrimSpace(fmt.Sprintf(undefinedValidation, current.tag, fieldName))}
 				}
 
 				if len(orVals) > 1 {
@@ -311,6 +448,14 @@ func (v *Validate) parseFieldTagsRecursive(tag string, fieldName string, alias s
 				if len(vals) > 1 {
 					current.param = strings.Replace(strings.Replace(vals[1], utf8HexComma, ",", -1), utf8Pipe, "|", -1)
 				}
+
+				if current.tag == exprTag {
+					if current.expr, err = v.compileExprTag(current.param); err != nil {
+						err.Tag = t
+						err.Reason = fmt.Sprintf("%s on field '%s'", err.Reason, fieldName)
+						return nil, nil, err
+					}
+				}
 			}
 			current.isBlockEnd = true
 		}
@@ -318,7 +463,36 @@ func (v *Validate) parseFieldTagsRecursive(tag string, fieldName string, alias s
 	return
 }
 
-func (v *Validate) fetchCacheTag(tag string) *cTag {
+// parseGroupTags parses the tags of each group, in order of group name so any error is consistent.
+func (v *Validate) parseGroupTags(groupTags map[string]string, fieldName string) (map[string]*cTag, *InvalidTagError) {
+
+	ctags := make(map[string]*cTag, len(groupTags))
+
+	for _, group := range sortedKeys(groupTags) {
+
+		tag := groupTags[group]
+
+		switch tag {
+		case skipValidationTag:
+			ctags[group] = nil
+
+		case "":
+			ctags[group] = new(cTag)
+
+		default:
+			ctag, _, err := v.parseFieldTagsRecursive(tag, fieldName, "", false)
+			if err != nil {
+				return nil, err
+			}
+
+			ctags[group] = ctag
+		}
+	}
+
+	return ctags, nil
+}
+
+func (v *Validate) fetchCacheTag(tag string) (*cTag, error) {
 	// find cached tag
 	ctag, found := v.tagCache.Get(tag)
 	if !found {
@@ -329,9 +503,238 @@ func (v *Validate) fetchCacheTag(tag string) *cTag {
 		// isn't parsed again.
 		ctag, found = v.tagCache.Get(tag)
 		if !found {
-			ctag, _ = v.parseFieldTagsRecursive(tag, "", "", false)
+			var err *InvalidTagError
+			if ctag, _, err = v.parseFieldTagsRecursive(tag, "", "", false); err != nil {
+				return nil, err
+			}
 			v.tagCache.Set(tag, ctag)
 		}
 	}
-	return ctag
+	return ctag, nil
+}
+
+// compiler walks a type graph ahead of time, parsing and caching the tags of every
+// struct found, and collecting every invalid tag along the way.
+type compiler struct {
+	v       *Validate
+	visited map[reflect.Type]struct{}
+	errs    InvalidTagErrors
+}
+
+func (v *Validate) compile(typs ...reflect.Type) error {
+
+	c := &compiler{v: v, visited: make(map[reflect.Type]struct{})}
+
+	for _, typ := range typs {
+
+		if typ == nil {
+			return &InvalidValidationError{Type: nil}
+		}
+
+		t := typ
+
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+
+		if t.Kind() != reflect.Struct || t == timeType {
+			return &InvalidValidationError{Type: typ}
+		}
+
+		c.compileType(t, "")
+	}
+
+	if len(c.errs) > 0 {
+		return c.errs
+	}
+
+	return nil
+}
+
+// compileType walks typ looking for structs, through any pointers, slices, arrays and maps.
+func (c *compiler) compileType(typ reflect.Type, ns string) {
+
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if c.v.hasCustomFuncs {
+		if _, ok := c.v.customFuncs[typ]; ok {
+			// the type validated is only known once the custom type func has been called
+			return
+		}
+	}
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		if typ != timeType {
+			if len(ns) == 0 {
+				ns = typ.Name()
+			}
+			c.compileStruct(typ, ns)
+		}
+
+	case reflect.Slice, reflect.Array:
+		c.compileType(typ.Elem(), ns+"[]")
+
+	case reflect.Map:
+		c.compileType(typ.Key(), ns+"[]")
+		c.compileType(typ.Elem(), ns+"[]")
+	}
+}
+
+// compileStruct parses, and caches, typ as validating it does, collecting every invalid tag of its fields
+// and checking the types reachable from the valid ones.
+func (c *compiler) compileStruct(typ reflect.Type, ns string) {
+
+	if _, ok := c.visited[typ]; ok {
+		return
+	}
+
+	c.visited[typ] = struct{}{}
+
+	cs, errs := c.v.extractStruct(typ, typ.Name(), true)
+
+	if len(ns) > 0 {
+		ns += namespaceSeparator
+	}
+
+	for _, err := range errs {
+		err.Namespace = ns + err.Namespace
+		c.errs = append(c.errs, err)
+	}
+
+	var fld reflect.StructField
+	var groups []string
+
+	for _, f := range cs.fields {
+
+		fld = typ.Field(f.idx)
+
+		c.compileTags(f.cTags, fld.Type, ns+f.altName)
+
+		// the same tag is likely to be used with Var, so make it ready
+		if tag := c.v.fieldTag(typ, fld); len(tag) > 0 && tag != skipValidationTag {
+			_, _ = c.v.fetchCacheTag(tag)
+		}
+
+		groups = groups[0:0]
+
+		for group := range f.groups {
+			groups = append(groups, group)
+		}
+
+		sort.Strings(groups)
+
+		for _, group := range groups {
+			if gct := f.groups[group]; gct != nil {
+				c.compileTags(gct, fld.Type, ns+f.altName)
+			}
+		}
+	}
+}
+
+// compileTags checks that the tags in ct can be applied to a field of type typ,
+// such as a 'dive' only being used on slices, arrays and maps.
+func (c *compiler) compileTags(ct *cTag, typ reflect.Type, ns string) {
+
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if c.v.hasCustomFuncs {
+		if _, ok := c.v.customFuncs[typ]; ok {
+			return
+		}
+	}
+
+	for ; ct != nil; ct = ct.next {
+
+		if ct.typeof != typeDive {
+			continue
+		}
+
+		switch typ.Kind() {
+		case reflect.Slice, reflect.Array:
+			c.compileTags(ct.next, typ.Elem(), ns+"[]")
+
+		case reflect.Map:
+			next := ct.next
+
+			if next != nil && next.typeof == typeKeys && next.keys != nil {
+				c.compileTags(next.keys, typ.Key(), ns+"[]")
+				next = next.next
+			} else {
+				c.compileType(typ.Key(), ns+"[]")
+			}
+
+			c.compileTags(next, typ.Elem(), ns+"[]")
+
+		case reflect.Interface:
+			// what is dived into is only known at validation time
+
+		default:
+			c.errs = append(c.errs, &InvalidTagError{
+				Namespace: ns,
+				Tag:       ct.aliasTag,
+				Reason:    "dive error! can't dive on a non slice or map",
+			})
+		}
+
+		return
+	}
+
+	c.compileType(typ, ns)
+}
+
+// mayCycle returns if a value of the struct type may contain itself, through pointers, slices, arrays,
+// maps or interfaces; in which case validating it must detect cycles.
+func mayCycle(typ reflect.Type) bool {
+
+	seen := make(map[reflect.Type]struct{})
+
+	var walk func(t reflect.Type) bool
+
+	walk = func(t reflect.Type) bool {
+
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array:
+			return walk(t.Elem())
+
+		case reflect.Map:
+			return walk(t.Key()) || walk(t.Elem())
+
+		case reflect.Interface:
+			// could hold anything
+			return true
+
+		case reflect.Struct:
+
+			if t == typ {
+				return true
+			}
+
+			if _, ok := seen[t]; ok {
+				return false
+			}
+
+			seen[t] = struct{}{}
+
+			for i := 0; i < t.NumField(); i++ {
+				if walk(t.Field(i).Type) {
+					return true
+				}
+			}
+		}
+
+		return false
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		if walk(typ.Field(i).Type) {
+			return true
+		}
+	}
+
+	return false
 }
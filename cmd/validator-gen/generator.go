@@ -0,0 +1,1059 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultInstance = "validatorGen"
+	diveTag         = "dive"
+	omitempty       = "omitempty"
+	isdefault       = "isdefault"
+	utf8HexComma    = "0x2C"
+	utf8Pipe        = "0x7C"
+	tagSeparator    = ","
+	orSeparator     = "|"
+	tagKeySeparator = "="
+	modTagName      = "mod"
+)
+
+// generatedTags are the validations that can be generated, any other
+// results in Struct being called instead.
+var generatedTags = map[string]struct{}{
+	omitempty:     {},
+	isdefault:     {},
+	diveTag:       {},
+	"required":    {},
+	"len":         {},
+	"min":         {},
+	"max":         {},
+	"eq":          {},
+	"ne":          {},
+	"gt":          {},
+	"gte":         {},
+	"lt":          {},
+	"lte":         {},
+	"oneof":       {},
+	"contains":    {},
+	"containsany": {},
+	"excludes":    {},
+	"excludesall": {},
+}
+
+// kind is the kind of a field's type, as far as can be determined from source
+type kind uint8
+
+const (
+	kindUnknown kind = iota // may be anything, including a struct that needs validating
+	kindOther               // can never hold anything that needs validating, eg. a chan
+	kindString
+	kindBool
+	kindInt
+	kindUint
+	kindFloat
+	kindSlice
+	kindArray
+	kindMap
+	kindPtr
+	kindStruct
+	kindTime
+	kindInterface
+)
+
+var basicKinds = map[string]kind{
+	"string":     kindString,
+	"bool":       kindBool,
+	"int":        kindInt,
+	"int8":       kindInt,
+	"int16":      kindInt,
+	"int32":      kindInt,
+	"int64":      kindInt,
+	"rune":       kindInt,
+	"uint":       kindUint,
+	"uint8":      kindUint,
+	"uint16":     kindUint,
+	"uint32":     kindUint,
+	"uint64":     kindUint,
+	"uintptr":    kindUint,
+	"byte":       kindUint,
+	"float32":    kindFloat,
+	"float64":    kindFloat,
+	"complex64":  kindOther,
+	"complex128": kindOther,
+	"error":      kindInterface,
+}
+
+// fieldType is a field's type resolved as far as needed to generate its validations
+type fieldType struct {
+	kind  kind
+	named bool   // named type declared within the package, requires conversion for some validations
+	name  string // name of the struct when kind is kindStruct
+	key   ast.Expr
+	elem  ast.Expr
+}
+
+// tag is a single parsed validation
+type tag struct {
+	name  string
+	param string
+}
+
+// field is a value being validated by the generated code
+type field struct {
+	expr    string // expression of the value, eg. x.Name or x.Names[i0]
+	name    string // expression of the fields name, eg. "Name" or "Names["+strconv.Itoa(i0)+"]"
+	display string // name used when reporting why a field can't be generated
+}
+
+// result is the outcome of generating a single struct
+type result struct {
+	code   []byte
+	deps   []string // structs whose generated validateGen is called
+	uses   map[string]bool
+	err    error
+	inProg bool
+}
+
+type generator struct {
+	tagName    string
+	instance   string
+	importPath string
+	pkgName    string
+	order      []string // struct names in declaration order
+	specs      map[string]ast.Expr
+	methods    map[string]map[string]bool
+	results    map[string]*result
+	reasons    map[string]string
+	fallbacks  []string
+}
+
+// generate returns the source of the validators for types within dir, or all structs
+// with validation tags when none are provided; output is excluded from parsing.
+func (g *generator) generate(dir, output string, types []string) ([]byte, error) {
+
+	if len(g.instance) == 0 {
+		g.instance = defaultInstance
+	}
+
+	if len(g.importPath) == 0 {
+		g.importPath = defaultImportPath
+	}
+
+	if err := g.parse(dir, output); err != nil {
+		return nil, err
+	}
+
+	targets := types
+
+	if len(targets) == 0 {
+
+		for _, name := range g.order {
+
+			st, ok := g.specs[name].(*ast.StructType)
+			if !ok || g.methods[name]["Validate"] || !g.hasTags(st) {
+				continue
+			}
+
+			targets = append(targets, name)
+		}
+
+	} else {
+
+		for _, name := range targets {
+
+			if _, ok := g.specs[name].(*ast.StructType); !ok {
+				return nil, fmt.Errorf("struct type %s not found in %s", name, dir)
+			}
+
+			if g.methods[name]["Validate"] {
+				return nil, fmt.Errorf("%s already has a Validate method", name)
+			}
+		}
+	}
+
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no structs with '%s' tags found in %s", g.tagName, dir)
+	}
+
+	for _, name := range targets {
+		g.structResult(name)
+	}
+
+	g.propagateFailures()
+
+	return g.write(targets)
+}
+
+// parse loads the type declarations of the single package within dir.
+func (g *generator) parse(dir, output string) error {
+
+	fset := token.NewFileSet()
+
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && fi.Name() != output
+	}, 0)
+	if err != nil {
+		return err
+	}
+
+	if len(pkgs) != 1 {
+		return fmt.Errorf("expected a single package in %s, found %d", dir, len(pkgs))
+	}
+
+	g.order = nil
+	g.fallbacks = nil
+	g.specs = make(map[string]ast.Expr)
+	g.methods = make(map[string]map[string]bool)
+	g.results = make(map[string]*result)
+	g.reasons = make(map[string]string)
+
+	var pkg *ast.Package
+
+	for name, p := range pkgs {
+		g.pkgName = name
+		pkg = p
+	}
+
+	// files are walked in name order so that the output is stable
+	fileNames := make([]string, 0, len(pkg.Files))
+
+	for name := range pkg.Files {
+		fileNames = append(fileNames, name)
+	}
+
+	sort.Strings(fileNames)
+
+	for _, name := range fileNames {
+
+		for _, decl := range pkg.Files[name].Decls {
+
+			switch d := decl.(type) {
+			case *ast.GenDecl:
+
+				if d.Tok != token.TYPE {
+					continue
+				}
+
+				for _, spec := range d.Specs {
+
+					ts := spec.(*ast.TypeSpec)
+
+					if ts.TypeParams != nil {
+						continue
+					}
+
+					g.specs[ts.Name.Name] = ts.Type
+
+					if _, ok := ts.Type.(*ast.StructType); ok {
+						g.order = append(g.order, ts.Name.Name)
+					}
+				}
+
+			case *ast.FuncDecl:
+
+				if d.Recv == nil || len(d.Recv.List) == 0 {
+					continue
+				}
+
+				recv := d.Recv.List[0].Type
+
+				if star, ok := recv.(*ast.StarExpr); ok {
+					recv = star.X
+				}
+
+				if ident, ok := recv.(*ast.Ident); ok {
+
+					if g.methods[ident.Name] == nil {
+						g.methods[ident.Name] = make(map[string]bool)
+					}
+
+					g.methods[ident.Name][d.Name.Name] = true
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (g *generator) hasTags(st *ast.StructType) bool {
+
+	for _, fld := range st.Fields.List {
+
+		if len(g.fieldTag(fld)) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (g *generator) fieldTag(fld *ast.Field) string {
+	return g.structTag(fld).Get(g.tagName)
+}
+
+func (g *generator) structTag(fld *ast.Field) reflect.StructTag {
+
+	if fld.Tag == nil {
+		return ""
+	}
+
+	tag, err := strconv.Unquote(fld.Tag.Value)
+	if err != nil {
+		return ""
+	}
+
+	return reflect.StructTag(tag)
+}
+
+// resolve determines the kind of typ, looking through the named types of the package.
+func (g *generator) resolve(typ ast.Expr) fieldType {
+
+	switch t := typ.(type) {
+	case *ast.Ident:
+
+		spec, ok := g.specs[t.Name]
+		if !ok {
+			if k, ok := basicKinds[t.Name]; ok {
+				return fieldType{kind: k}
+			}
+
+			return fieldType{kind: kindUnknown}
+		}
+
+		if _, ok := spec.(*ast.StructType); ok {
+			return fieldType{kind: kindStruct, name: t.Name}
+		}
+
+		if ident, ok := spec.(*ast.Ident); ok && ident.Name == t.Name {
+			return fieldType{kind: kindUnknown}
+		}
+
+		ft := g.resolve(spec)
+		ft.named = true
+
+		return ft
+
+	case *ast.ParenExpr:
+		return g.resolve(t.X)
+
+	case *ast.StarExpr:
+		return fieldType{kind: kindPtr, elem: t.X}
+
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return fieldType{kind: kindSlice, elem: t.Elt}
+		}
+
+		return fieldType{kind: kindArray, elem: t.Elt}
+
+	case *ast.MapType:
+		return fieldType{kind: kindMap, key: t.Key, elem: t.Value}
+
+	case *ast.SelectorExpr:
+		if pkg, ok := t.X.(*ast.Ident); ok && pkg.Name == "time" && t.Sel.Name == "Time" {
+			return fieldType{kind: kindTime}
+		}
+
+	case *ast.InterfaceType:
+		return fieldType{kind: kindInterface}
+
+	case *ast.ChanType, *ast.FuncType:
+		return fieldType{kind: kindOther}
+	}
+
+	return fieldType{kind: kindUnknown}
+}
+
+// structResult generates the validations of the struct name, once.
+func (g *generator) structResult(name string) *result {
+
+	if r, ok := g.results[name]; ok {
+		return r
+	}
+
+	r := &result{inProg: true}
+	g.results[name] = r
+
+	sg := &structGen{g: g, uses: make(map[string]bool), deps: make(map[string]bool)}
+
+	if g.methods[name]["ValidateStruct"] || g.methods[name]["ValidateStructCtx"] {
+		r.err = fmt.Errorf("struct level validations, of Validatable, cannot be generated")
+	} else {
+		r.err = sg.fields(g.specs[name].(*ast.StructType))
+	}
+
+	r.inProg = false
+
+	if r.err != nil {
+		return r
+	}
+
+	r.code = sg.buf.Bytes()
+	r.uses = sg.uses
+
+	for dep := range sg.deps {
+		r.deps = append(r.deps, dep)
+	}
+
+	sort.Strings(r.deps)
+
+	return r
+}
+
+// propagateFailures fails every struct depending on one that failed; needed as a
+// struct currently being generated is assumed to succeed when it is recursed into.
+func (g *generator) propagateFailures() {
+
+	for changed := true; changed; {
+
+		changed = false
+
+		for name, r := range g.results {
+
+			if r.err != nil {
+				continue
+			}
+
+			for _, dep := range r.deps {
+
+				if g.results[dep].err != nil {
+					r.err = fmt.Errorf("%s: %s", dep, g.results[dep].err)
+					changed = true
+					break
+				}
+			}
+
+			if r.err != nil {
+				g.reasons[name] = r.err.Error()
+			}
+		}
+	}
+}
+
+// write assembles the output file.
+func (g *generator) write(targets []string) ([]byte, error) {
+
+	isTarget := make(map[string]bool, len(targets))
+	needed := make(map[string]bool)
+	uses := make(map[string]bool)
+
+	var need func(name string)
+
+	need = func(name string) {
+
+		if needed[name] {
+			return
+		}
+
+		needed[name] = true
+
+		r := g.results[name]
+
+		for pkg := range r.uses {
+			uses[pkg] = true
+		}
+
+		for _, dep := range r.deps {
+			need(dep)
+		}
+	}
+
+	for _, name := range targets {
+
+		isTarget[name] = true
+
+		if r := g.results[name]; r.err != nil {
+			g.reasons[name] = r.err.Error()
+			g.fallbacks = append(g.fallbacks, name)
+			continue
+		}
+
+		need(name)
+	}
+
+	var buf bytes.Buffer
+
+	buf.WriteString("// Code generated by validator-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\nimport (\n", g.pkgName)
+
+	for _, pkg := range []string{"fmt", "strconv", "strings", "unicode/utf8"} {
+		if uses[pkg] {
+			fmt.Fprintf(&buf, "\t%q\n", pkg)
+		}
+	}
+
+	fmt.Fprintf(&buf, "\n\t%q\n)\n\n", g.importPath)
+
+	if g.instance == defaultInstance {
+		fmt.Fprintf(&buf, "// %s is the instance used by the generated validators, for the structs that could not\n", g.instance)
+		buf.WriteString("// be generated and for the errors returned, so that its translations are used. It may be\n")
+		buf.WriteString("// replaced by the application's own instance, as long as it is configured the same way.\n")
+		fmt.Fprintf(&buf, "var %s = validator.New()\n\n", g.instance)
+	}
+
+	for _, name := range g.order {
+
+		if isTarget[name] {
+
+			if r := g.results[name]; r.err != nil {
+				fmt.Fprintf(&buf, "// Validate validates %s by calling Struct, as its validations could not be generated;\n", name)
+				fmt.Fprintf(&buf, "// %s\n", r.err)
+				fmt.Fprintf(&buf, "func (x *%s) Validate() error {\n\treturn %s.Struct(x)\n}\n\n", name, g.instance)
+				continue
+			}
+
+			fmt.Fprintf(&buf, "// Validate validates the fields of %s, returning the same errors as calling Struct would.\n", name)
+			fmt.Fprintf(&buf, "func (x *%s) Validate() error {\n\n", name)
+			fmt.Fprintf(&buf, "\tif x == nil {\n\t\treturn %s.Struct(x)\n\t}\n\n", g.instance)
+			fmt.Fprintf(&buf, "\tif errs := x.validateGen(%q, %q, nil); len(errs) > 0 {\n\t\treturn errs\n\t}\n\n", name+".", name+".")
+			buf.WriteString("\treturn nil\n}\n\n")
+		}
+
+		if needed[name] {
+			fmt.Fprintf(&buf, "// validateGen appends the errors of the fields of %s, namespaced by ns and structNs, to errs.\n", name)
+			fmt.Fprintf(&buf, "func (x *%s) validateGen(ns, structNs string, errs validator.ValidationErrors) validator.ValidationErrors {\n\n", name)
+			buf.Write(g.results[name].code)
+			buf.WriteString("\treturn errs\n}\n\n")
+		}
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// structGen generates the validations of a single struct
+type structGen struct {
+	g    *generator
+	buf  bytes.Buffer
+	uses map[string]bool // packages used by the generated code
+	deps map[string]bool // structs whose validateGen is called
+	vars int             // loop variables declared
+}
+
+// fields generates the validations of every field of st; failing with the reasons of
+// each field that can't be generated, so they can all be reported at once.
+func (sg *structGen) fields(st *ast.StructType) error {
+
+	var reasons []string
+
+	for _, fld := range st.Fields.List {
+
+		s := sg.g.fieldTag(fld)
+
+		if len(fld.Names) > 0 && len(sg.g.structTag(fld).Get(modTagName)) > 0 {
+			reasons = append(reasons, fmt.Sprintf("field %s: modifiers cannot be generated", fld.Names[0].Name))
+			continue
+		}
+
+		if s == "-" {
+			continue
+		}
+
+		if len(fld.Names) == 0 {
+			reasons = append(reasons, fmt.Sprintf("embedded field %s cannot be generated", types.ExprString(fld.Type)))
+			continue
+		}
+
+		var tags []tag
+
+		if len(s) > 0 {
+
+			var err error
+
+			if tags, err = parseTags(s); err != nil {
+				reasons = append(reasons, fmt.Sprintf("field %s: %s", fld.Names[0].Name, err))
+				continue
+			}
+		}
+
+		for _, ident := range fld.Names {
+
+			if !ident.IsExported() {
+				continue
+			}
+
+			f := field{expr: "x." + ident.Name, name: strconv.Quote(ident.Name), display: ident.Name}
+
+			if err := sg.field(&sg.buf, f, fld.Type, tags); err != nil {
+				reasons = append(reasons, fmt.Sprintf("field %s: %s", ident.Name, err))
+			}
+		}
+	}
+
+	if len(reasons) > 0 {
+		return errors.New(strings.Join(reasons, "; "))
+	}
+
+	return nil
+}
+
+// parseTags parses a tag the same way the validator does, failing on any validation
+// that can't be generated.
+func parseTags(s string) ([]tag, error) {
+
+	var tags []tag
+
+	for _, t := range strings.Split(s, tagSeparator) {
+
+		if strings.Contains(t, orSeparator) {
+			return nil, fmt.Errorf("or validation '%s' cannot be generated", t)
+		}
+
+		vals := strings.SplitN(t, tagKeySeparator, 2)
+
+		if _, ok := generatedTags[vals[0]]; !ok {
+			return nil, fmt.Errorf("validation '%s' cannot be generated", vals[0])
+		}
+
+		tg := tag{name: vals[0]}
+
+		if len(vals) > 1 {
+			tg.param = strings.Replace(strings.Replace(vals[1], utf8HexComma, ",", -1), utf8Pipe, "|", -1)
+		}
+
+		tags = append(tags, tg)
+	}
+
+	return tags, nil
+}
+
+// field writes the validations of f, of type typ, to w.
+func (sg *structGen) field(w *bytes.Buffer, f field, typ ast.Expr, tags []tag) error {
+
+	ft := sg.g.resolve(typ)
+
+	switch ft.kind {
+	case kindString, kindBool, kindInt, kindUint, kindFloat, kindSlice, kindArray, kindMap:
+		if len(tags) == 0 {
+			return nil
+		}
+
+		return sg.checks(w, f, ft, tags, false)
+
+	case kindPtr:
+		return sg.pointer(w, f, ft, tags)
+
+	case kindStruct:
+		if len(tags) > 0 && tags[0].name == isdefault {
+			return fmt.Errorf("validation '%s' on a struct cannot be generated", isdefault)
+		}
+
+		return sg.nested(w, f, f.expr, ft.name)
+
+	case kindTime, kindOther:
+		if len(tags) == 0 {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("type %s cannot be generated", types.ExprString(typ))
+}
+
+// pointer writes the validations of the pointer f to w; a nil pointer fails on its first
+// validation, unless omitempty or isdefault, otherwise the value pointed to is validated.
+func (sg *structGen) pointer(w *bytes.Buffer, f field, ft fieldType, tags []tag) error {
+
+	elem := sg.g.resolve(ft.elem)
+
+	nilFails := len(tags) > 0 && tags[0].name != omitempty && tags[0].name != isdefault
+
+	var body bytes.Buffer
+
+	switch elem.kind {
+	case kindString, kindBool, kindInt, kindUint, kindFloat:
+		if len(tags) == 0 {
+			return nil
+		}
+
+		if err := sg.checks(&body, field{expr: "*" + f.expr, name: f.name, display: f.display}, elem, tags, true); err != nil {
+			return err
+		}
+
+	case kindStruct:
+		if len(tags) > 0 && tags[0].name == isdefault {
+			return fmt.Errorf("validation '%s' on a struct cannot be generated", isdefault)
+		}
+
+		if err := sg.nested(&body, f, f.expr, elem.name); err != nil {
+			return err
+		}
+
+	case kindTime, kindOther, kindSlice, kindArray, kindMap:
+		if len(tags) == 0 {
+			return nil
+		}
+
+		return fmt.Errorf("validations on type %s cannot be generated", types.ExprString(ft.elem))
+
+	default:
+		return fmt.Errorf("type *%s cannot be generated", types.ExprString(ft.elem))
+	}
+
+	switch {
+	case nilFails && body.Len() > 0:
+		fmt.Fprintf(w, "\tif %s == nil {\n", f.expr)
+		sg.fail(w, f, tags[0], f.expr)
+		w.WriteString("\t} else {\n")
+		w.Write(bytes.TrimRight(body.Bytes(), "\n"))
+		w.WriteString("\n\t}\n\n")
+
+	case nilFails:
+		fmt.Fprintf(w, "\tif %s == nil {\n", f.expr)
+		sg.fail(w, f, tags[0], f.expr)
+		w.WriteString("\t}\n\n")
+
+	case body.Len() > 0:
+		fmt.Fprintf(w, "\tif %s != nil {\n", f.expr)
+		w.Write(bytes.TrimRight(body.Bytes(), "\n"))
+		w.WriteString("\n\t}\n\n")
+	}
+
+	return nil
+}
+
+// nested writes the call to the generated validations of the struct name, any validations on
+// the field itself being ignored the same as the validator does.
+func (sg *structGen) nested(w *bytes.Buffer, f field, expr, name string) error {
+
+	if r := sg.g.structResult(name); !r.inProg && r.err != nil {
+		return fmt.Errorf("%s: %s", name, r.err)
+	}
+
+	sg.deps[name] = true
+
+	ns := concat(f.name, `"."`)
+
+	fmt.Fprintf(w, "\terrs = %s.validateGen(%s, %s, errs)\n\n", expr, concat("ns", ns), concat("structNs", ns))
+
+	return nil
+}
+
+// checks writes the validations of f to w, as a switch whose first matching case is the first
+// failed validation; diving into the elements of a slice, array or map when none failed.
+func (sg *structGen) checks(w *bytes.Buffer, f field, ft fieldType, tags []tag, isPointer bool) error {
+
+	pre, post, hasDive := tags, []tag(nil), false
+
+	for i, t := range tags {
+
+		if t.name == diveTag {
+			pre, post, hasDive = tags[:i], tags[i+1:], true
+			break
+		}
+	}
+
+	var cases bytes.Buffer
+
+	for _, t := range pre {
+
+		cond, omit, err := sg.cond(f.expr, ft, t, isPointer)
+		if err != nil {
+			return err
+		}
+
+		if len(cond) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&cases, "\tcase %s:\n", cond)
+
+		if omit {
+			cases.WriteString("\t\t// omitempty\n")
+			continue
+		}
+
+		sg.fail(&cases, f, t, f.expr)
+	}
+
+	var dive bytes.Buffer
+
+	if hasDive {
+		if err := sg.dive(&dive, f, ft, post); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case cases.Len() > 0:
+		w.WriteString("\tswitch {\n")
+		w.Write(cases.Bytes())
+
+		if dive.Len() > 0 {
+			w.WriteString("\tdefault:\n")
+			w.Write(dive.Bytes())
+		}
+
+		w.WriteString("\t}\n\n")
+
+	case dive.Len() > 0:
+		w.Write(dive.Bytes())
+		w.WriteString("\n")
+	}
+
+	return nil
+}
+
+// dive writes the loop validating each element of f with tags.
+func (sg *structGen) dive(w *bytes.Buffer, f field, ft fieldType, tags []tag) error {
+
+	switch ft.kind {
+	case kindSlice, kindArray, kindMap:
+	default:
+		return fmt.Errorf("dive on a non slice or map")
+	}
+
+	if len(tags) == 0 {
+
+		elem := sg.g.resolve(ft.elem)
+
+		if elem.kind == kindPtr {
+			elem = sg.g.resolve(elem.elem)
+		}
+
+		if elem.kind != kindStruct {
+			return fmt.Errorf("dive without any validations on type %s cannot be generated", types.ExprString(ft.elem))
+		}
+	}
+
+	sg.vars++
+
+	var loop string
+	var elem field
+
+	if ft.kind == kindMap {
+
+		key, val := "k"+strconv.Itoa(sg.vars), "v"+strconv.Itoa(sg.vars)
+
+		loop = fmt.Sprintf("\tfor %s, %s := range %s {\n", key, val, f.expr)
+		elem = field{expr: val, name: concat(f.name, `"["+fmt.Sprint(`+key+`)+"]"`), display: f.display + "[]"}
+
+		sg.uses["fmt"] = true
+
+	} else {
+
+		idx := "i" + strconv.Itoa(sg.vars)
+
+		loop = fmt.Sprintf("\tfor %s := range %s {\n", idx, f.expr)
+		elem = field{expr: f.expr + "[" + idx + "]", name: concat(f.name, `"["+strconv.Itoa(`+idx+`)+"]"`), display: f.display + "[]"}
+
+		sg.uses["strconv"] = true
+	}
+
+	var body bytes.Buffer
+
+	if err := sg.field(&body, elem, ft.elem, tags); err != nil {
+		return err
+	}
+
+	if body.Len() == 0 {
+		return nil
+	}
+
+	w.WriteString(loop)
+	w.Write(bytes.TrimRight(body.Bytes(), "\n"))
+	w.WriteString("\n\t}\n")
+
+	return nil
+}
+
+// fail writes the appending of the error for f failing t.
+func (sg *structGen) fail(w *bytes.Buffer, f field, t tag, value string) {
+
+	var param string
+
+	if len(t.param) > 0 {
+		param = fmt.Sprintf(", Param: %q", t.param)
+	}
+
+	fmt.Fprintf(w, "\t\terrs = append(errs, %s.NewFieldError(validator.FieldErrorOptions{Namespace: %s, StructNamespace: %s, Field: %s, Tag: %q%s, Value: %s}))\n",
+		sg.g.instance, concat("ns", f.name), concat("structNs", f.name), f.name, t.name, param, value)
+}
+
+// cond returns the condition under which v fails t; or when omit is true, under which the
+// remaining validations are skipped. A blank condition means t can never fail.
+func (sg *structGen) cond(v string, ft fieldType, t tag, isPointer bool) (cond string, omit bool, err error) {
+
+	zero, isZero, notZero := "", "", ""
+
+	switch ft.kind {
+	case kindString:
+		zero = `""`
+	case kindBool:
+		zero = "false"
+	case kindInt, kindUint, kindFloat:
+		zero = "0"
+	case kindSlice, kindMap:
+		zero = "nil"
+	}
+
+	if len(zero) > 0 {
+
+		if ft.kind == kindBool {
+			isZero, notZero = "!"+v, v
+		} else {
+			isZero, notZero = v+" == "+zero, v+" != "+zero
+		}
+	}
+
+	switch t.name {
+	case omitempty, "required", isdefault:
+
+		if len(zero) == 0 {
+			return "", false, fmt.Errorf("validation '%s' on an array cannot be generated", t.name)
+		}
+
+		// a non nil pointer always has a value
+		switch {
+		case t.name == isdefault && isPointer:
+			return "", false, fmt.Errorf("validation '%s' on a pointer cannot be generated", t.name)
+		case isPointer:
+			return "", false, nil
+		case t.name == omitempty:
+			return isZero, true, nil
+		case t.name == "required":
+			return isZero, false, nil
+		default:
+			return notZero, false, nil
+		}
+
+	case "len", "eq", "ne", "min", "max", "gt", "gte", "lt", "lte":
+
+		op := map[string]string{
+			"len": "!=",
+			"eq":  "!=",
+			"ne":  "==",
+			"min": "<",
+			"gte": "<",
+			"max": ">",
+			"lte": ">",
+			"gt":  "<=",
+			"lt":  ">=",
+		}[t.name]
+
+		if ft.kind == kindString && (t.name == "eq" || t.name == "ne") {
+			return v + " " + op + " " + strconv.Quote(t.param), false, nil
+		}
+
+		switch ft.kind {
+		case kindString:
+			p, err := strconv.ParseInt(t.param, 0, 64)
+			if err != nil {
+				return "", false, fmt.Errorf("invalid '%s' param '%s'", t.name, t.param)
+			}
+
+			sg.uses["unicode/utf8"] = true
+
+			return fmt.Sprintf("int64(utf8.RuneCountInString(%s)) %s %d", convert("string", v, ft.named), op, p), false, nil
+
+		case kindSlice, kindArray, kindMap:
+			p, err := strconv.ParseInt(t.param, 0, 64)
+			if err != nil {
+				return "", false, fmt.Errorf("invalid '%s' param '%s'", t.name, t.param)
+			}
+
+			return fmt.Sprintf("int64(len(%s)) %s %d", v, op, p), false, nil
+
+		case kindInt:
+			p, err := strconv.ParseInt(t.param, 0, 64)
+			if err != nil {
+				return "", false, fmt.Errorf("invalid '%s' param '%s'", t.name, t.param)
+			}
+
+			return fmt.Sprintf("int64(%s) %s %d", v, op, p), false, nil
+
+		case kindUint:
+			p, err := strconv.ParseUint(t.param, 0, 64)
+			if err != nil {
+				return "", false, fmt.Errorf("invalid '%s' param '%s'", t.name, t.param)
+			}
+
+			return fmt.Sprintf("uint64(%s) %s %d", v, op, p), false, nil
+
+		case kindFloat:
+			p, err := strconv.ParseFloat(t.param, 64)
+			if err != nil || strings.ContainsAny(t.param, "nN") {
+				return "", false, fmt.Errorf("invalid '%s' param '%s'", t.name, t.param)
+			}
+
+			return fmt.Sprintf("float64(%s) %s %s", v, op, strconv.FormatFloat(p, 'g', -1, 64)), false, nil
+		}
+
+	case "oneof":
+
+		var vals []string
+
+		for _, val := range strings.Fields(t.param) {
+
+			switch ft.kind {
+			case kindString:
+				vals = append(vals, v+" != "+strconv.Quote(val))
+
+			case kindInt:
+				// values are compared as strings, so only those formatted the same can ever match
+				if i, err := strconv.ParseInt(val, 10, 64); err == nil && strconv.FormatInt(i, 10) == val {
+					vals = append(vals, "int64("+v+") != "+val)
+				}
+
+			case kindUint:
+				if u, err := strconv.ParseUint(val, 10, 64); err == nil && strconv.FormatUint(u, 10) == val {
+					vals = append(vals, "uint64("+v+") != "+val)
+				}
+			}
+		}
+
+		if len(vals) > 0 {
+			return strings.Join(vals, " && "), false, nil
+		}
+
+	case "contains", "containsany", "excludes", "excludesall":
+
+		if ft.kind == kindString {
+
+			fn := map[string]string{
+				"contains":    "!strings.Contains",
+				"containsany": "!strings.ContainsAny",
+				"excludes":    "strings.Contains",
+				"excludesall": "strings.ContainsAny",
+			}[t.name]
+
+			sg.uses["strings"] = true
+
+			return fmt.Sprintf("%s(%s, %s)", fn, convert("string", v, ft.named), strconv.Quote(t.param)), false, nil
+		}
+	}
+
+	return "", false, fmt.Errorf("validation '%s' on this type cannot be generated", t.name)
+}
+
+// convert returns v converted to typ, when it is a named type.
+func convert(typ, v string, named bool) string {
+
+	if named {
+		return typ + "(" + v + ")"
+	}
+
+	return v
+}
+
+// concat returns the expression concatenating the expressions a and b, merging
+// the string literals where a ends and b begins.
+func concat(a, b string) string {
+
+	if strings.HasSuffix(a, `"`) && strings.HasPrefix(b, `"`) {
+		return a[:len(a)-1] + b[1:]
+	}
+
+	return a + "+" + b
+}
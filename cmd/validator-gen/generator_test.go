@@ -0,0 +1,282 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	. "gopkg.in/go-playground/assert.v1"
+)
+
+var parityTypes = []string{"Strings", "Numbers", "Pointers", "Collections", "Nested", "Fallback", "Referrer"}
+
+func TestGeneratedParityUpToDate(t *testing.T) {
+
+	g := &generator{tagName: "validate"}
+
+	src, err := g.generate("internal/parity", "validator_gen.go", parityTypes)
+	Equal(t, err, nil)
+
+	existing, err := ioutil.ReadFile("internal/parity/validator_gen.go")
+	Equal(t, err, nil)
+
+	// line endings depend on how the repository was checked out
+	if string(src) != strings.Replace(string(existing), "\r\n", "\n", -1) {
+		t.Fatal("internal/parity/validator_gen.go is out of date, run go generate ./...")
+	}
+
+	Equal(t, g.fallbacks, []string{"Fallback", "Referrer"})
+	Equal(t, g.reasons["Fallback"], "field Email: validation 'email' cannot be generated; field Confirm: validation 'eqfield' cannot be generated; field Color: validation 'iscolor' cannot be generated; field Or: or validation 'rgb|rgba' cannot be generated")
+	Equal(t, g.reasons["Referrer"], "field Fallback: Fallback: field Email: validation 'email' cannot be generated; field Confirm: validation 'eqfield' cannot be generated; field Color: validation 'iscolor' cannot be generated; field Or: or validation 'rgb|rgba' cannot be generated")
+}
+
+// TestParityCoversGeneratedTags ensures internal/parity checks every generated validation
+// against Struct, parity is only claimed for those.
+func TestParityCoversGeneratedTags(t *testing.T) {
+
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, "internal/parity/parity.go", nil, 0)
+	Equal(t, err, nil)
+
+	covered := make(map[string]bool)
+
+	ast.Inspect(file, func(n ast.Node) bool {
+
+		f, ok := n.(*ast.Field)
+		if !ok || f.Tag == nil {
+			return true
+		}
+
+		s, err := strconv.Unquote(f.Tag.Value)
+		Equal(t, err, nil)
+
+		if tags, err := parseTags(reflect.StructTag(s).Get("validate")); err == nil {
+			for _, tg := range tags {
+				covered[tg.name] = true
+			}
+		}
+
+		return true
+	})
+
+	for name := range generatedTags {
+		if !covered[name] {
+			t.Errorf("validation '%s' is generated but not checked by internal/parity", name)
+		}
+	}
+}
+
+func TestGenerateFallbacks(t *testing.T) {
+
+	dir := writePackage(t, `package test
+
+import (
+	"sync"
+	"time"
+
+	"package/validator"
+)
+
+type Inner struct {
+	Name string `+"`validate:\"required\"`"+`
+}
+
+type Or struct {
+	Color string `+"`validate:\"rgb|rgba\"`"+`
+}
+
+type CrossField struct {
+	Password string
+	Confirm  string `+"`validate:\"eqfield=Password\"`"+`
+}
+
+type Embedded struct {
+	Inner
+	Name string `+"`validate:\"required\"`"+`
+}
+
+type DiveNonSlice struct {
+	Name string `+"`validate:\"dive,required\"`"+`
+}
+
+type BadParam struct {
+	Age int `+"`validate:\"min=one\"`"+`
+}
+
+type OneOfFloat struct {
+	Value float64 `+"`validate:\"oneof=1 2\"`"+`
+}
+
+type Time struct {
+	Created time.Time `+"`validate:\"required\"`"+`
+}
+
+type Foreign struct {
+	Mu   sync.Mutex
+	Name string `+"`validate:\"required\"`"+`
+}
+
+type Keys struct {
+	Map map[string]string `+"`validate:\"dive,keys,required,endkeys\"`"+`
+}
+
+type DiveNoTags struct {
+	Names []string `+"`validate:\"dive\"`"+`
+}
+
+type DefaultPointer struct {
+	Name *string `+"`validate:\"isdefault\"`"+`
+}
+
+type Modified struct {
+	Name string `+"`mod:\"trim\" validate:\"required\"`"+`
+}
+
+type MultiField struct {
+	Email   string `+"`validate:\"email\"`"+`
+	Name    string `+"`validate:\"required\"`"+`
+	Confirm string `+"`validate:\"eqfield=Email\"`"+`
+}
+
+type Valid struct {
+	Inner    Inner
+	Created  time.Time
+	Inners   []Inner `+"`validate:\"dive\"`"+`
+	Ignored  sync.Mutex `+"`validate:\"-\"`"+`
+	internal string
+}
+
+type Existing struct {
+	Name string `+"`validate:\"required\"`"+`
+}
+
+func (e *Existing) Validate() error { return nil }
+
+type SelfValidating struct {
+	Name string `+"`validate:\"required\"`"+`
+}
+
+func (s *SelfValidating) ValidateStruct(sl validator.StructLevel) {}
+`)
+
+	g := &generator{tagName: "validate"}
+
+	src, err := g.generate(dir, "validator_gen.go", nil)
+	Equal(t, err, nil)
+
+	Equal(t, g.fallbacks, []string{"Or", "CrossField", "Embedded", "DiveNonSlice", "BadParam", "OneOfFloat", "Time", "Foreign", "Keys", "DiveNoTags", "DefaultPointer", "Modified", "MultiField", "SelfValidating"})
+
+	Equal(t, g.reasons["Or"], "field Color: or validation 'rgb|rgba' cannot be generated")
+	Equal(t, g.reasons["CrossField"], "field Confirm: validation 'eqfield' cannot be generated")
+	Equal(t, g.reasons["Embedded"], "embedded field Inner cannot be generated")
+	Equal(t, g.reasons["DiveNonSlice"], "field Name: dive on a non slice or map")
+	Equal(t, g.reasons["BadParam"], "field Age: invalid 'min' param 'one'")
+	Equal(t, g.reasons["OneOfFloat"], "field Value: validation 'oneof' on this type cannot be generated")
+	Equal(t, g.reasons["Time"], "field Created: type time.Time cannot be generated")
+	Equal(t, g.reasons["Foreign"], "field Mu: type sync.Mutex cannot be generated")
+	Equal(t, g.reasons["Keys"], "field Map: validation 'keys' cannot be generated")
+	Equal(t, g.reasons["DiveNoTags"], "field Names: dive without any validations on type string cannot be generated")
+	Equal(t, g.reasons["DefaultPointer"], "field Name: validation 'isdefault' on a pointer cannot be generated")
+	Equal(t, g.reasons["Modified"], "field Name: modifiers cannot be generated")
+	Equal(t, g.reasons["MultiField"], "field Email: validation 'email' cannot be generated; field Confirm: validation 'eqfield' cannot be generated")
+	Equal(t, g.reasons["SelfValidating"], "struct level validations, of Validatable, cannot be generated")
+
+	out := string(src)
+
+	// Existing already has a Validate method and Inner is only generated as it's nested
+	Equal(t, strings.Contains(out, "func (x *Existing)"), false)
+	Equal(t, strings.Contains(out, "func (x *Inner) validateGen("), true)
+	Equal(t, strings.Contains(out, "func (x *Inner) Validate()"), true)
+	Equal(t, strings.Contains(out, "func (x *Valid) validateGen("), true)
+	Equal(t, strings.Contains(out, "func (x *Or) validateGen("), false)
+	Equal(t, strings.Contains(out, "func (x *Or) Validate() error {\n\treturn validatorGen.Struct(x)\n}"), true)
+	Equal(t, strings.Contains(out, "var validatorGen = validator.New()"), true)
+}
+
+func TestGenerateOptions(t *testing.T) {
+
+	dir := writePackage(t, `package test
+
+import "package/validator"
+
+var validate = validator.New()
+
+type Inner struct {
+	Name string `+"`valid:\"required\"`"+`
+}
+
+type Outer struct {
+	Inner Inner
+	Age   int `+"`valid:\"gte=18\"`"+`
+}
+`)
+
+	g := &generator{tagName: "valid", instance: "validate", importPath: "example.com/validator"}
+
+	src, err := g.generate(dir, "validator_gen.go", []string{"Outer"})
+	Equal(t, err, nil)
+	Equal(t, len(g.fallbacks), 0)
+
+	out := string(src)
+
+	Equal(t, strings.Contains(out, "\"example.com/validator\""), true)
+	Equal(t, strings.Contains(out, "var validatorGen"), false)
+	Equal(t, strings.Contains(out, "validate.NewFieldError(validator.FieldErrorOptions{Namespace: ns + \"Age\""), true)
+	Equal(t, strings.Contains(out, "func (x *Outer) Validate() error"), true)
+	Equal(t, strings.Contains(out, "func (x *Inner) Validate() error"), false)
+	Equal(t, strings.Contains(out, "errs = x.Inner.validateGen(ns+\"Inner.\", structNs+\"Inner.\", errs)"), true)
+}
+
+func TestGenerateErrors(t *testing.T) {
+
+	dir := writePackage(t, `package test
+
+type NoTags struct {
+	Name string
+}
+
+type Existing struct {
+	Name string `+"`validate:\"required\"`"+`
+}
+
+func (e Existing) Validate() error { return nil }
+`)
+
+	g := &generator{tagName: "validate"}
+
+	_, err := g.generate(dir, "validator_gen.go", nil)
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "no structs with 'validate' tags found in "+dir)
+
+	_, err = g.generate(dir, "validator_gen.go", []string{"Missing"})
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "struct type Missing not found in "+dir)
+
+	_, err = g.generate(dir, "validator_gen.go", []string{"Existing"})
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "Existing already has a Validate method")
+
+	_, err = g.generate(filepath.Join(dir, "missing"), "validator_gen.go", nil)
+	NotEqual(t, err, nil)
+}
+
+func writePackage(t *testing.T, src string) string {
+
+	dir, err := ioutil.TempDir("", "validator-gen")
+	Equal(t, err, nil)
+
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	err = ioutil.WriteFile(filepath.Join(dir, "test.go"), []byte(src), 0644)
+	Equal(t, err, nil)
+
+	return dir
+}
@@ -0,0 +1,106 @@
+// Package parity holds the structs used to prove that the validators generated by
+// validator-gen return the same errors as the validator itself.
+package parity
+
+//go:generate go run package/validator/cmd/validator-gen -type Strings,Numbers,Pointers,Collections,Nested,Fallback,Referrer
+
+// Status is a named string type
+type Status string
+
+// Count is a named int type
+type Count int
+
+// Strings has a field per validation generated for strings
+type Strings struct {
+	Required    string `validate:"required"`
+	Len         string `validate:"len=3"`
+	Min         string `validate:"min=2"`
+	Max         string `validate:"max=4"`
+	Eq          string `validate:"eq=abc"`
+	Ne          string `validate:"ne=abc"`
+	Gt          string `validate:"gt=1"`
+	Gte         string `validate:"gte=1"`
+	Lt          string `validate:"lt=3"`
+	Lte         string `validate:"lte=3"`
+	OneOf       string `validate:"oneof=red green"`
+	Contains    string `validate:"contains=@"`
+	ContainsAny string `validate:"containsany=!@"`
+	Excludes    string `validate:"excludes=0x2C"`
+	ExcludesAll string `validate:"excludesall=0x7C!"`
+	Omit        string `validate:"omitempty,min=3"`
+	IsDefault   string `validate:"isdefault"`
+	Multi       string `validate:"required,min=2,max=5"`
+	Status      Status `validate:"omitempty,oneof=active inactive,min=6"`
+	Skipped     string `validate:"-"`
+	NoTag       string
+}
+
+// Numbers has a field per validation generated for numbers and bools
+type Numbers struct {
+	Int      int     `validate:"required,min=1,max=10"`
+	Int8     int8    `validate:"gt=-5,lt=100"`
+	Uint     uint    `validate:"oneof=1 2 3"`
+	Uint16   uint16  `validate:"gte=10,lte=32"`
+	Float    float64 `validate:"omitempty,gt=0.5,lte=1e3"`
+	Float32  float32 `validate:"eq=1.5"`
+	IntOneOf int     `validate:"oneof=-1 01 2"`
+	Ne       int64   `validate:"ne=0"`
+	Len      int32   `validate:"len=7"`
+	Bool     bool    `validate:"required"`
+	Default  bool    `validate:"isdefault"`
+	Count    Count   `validate:"omitempty,max=3"`
+}
+
+// Inner is nested within the other structs
+type Inner struct {
+	Name string   `validate:"required"`
+	Tags []string `validate:"dive,min=2"`
+}
+
+// Pointers has pointer fields, which fail on their first validation when nil
+type Pointers struct {
+	Required *string  `validate:"required,min=2"`
+	Omit     *int     `validate:"omitempty,gt=1"`
+	Min      *float64 `validate:"min=1"`
+	Bool     *bool    `validate:"required"`
+	Inner    *Inner   `validate:"required"`
+	OptInner *Inner
+	NoTag    *string
+}
+
+// Collections has slice, array and map fields, diving into their elements
+type Collections struct {
+	Slice     []string       `validate:"required,min=1,dive,required,max=3"`
+	Omit      []int          `validate:"omitempty,max=2,dive,gt=0"`
+	Array     [2]int         `validate:"len=2,dive,ne=0"`
+	Map       map[string]int `validate:"required,dive,min=1"`
+	Nested    [][]string     `validate:"dive,min=1,dive,oneof=a b"`
+	Inners    []Inner        `validate:"dive"`
+	InnerPtrs []*Inner       `validate:"omitempty,dive"`
+	InnerMap  map[int]*Inner `validate:"dive,required"`
+	Ptrs      []*string      `validate:"dive,omitempty,min=2"`
+	Untagged  []Inner
+}
+
+// Nested has nested structs, including itself
+type Nested struct {
+	Inner    Inner
+	Pointers Pointers
+	Self     *Nested
+	Name     string `validate:"required"`
+}
+
+// Fallback uses validations that can't be generated, so calls Struct
+type Fallback struct {
+	Email    string `validate:"email"`
+	Password string `validate:"required"`
+	Confirm  string `validate:"eqfield=Password"`
+	Color    string `validate:"omitempty,iscolor"`
+	Or       string `validate:"omitempty,rgb|rgba"`
+}
+
+// Referrer nests a struct that can't be generated, so calls Struct too
+type Referrer struct {
+	Fallback Fallback
+	Name     string `validate:"required"`
+}
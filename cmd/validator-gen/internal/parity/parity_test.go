@@ -0,0 +1,181 @@
+package parity
+
+import (
+	"sort"
+	"testing"
+
+	english "package/locales/en"
+	ut "package/universal-translator"
+	"package/validator"
+	en_translations "package/validator/translations/en"
+
+	. "gopkg.in/go-playground/assert.v1"
+)
+
+// NOTES:
+// - Run "go generate" after changing parity.go to regenerate validator_gen.go
+// - Every value is validated by both the generated Validate method and Struct, the
+//   errors of both must be identical, including their translations
+// - Parity is only checked for the 18 validations validator-gen generates, every one
+//   of which must be used in parity.go, see TestParityCoversGeneratedTags; the structs
+//   falling back to Struct, eg. Fallback, only check the fallback is used
+
+type validatable interface {
+	Validate() error
+}
+
+func TestParity(t *testing.T) {
+
+	eng := english.New()
+	uni := ut.New(eng, eng)
+	trans, _ := uni.GetTranslator("en")
+
+	validatorGen = validator.New()
+
+	err := en_translations.RegisterDefaultTranslations(validatorGen, trans)
+	Equal(t, err, nil)
+
+	str := func(s string) *string { return &s }
+	i := func(i int) *int { return &i }
+	f := func(f float64) *float64 { return &f }
+	b := func(b bool) *bool { return &b }
+
+	tests := []validatable{
+		&Strings{},
+		&Strings{
+			Required:    "a",
+			Len:         "abc",
+			Min:         "ab",
+			Max:         "abcd",
+			Eq:          "abc",
+			Ne:          "abd",
+			Gt:          "ab",
+			Gte:         "a",
+			Lt:          "ab",
+			Lte:         "abc",
+			OneOf:       "green",
+			Contains:    "a@b",
+			ContainsAny: "!",
+			Excludes:    "ab",
+			ExcludesAll: "ab",
+			Multi:       "abcde",
+			Status:      "active",
+		},
+		&Strings{
+			Len:         "日本語",
+			Min:         "日",
+			Max:         "abcde",
+			Eq:          "ab",
+			Ne:          "abc",
+			Gt:          "a",
+			Lt:          "abc",
+			Lte:         "abcd",
+			OneOf:       "blue",
+			Contains:    "ab",
+			ContainsAny: "ab",
+			Excludes:    "a,b",
+			ExcludesAll: "a|b",
+			Omit:        "ab",
+			IsDefault:   "set",
+			Multi:       "a",
+			Status:      "other",
+			Skipped:     "ignored",
+		},
+		&Strings{Multi: "abcdef", Status: "inactive", Omit: "abc"},
+		(*Strings)(nil),
+		&Numbers{},
+		&Numbers{Int: 5, Int8: -4, Uint: 3, Uint16: 32, Float: 1000, Float32: 1.5, IntOneOf: -1, Ne: 1, Len: 7, Bool: true, Count: 3},
+		&Numbers{Int: -1, Int8: -5, Uint: 4, Uint16: 9, Float: 0.5, Float32: 1.4, IntOneOf: 1, Len: 6, Default: true, Count: 4},
+		&Numbers{Int: 11, Int8: 100, Uint16: 33, Float: 1000.5, IntOneOf: 2},
+		&Pointers{},
+		&Pointers{Required: str(""), Omit: i(1), Min: f(0.5), Bool: b(false), Inner: &Inner{}, OptInner: &Inner{Tags: []string{"a"}}},
+		&Pointers{Required: str("ab"), Omit: i(2), Min: f(1), Bool: b(true), Inner: &Inner{Name: "a", Tags: []string{"ab", "abc"}}, NoTag: str("")},
+		&Collections{},
+		&Collections{
+			Slice:     []string{},
+			Omit:      []int{},
+			Array:     [2]int{1, 0},
+			Map:       map[string]int{},
+			Nested:    [][]string{{}, {"a", "c"}, nil},
+			Inners:    []Inner{{}, {Name: "a", Tags: []string{"a"}}},
+			InnerPtrs: []*Inner{nil, {}},
+			InnerMap:  map[int]*Inner{1: nil, 2: {Name: "a"}, 3: {}},
+			Ptrs:      []*string{nil, str("a"), str("ab")},
+			Untagged:  []Inner{{}},
+		},
+		&Collections{
+			Slice:     []string{"", "abcd", "abc"},
+			Omit:      []int{1, 2, 3},
+			Array:     [2]int{1, 2},
+			Map:       map[string]int{"a": 0, "b": 1, "c": -1},
+			Nested:    [][]string{{"a", "b"}},
+			InnerPtrs: nil,
+		},
+		&Collections{Slice: []string{"a"}, Omit: []int{0, 1}, Map: map[string]int{"a": 1}},
+		&Nested{},
+		&Nested{
+			Inner:    Inner{Name: "a"},
+			Pointers: Pointers{Required: str("a")},
+			Self:     &Nested{Self: &Nested{Name: "a"}},
+			Name:     "a",
+		},
+		&Fallback{},
+		&Fallback{Email: "a@b.com", Password: "a", Confirm: "b", Color: "red", Or: "rgb(0,0,0)"},
+		&Referrer{},
+		&Referrer{Fallback: Fallback{Email: "a@b.com", Password: "a", Confirm: "a"}, Name: "a"},
+	}
+
+	for idx, tt := range tests {
+
+		expected := validatorGen.Struct(tt)
+		actual := tt.Validate()
+
+		if expected == nil || actual == nil {
+			Equal(t, actual, expected)
+			continue
+		}
+
+		expectedErrs, ok := expected.(validator.ValidationErrors)
+		if !ok {
+			Equal(t, actual.Error(), expected.Error())
+			continue
+		}
+
+		actualErrs, ok := actual.(validator.ValidationErrors)
+		Equal(t, ok, true)
+
+		if len(actualErrs) != len(expectedErrs) {
+			t.Fatalf("test %d: expected %d errors, got %d\nexpected:\n%s\nactual:\n%s", idx, len(expectedErrs), len(actualErrs), expected, actual)
+		}
+
+		// map entries are validated in no particular order
+		sortErrors(expectedErrs)
+		sortErrors(actualErrs)
+
+		for j := range expectedErrs {
+
+			e, a := expectedErrs[j], actualErrs[j]
+
+			Equal(t, a.Tag(), e.Tag())
+			Equal(t, a.ActualTag(), e.ActualTag())
+			Equal(t, a.Namespace(), e.Namespace())
+			Equal(t, a.StructNamespace(), e.StructNamespace())
+			Equal(t, a.Field(), e.Field())
+			Equal(t, a.StructField(), e.StructField())
+			Equal(t, a.Value(), e.Value())
+			Equal(t, a.Param(), e.Param())
+			Equal(t, a.Kind(), e.Kind())
+			Equal(t, a.Type() == e.Type(), true)
+			Equal(t, a.Path().String(), e.Path().String())
+			Equal(t, a.Path().JSONPointer(), e.Path().JSONPointer())
+			Equal(t, a.(error).Error(), e.(error).Error())
+			Equal(t, a.Translate(trans), e.Translate(trans))
+		}
+	}
+}
+
+func sortErrors(errs validator.ValidationErrors) {
+	sort.SliceStable(errs, func(i, j int) bool {
+		return errs[i].Namespace() < errs[j].Namespace()
+	})
+}
@@ -0,0 +1,478 @@
+// Code generated by validator-gen. DO NOT EDIT.
+
+package parity
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"package/validator"
+)
+
+// validatorGen is the instance used by the generated validators, for the structs that could not
+// be generated and for the errors returned, so that its translations are used. It may be
+// replaced by the application's own instance, as long as it is configured the same way.
+var validatorGen = validator.New()
+
+// Validate validates the fields of Strings, returning the same errors as calling Struct would.
+func (x *Strings) Validate() error {
+
+	if x == nil {
+		return validatorGen.Struct(x)
+	}
+
+	if errs := x.validateGen("Strings.", "Strings.", nil); len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// validateGen appends the errors of the fields of Strings, namespaced by ns and structNs, to errs.
+func (x *Strings) validateGen(ns, structNs string, errs validator.ValidationErrors) validator.ValidationErrors {
+
+	switch {
+	case x.Required == "":
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Required", StructNamespace: structNs + "Required", Field: "Required", Tag: "required", Value: x.Required}))
+	}
+
+	switch {
+	case int64(utf8.RuneCountInString(x.Len)) != 3:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Len", StructNamespace: structNs + "Len", Field: "Len", Tag: "len", Param: "3", Value: x.Len}))
+	}
+
+	switch {
+	case int64(utf8.RuneCountInString(x.Min)) < 2:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Min", StructNamespace: structNs + "Min", Field: "Min", Tag: "min", Param: "2", Value: x.Min}))
+	}
+
+	switch {
+	case int64(utf8.RuneCountInString(x.Max)) > 4:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Max", StructNamespace: structNs + "Max", Field: "Max", Tag: "max", Param: "4", Value: x.Max}))
+	}
+
+	switch {
+	case x.Eq != "abc":
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Eq", StructNamespace: structNs + "Eq", Field: "Eq", Tag: "eq", Param: "abc", Value: x.Eq}))
+	}
+
+	switch {
+	case x.Ne == "abc":
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Ne", StructNamespace: structNs + "Ne", Field: "Ne", Tag: "ne", Param: "abc", Value: x.Ne}))
+	}
+
+	switch {
+	case int64(utf8.RuneCountInString(x.Gt)) <= 1:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Gt", StructNamespace: structNs + "Gt", Field: "Gt", Tag: "gt", Param: "1", Value: x.Gt}))
+	}
+
+	switch {
+	case int64(utf8.RuneCountInString(x.Gte)) < 1:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Gte", StructNamespace: structNs + "Gte", Field: "Gte", Tag: "gte", Param: "1", Value: x.Gte}))
+	}
+
+	switch {
+	case int64(utf8.RuneCountInString(x.Lt)) >= 3:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Lt", StructNamespace: structNs + "Lt", Field: "Lt", Tag: "lt", Param: "3", Value: x.Lt}))
+	}
+
+	switch {
+	case int64(utf8.RuneCountInString(x.Lte)) > 3:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Lte", StructNamespace: structNs + "Lte", Field: "Lte", Tag: "lte", Param: "3", Value: x.Lte}))
+	}
+
+	switch {
+	case x.OneOf != "red" && x.OneOf != "green":
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "OneOf", StructNamespace: structNs + "OneOf", Field: "OneOf", Tag: "oneof", Param: "red green", Value: x.OneOf}))
+	}
+
+	switch {
+	case !strings.Contains(x.Contains, "@"):
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Contains", StructNamespace: structNs + "Contains", Field: "Contains", Tag: "contains", Param: "@", Value: x.Contains}))
+	}
+
+	switch {
+	case !strings.ContainsAny(x.ContainsAny, "!@"):
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "ContainsAny", StructNamespace: structNs + "ContainsAny", Field: "ContainsAny", Tag: "containsany", Param: "!@", Value: x.ContainsAny}))
+	}
+
+	switch {
+	case strings.Contains(x.Excludes, ","):
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Excludes", StructNamespace: structNs + "Excludes", Field: "Excludes", Tag: "excludes", Param: ",", Value: x.Excludes}))
+	}
+
+	switch {
+	case strings.ContainsAny(x.ExcludesAll, "|!"):
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "ExcludesAll", StructNamespace: structNs + "ExcludesAll", Field: "ExcludesAll", Tag: "excludesall", Param: "|!", Value: x.ExcludesAll}))
+	}
+
+	switch {
+	case x.Omit == "":
+		// omitempty
+	case int64(utf8.RuneCountInString(x.Omit)) < 3:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Omit", StructNamespace: structNs + "Omit", Field: "Omit", Tag: "min", Param: "3", Value: x.Omit}))
+	}
+
+	switch {
+	case x.IsDefault != "":
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "IsDefault", StructNamespace: structNs + "IsDefault", Field: "IsDefault", Tag: "isdefault", Value: x.IsDefault}))
+	}
+
+	switch {
+	case x.Multi == "":
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Multi", StructNamespace: structNs + "Multi", Field: "Multi", Tag: "required", Value: x.Multi}))
+	case int64(utf8.RuneCountInString(x.Multi)) < 2:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Multi", StructNamespace: structNs + "Multi", Field: "Multi", Tag: "min", Param: "2", Value: x.Multi}))
+	case int64(utf8.RuneCountInString(x.Multi)) > 5:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Multi", StructNamespace: structNs + "Multi", Field: "Multi", Tag: "max", Param: "5", Value: x.Multi}))
+	}
+
+	switch {
+	case x.Status == "":
+		// omitempty
+	case x.Status != "active" && x.Status != "inactive":
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Status", StructNamespace: structNs + "Status", Field: "Status", Tag: "oneof", Param: "active inactive", Value: x.Status}))
+	case int64(utf8.RuneCountInString(string(x.Status))) < 6:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Status", StructNamespace: structNs + "Status", Field: "Status", Tag: "min", Param: "6", Value: x.Status}))
+	}
+
+	return errs
+}
+
+// Validate validates the fields of Numbers, returning the same errors as calling Struct would.
+func (x *Numbers) Validate() error {
+
+	if x == nil {
+		return validatorGen.Struct(x)
+	}
+
+	if errs := x.validateGen("Numbers.", "Numbers.", nil); len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// validateGen appends the errors of the fields of Numbers, namespaced by ns and structNs, to errs.
+func (x *Numbers) validateGen(ns, structNs string, errs validator.ValidationErrors) validator.ValidationErrors {
+
+	switch {
+	case x.Int == 0:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Int", StructNamespace: structNs + "Int", Field: "Int", Tag: "required", Value: x.Int}))
+	case int64(x.Int) < 1:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Int", StructNamespace: structNs + "Int", Field: "Int", Tag: "min", Param: "1", Value: x.Int}))
+	case int64(x.Int) > 10:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Int", StructNamespace: structNs + "Int", Field: "Int", Tag: "max", Param: "10", Value: x.Int}))
+	}
+
+	switch {
+	case int64(x.Int8) <= -5:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Int8", StructNamespace: structNs + "Int8", Field: "Int8", Tag: "gt", Param: "-5", Value: x.Int8}))
+	case int64(x.Int8) >= 100:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Int8", StructNamespace: structNs + "Int8", Field: "Int8", Tag: "lt", Param: "100", Value: x.Int8}))
+	}
+
+	switch {
+	case uint64(x.Uint) != 1 && uint64(x.Uint) != 2 && uint64(x.Uint) != 3:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Uint", StructNamespace: structNs + "Uint", Field: "Uint", Tag: "oneof", Param: "1 2 3", Value: x.Uint}))
+	}
+
+	switch {
+	case uint64(x.Uint16) < 10:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Uint16", StructNamespace: structNs + "Uint16", Field: "Uint16", Tag: "gte", Param: "10", Value: x.Uint16}))
+	case uint64(x.Uint16) > 32:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Uint16", StructNamespace: structNs + "Uint16", Field: "Uint16", Tag: "lte", Param: "32", Value: x.Uint16}))
+	}
+
+	switch {
+	case x.Float == 0:
+		// omitempty
+	case float64(x.Float) <= 0.5:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Float", StructNamespace: structNs + "Float", Field: "Float", Tag: "gt", Param: "0.5", Value: x.Float}))
+	case float64(x.Float) > 1000:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Float", StructNamespace: structNs + "Float", Field: "Float", Tag: "lte", Param: "1e3", Value: x.Float}))
+	}
+
+	switch {
+	case float64(x.Float32) != 1.5:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Float32", StructNamespace: structNs + "Float32", Field: "Float32", Tag: "eq", Param: "1.5", Value: x.Float32}))
+	}
+
+	switch {
+	case int64(x.IntOneOf) != -1 && int64(x.IntOneOf) != 2:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "IntOneOf", StructNamespace: structNs + "IntOneOf", Field: "IntOneOf", Tag: "oneof", Param: "-1 01 2", Value: x.IntOneOf}))
+	}
+
+	switch {
+	case int64(x.Ne) == 0:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Ne", StructNamespace: structNs + "Ne", Field: "Ne", Tag: "ne", Param: "0", Value: x.Ne}))
+	}
+
+	switch {
+	case int64(x.Len) != 7:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Len", StructNamespace: structNs + "Len", Field: "Len", Tag: "len", Param: "7", Value: x.Len}))
+	}
+
+	switch {
+	case !x.Bool:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Bool", StructNamespace: structNs + "Bool", Field: "Bool", Tag: "required", Value: x.Bool}))
+	}
+
+	switch {
+	case x.Default:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Default", StructNamespace: structNs + "Default", Field: "Default", Tag: "isdefault", Value: x.Default}))
+	}
+
+	switch {
+	case x.Count == 0:
+		// omitempty
+	case int64(x.Count) > 3:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Count", StructNamespace: structNs + "Count", Field: "Count", Tag: "max", Param: "3", Value: x.Count}))
+	}
+
+	return errs
+}
+
+// validateGen appends the errors of the fields of Inner, namespaced by ns and structNs, to errs.
+func (x *Inner) validateGen(ns, structNs string, errs validator.ValidationErrors) validator.ValidationErrors {
+
+	switch {
+	case x.Name == "":
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Name", StructNamespace: structNs + "Name", Field: "Name", Tag: "required", Value: x.Name}))
+	}
+
+	for i1 := range x.Tags {
+		switch {
+		case int64(utf8.RuneCountInString(x.Tags[i1])) < 2:
+			errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Tags[" + strconv.Itoa(i1) + "]", StructNamespace: structNs + "Tags[" + strconv.Itoa(i1) + "]", Field: "Tags[" + strconv.Itoa(i1) + "]", Tag: "min", Param: "2", Value: x.Tags[i1]}))
+		}
+	}
+
+	return errs
+}
+
+// Validate validates the fields of Pointers, returning the same errors as calling Struct would.
+func (x *Pointers) Validate() error {
+
+	if x == nil {
+		return validatorGen.Struct(x)
+	}
+
+	if errs := x.validateGen("Pointers.", "Pointers.", nil); len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// validateGen appends the errors of the fields of Pointers, namespaced by ns and structNs, to errs.
+func (x *Pointers) validateGen(ns, structNs string, errs validator.ValidationErrors) validator.ValidationErrors {
+
+	if x.Required == nil {
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Required", StructNamespace: structNs + "Required", Field: "Required", Tag: "required", Value: x.Required}))
+	} else {
+		switch {
+		case int64(utf8.RuneCountInString(*x.Required)) < 2:
+			errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Required", StructNamespace: structNs + "Required", Field: "Required", Tag: "min", Param: "2", Value: *x.Required}))
+		}
+	}
+
+	if x.Omit != nil {
+		switch {
+		case int64(*x.Omit) <= 1:
+			errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Omit", StructNamespace: structNs + "Omit", Field: "Omit", Tag: "gt", Param: "1", Value: *x.Omit}))
+		}
+	}
+
+	if x.Min == nil {
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Min", StructNamespace: structNs + "Min", Field: "Min", Tag: "min", Param: "1", Value: x.Min}))
+	} else {
+		switch {
+		case float64(*x.Min) < 1:
+			errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Min", StructNamespace: structNs + "Min", Field: "Min", Tag: "min", Param: "1", Value: *x.Min}))
+		}
+	}
+
+	if x.Bool == nil {
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Bool", StructNamespace: structNs + "Bool", Field: "Bool", Tag: "required", Value: x.Bool}))
+	}
+
+	if x.Inner == nil {
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Inner", StructNamespace: structNs + "Inner", Field: "Inner", Tag: "required", Value: x.Inner}))
+	} else {
+		errs = x.Inner.validateGen(ns+"Inner.", structNs+"Inner.", errs)
+	}
+
+	if x.OptInner != nil {
+		errs = x.OptInner.validateGen(ns+"OptInner.", structNs+"OptInner.", errs)
+	}
+
+	return errs
+}
+
+// Validate validates the fields of Collections, returning the same errors as calling Struct would.
+func (x *Collections) Validate() error {
+
+	if x == nil {
+		return validatorGen.Struct(x)
+	}
+
+	if errs := x.validateGen("Collections.", "Collections.", nil); len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// validateGen appends the errors of the fields of Collections, namespaced by ns and structNs, to errs.
+func (x *Collections) validateGen(ns, structNs string, errs validator.ValidationErrors) validator.ValidationErrors {
+
+	switch {
+	case x.Slice == nil:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Slice", StructNamespace: structNs + "Slice", Field: "Slice", Tag: "required", Value: x.Slice}))
+	case int64(len(x.Slice)) < 1:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Slice", StructNamespace: structNs + "Slice", Field: "Slice", Tag: "min", Param: "1", Value: x.Slice}))
+	default:
+		for i1 := range x.Slice {
+			switch {
+			case x.Slice[i1] == "":
+				errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Slice[" + strconv.Itoa(i1) + "]", StructNamespace: structNs + "Slice[" + strconv.Itoa(i1) + "]", Field: "Slice[" + strconv.Itoa(i1) + "]", Tag: "required", Value: x.Slice[i1]}))
+			case int64(utf8.RuneCountInString(x.Slice[i1])) > 3:
+				errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Slice[" + strconv.Itoa(i1) + "]", StructNamespace: structNs + "Slice[" + strconv.Itoa(i1) + "]", Field: "Slice[" + strconv.Itoa(i1) + "]", Tag: "max", Param: "3", Value: x.Slice[i1]}))
+			}
+		}
+	}
+
+	switch {
+	case x.Omit == nil:
+		// omitempty
+	case int64(len(x.Omit)) > 2:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Omit", StructNamespace: structNs + "Omit", Field: "Omit", Tag: "max", Param: "2", Value: x.Omit}))
+	default:
+		for i2 := range x.Omit {
+			switch {
+			case int64(x.Omit[i2]) <= 0:
+				errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Omit[" + strconv.Itoa(i2) + "]", StructNamespace: structNs + "Omit[" + strconv.Itoa(i2) + "]", Field: "Omit[" + strconv.Itoa(i2) + "]", Tag: "gt", Param: "0", Value: x.Omit[i2]}))
+			}
+		}
+	}
+
+	switch {
+	case int64(len(x.Array)) != 2:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Array", StructNamespace: structNs + "Array", Field: "Array", Tag: "len", Param: "2", Value: x.Array}))
+	default:
+		for i3 := range x.Array {
+			switch {
+			case int64(x.Array[i3]) == 0:
+				errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Array[" + strconv.Itoa(i3) + "]", StructNamespace: structNs + "Array[" + strconv.Itoa(i3) + "]", Field: "Array[" + strconv.Itoa(i3) + "]", Tag: "ne", Param: "0", Value: x.Array[i3]}))
+			}
+		}
+	}
+
+	switch {
+	case x.Map == nil:
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Map", StructNamespace: structNs + "Map", Field: "Map", Tag: "required", Value: x.Map}))
+	default:
+		for k4, v4 := range x.Map {
+			switch {
+			case int64(v4) < 1:
+				errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Map[" + fmt.Sprint(k4) + "]", StructNamespace: structNs + "Map[" + fmt.Sprint(k4) + "]", Field: "Map[" + fmt.Sprint(k4) + "]", Tag: "min", Param: "1", Value: v4}))
+			}
+		}
+	}
+
+	for i5 := range x.Nested {
+		switch {
+		case int64(len(x.Nested[i5])) < 1:
+			errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Nested[" + strconv.Itoa(i5) + "]", StructNamespace: structNs + "Nested[" + strconv.Itoa(i5) + "]", Field: "Nested[" + strconv.Itoa(i5) + "]", Tag: "min", Param: "1", Value: x.Nested[i5]}))
+		default:
+			for i6 := range x.Nested[i5] {
+				switch {
+				case x.Nested[i5][i6] != "a" && x.Nested[i5][i6] != "b":
+					errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Nested[" + strconv.Itoa(i5) + "][" + strconv.Itoa(i6) + "]", StructNamespace: structNs + "Nested[" + strconv.Itoa(i5) + "][" + strconv.Itoa(i6) + "]", Field: "Nested[" + strconv.Itoa(i5) + "][" + strconv.Itoa(i6) + "]", Tag: "oneof", Param: "a b", Value: x.Nested[i5][i6]}))
+				}
+			}
+		}
+	}
+
+	for i7 := range x.Inners {
+		errs = x.Inners[i7].validateGen(ns+"Inners["+strconv.Itoa(i7)+"].", structNs+"Inners["+strconv.Itoa(i7)+"].", errs)
+	}
+
+	switch {
+	case x.InnerPtrs == nil:
+		// omitempty
+	default:
+		for i8 := range x.InnerPtrs {
+			if x.InnerPtrs[i8] != nil {
+				errs = x.InnerPtrs[i8].validateGen(ns+"InnerPtrs["+strconv.Itoa(i8)+"].", structNs+"InnerPtrs["+strconv.Itoa(i8)+"].", errs)
+			}
+		}
+	}
+
+	for k9, v9 := range x.InnerMap {
+		if v9 == nil {
+			errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "InnerMap[" + fmt.Sprint(k9) + "]", StructNamespace: structNs + "InnerMap[" + fmt.Sprint(k9) + "]", Field: "InnerMap[" + fmt.Sprint(k9) + "]", Tag: "required", Value: v9}))
+		} else {
+			errs = v9.validateGen(ns+"InnerMap["+fmt.Sprint(k9)+"].", structNs+"InnerMap["+fmt.Sprint(k9)+"].", errs)
+		}
+	}
+
+	for i10 := range x.Ptrs {
+		if x.Ptrs[i10] != nil {
+			switch {
+			case int64(utf8.RuneCountInString(*x.Ptrs[i10])) < 2:
+				errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Ptrs[" + strconv.Itoa(i10) + "]", StructNamespace: structNs + "Ptrs[" + strconv.Itoa(i10) + "]", Field: "Ptrs[" + strconv.Itoa(i10) + "]", Tag: "min", Param: "2", Value: *x.Ptrs[i10]}))
+			}
+		}
+	}
+
+	return errs
+}
+
+// Validate validates the fields of Nested, returning the same errors as calling Struct would.
+func (x *Nested) Validate() error {
+
+	if x == nil {
+		return validatorGen.Struct(x)
+	}
+
+	if errs := x.validateGen("Nested.", "Nested.", nil); len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// validateGen appends the errors of the fields of Nested, namespaced by ns and structNs, to errs.
+func (x *Nested) validateGen(ns, structNs string, errs validator.ValidationErrors) validator.ValidationErrors {
+
+	errs = x.Inner.validateGen(ns+"Inner.", structNs+"Inner.", errs)
+
+	errs = x.Pointers.validateGen(ns+"Pointers.", structNs+"Pointers.", errs)
+
+	if x.Self != nil {
+		errs = x.Self.validateGen(ns+"Self.", structNs+"Self.", errs)
+	}
+
+	switch {
+	case x.Name == "":
+		errs = append(errs, validatorGen.NewFieldError(validator.FieldErrorOptions{Namespace: ns + "Name", StructNamespace: structNs + "Name", Field: "Name", Tag: "required", Value: x.Name}))
+	}
+
+	return errs
+}
+
+// Validate validates Fallback by calling Struct, as its validations could not be generated;
+// field Email: validation 'email' cannot be generated; field Confirm: validation 'eqfield' cannot be generated; field Color: validation 'iscolor' cannot be generated; field Or: or validation 'rgb|rgba' cannot be generated
+func (x *Fallback) Validate() error {
+	return validatorGen.Struct(x)
+}
+
+// Validate validates Referrer by calling Struct, as its validations could not be generated;
+// field Fallback: Fallback: field Email: validation 'email' cannot be generated; field Confirm: validation 'eqfield' cannot be generated; field Color: validation 'iscolor' cannot be generated; field Or: or validation 'rgb|rgba' cannot be generated
+func (x *Referrer) Validate() error {
+	return validatorGen.Struct(x)
+}
@@ -0,0 +1,109 @@
+/*
+Command validator-gen generates validators for the structs of a package, avoiding reflection
+for the common validations.
+
+For each struct it generates a Validate method, returning the same ValidationErrors, with the
+same namespaces, tags, params and values, as calling Struct on the validator would; but, when
+all of its validations can be generated, without walking the struct using reflection.
+
+Usage:
+
+	validator-gen [flags] [directory]
+
+	-type string
+		comma separated list of the struct types to generate validators for; defaults
+		to every struct with at least one field having a validation tag
+	-output string
+		name of the file to write, defaults to validator_gen.go within the directory
+	-tag string
+		name of the struct tag holding the validations, defaults to validate
+	-instance string
+		name of a package level *validator.Validate variable to use; when blank
+		a validatorGen variable is declared
+	-import string
+		import path of the validator package
+
+It is intended to be used with go:generate, eg.
+
+	//go:generate validator-gen -type User,Address
+
+Only the most common validations are generated; omitempty, required, isdefault, len, min,
+max, eq, ne, gt, gte, lt, lte, oneof, contains, containsany, excludes, excludesall and dive,
+on fields of the basic kinds, slices, arrays, maps, pointers and other structs of the package.
+Any struct using any other validation, which includes most of the baked in ones such as email,
+url, uuid or ip, the cross field validations such as eqfield or required_with, aliases, custom
+validations, modifiers or implementing Validatable, gets a Validate method that calls Struct
+instead, using reflection as usual; every field preventing generation is printed when generating.
+Identical errors are only claimed, and tested by internal/parity, for the 18 validations above.
+
+The instance used must be configured as the generated code expects, that is with the same tag
+name, no tag name func, no custom type funcs, struct level validations or struct rules for the
+generated types and without overriding any of the validations above; anything else should be excluded
+using -type. Unlike Struct the generated validators do not detect cycles, nor support the
+options of StructWithOptions.
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const defaultImportPath = "package/validator"
+
+func main() {
+
+	typeNames := flag.String("type", "", "comma separated list of the struct types to generate validators for")
+	output := flag.String("output", "", "name of the file to write, defaults to validator_gen.go within the directory")
+	tagName := flag.String("tag", "validate", "name of the struct tag holding the validations")
+	instance := flag.String("instance", "", "name of a package level *validator.Validate variable to use")
+	importPath := flag.String("import", defaultImportPath, "import path of the validator package")
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: validator-gen [flags] [directory]\n")
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	dir := "."
+
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	if len(*output) == 0 {
+		*output = filepath.Join(dir, "validator_gen.go")
+	}
+
+	var types []string
+
+	if len(*typeNames) > 0 {
+		types = strings.Split(*typeNames, ",")
+	}
+
+	g := &generator{
+		tagName:    *tagName,
+		instance:   *instance,
+		importPath: *importPath,
+	}
+
+	src, err := g.generate(dir, filepath.Base(*output), types)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "validator-gen: %s\n", err)
+		os.Exit(1)
+	}
+
+	for _, name := range g.fallbacks {
+		fmt.Fprintf(os.Stderr, "validator-gen: %s calls Struct: %s\n", name, g.reasons[name])
+	}
+
+	if err = ioutil.WriteFile(*output, src, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "validator-gen: %s\n", err)
+		os.Exit(1)
+	}
+}
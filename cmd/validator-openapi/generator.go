@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/build"
+	"go/format"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// programDir prefixes the temporary directory of the program; the leading underscore
+// excluding it from package patterns such as ./...
+const programDir = "_validator-openapi"
+
+type generator struct {
+	tagName    string
+	nameTag    string
+	importPath string
+}
+
+// generate returns the components document of the types within the package in dir
+func (g *generator) generate(dir string, types []string) ([]byte, error) {
+
+	if len(g.importPath) == 0 {
+		g.importPath = defaultImportPath
+	}
+
+	for _, name := range types {
+		if !token.IsIdentifier(name) || !token.IsExported(name) {
+			return nil, fmt.Errorf("invalid type name '%s'", name)
+		}
+	}
+
+	pkgPath, err := g.packagePath(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := g.program(pkgPath, types)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.run(dir, src)
+}
+
+// packagePath returns the import path of the package in dir
+func (g *generator) packagePath(dir string) (string, error) {
+
+	pkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return "", err
+	}
+
+	if pkg.Name == "main" {
+		return "", fmt.Errorf("%s is a main package, which can't be imported", dir)
+	}
+
+	cmd := exec.Command("go", "list", "-f", "{{.ImportPath}}", ".")
+	cmd.Dir = dir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("listing package: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// program returns the source of the program writing the components document of the types
+func (g *generator) program(pkgPath string, types []string) ([]byte, error) {
+
+	var w bytes.Buffer
+
+	fmt.Fprintf(&w, "// Code generated by validator-openapi. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&w, "package main\n\n")
+	fmt.Fprintf(&w, "import (\n")
+	fmt.Fprintf(&w, "\"encoding/json\"\n\"fmt\"\n\"os\"\n\"reflect\"\n\"strings\"\n\n")
+	fmt.Fprintf(&w, "validator %s\n", strconv.Quote(g.importPath))
+	fmt.Fprintf(&w, "openapi %s\n\n", strconv.Quote(g.importPath+"/openapi"))
+	fmt.Fprintf(&w, "pkg %s\n", strconv.Quote(pkgPath))
+	fmt.Fprintf(&w, ")\n\n")
+
+	fmt.Fprintf(&w, "func main() {\n\n")
+	fmt.Fprintf(&w, "validate := validator.New()\n")
+	fmt.Fprintf(&w, "validate.SetTagName(%s)\n", strconv.Quote(g.tagName))
+	fmt.Fprintf(&w, "validate.RegisterTagNameFunc(func(fld reflect.StructField) string {\n")
+	fmt.Fprintf(&w, "return strings.SplitN(fld.Tag.Get(%s), \",\", 2)[0]\n", strconv.Quote(g.nameTag))
+	fmt.Fprintf(&w, "})\n\n")
+
+	fmt.Fprintf(&w, "components, err := openapi.New(validate).Generate(\n")
+
+	for _, name := range types {
+		fmt.Fprintf(&w, "reflect.TypeOf((*pkg.%s)(nil)).Elem(),\n", name)
+	}
+
+	fmt.Fprintf(&w, ")\n")
+	fmt.Fprintf(&w, "if err != nil {\nfmt.Fprintln(os.Stderr, err)\nos.Exit(1)\n}\n\n")
+	fmt.Fprintf(&w, "b, err := json.MarshalIndent(map[string]interface{}{\"components\": components}, \"\", \"  \")\n")
+	fmt.Fprintf(&w, "if err != nil {\nfmt.Fprintln(os.Stderr, err)\nos.Exit(1)\n}\n\n")
+	fmt.Fprintf(&w, "os.Stdout.Write(append(b, '\\n'))\n")
+	fmt.Fprintf(&w, "}\n")
+
+	return format.Source(w.Bytes())
+}
+
+// run runs the program from within dir, so it's built within the package's module, returning it's output
+func (g *generator) run(dir string, src []byte) ([]byte, error) {
+
+	tmp, err := ioutil.TempDir(dir, programDir)
+	if err != nil {
+		return nil, err
+	}
+
+	defer os.RemoveAll(tmp)
+
+	if err = ioutil.WriteFile(filepath.Join(tmp, "main.go"), src, 0644); err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command("go", "run", "./"+filepath.Base(tmp))
+	cmd.Dir = dir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("running generator: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	return out, nil
+}
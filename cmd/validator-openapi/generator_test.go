@@ -0,0 +1,84 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "gopkg.in/go-playground/assert.v1"
+)
+
+func TestGeneratedExampleUpToDate(t *testing.T) {
+
+	if testing.Short() {
+		t.Skip("builds and runs the generator program")
+	}
+
+	g := &generator{tagName: "validate", nameTag: "json"}
+
+	doc, err := g.generate("internal/example", []string{"User"})
+	Equal(t, err, nil)
+
+	existing, err := ioutil.ReadFile("internal/example/openapi.json")
+	Equal(t, err, nil)
+
+	// line endings depend on how the repository was checked out
+	if string(doc) != strings.Replace(string(existing), "\r\n", "\n", -1) {
+		t.Fatal("internal/example/openapi.json is out of date, run go generate ./...")
+	}
+
+	// the program's temporary directory is removed
+	matches, err := filepath.Glob(filepath.Join("internal/example", programDir+"*"))
+	Equal(t, err, nil)
+	Equal(t, len(matches), 0)
+}
+
+func TestProgram(t *testing.T) {
+
+	g := &generator{tagName: "valid", nameTag: "yaml", importPath: defaultImportPath}
+
+	src, err := g.program("example.com/models", []string{"User", "Address"})
+	Equal(t, err, nil)
+
+	for _, s := range []string{
+		"pkg \"example.com/models\"",
+		"openapi \"package/validator/openapi\"",
+		"validate.SetTagName(\"valid\")",
+		"fld.Tag.Get(\"yaml\")",
+		"reflect.TypeOf((*pkg.User)(nil)).Elem(),",
+		"reflect.TypeOf((*pkg.Address)(nil)).Elem(),",
+	} {
+		if !strings.Contains(string(src), s) {
+			t.Fatalf("program is missing %s:\n%s", s, src)
+		}
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+
+	g := &generator{tagName: "validate", nameTag: "json"}
+
+	_, err := g.generate("internal/example", []string{"user"})
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "invalid type name 'user'")
+
+	_, err = g.generate("internal/example", []string{"User.Name"})
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "invalid type name 'User.Name'")
+
+	_, err = g.generate(".", []string{"User"})
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), ". is a main package, which can't be imported")
+
+	_, err = g.generate("internal/missing", []string{"User"})
+	NotEqual(t, err, nil)
+
+	if testing.Short() {
+		return
+	}
+
+	_, err = g.generate("internal/example", []string{"Missing"})
+	NotEqual(t, err, nil)
+	Equal(t, strings.Contains(err.Error(), "undefined: pkg.Missing"), true)
+}
@@ -0,0 +1,25 @@
+// Package example holds the structs used to prove the components generated by
+// validator-openapi are those of the openapi package.
+package example
+
+//go:generate go run package/validator/cmd/validator-openapi -type User -output openapi.json
+
+// Address is nested within User
+type Address struct {
+	Street string `json:"street" validate:"required,max=64"`
+	Zip    string `json:"zip" validate:"omitempty,len=5,numeric"`
+}
+
+// User has fields with validations both with and without OpenAPI equivalents
+type User struct {
+	Name      string            `json:"name" validate:"required,max=64"`
+	Email     string            `json:"email,omitempty" validate:"omitempty,email"`
+	Nickname  *string           `json:"nickname" validate:"omitempty,min=2"`
+	Password  string            `json:"password" validate:"required,min=8"`
+	Confirm   string            `json:"confirm" validate:"eqfield=Password"`
+	Role      string            `json:"role" validate:"oneof=admin user"`
+	Age       int               `json:"age" validate:"gte=18,lt=150"`
+	Addresses []Address         `json:"addresses" validate:"max=3,dive"`
+	Labels    map[string]string `json:"labels" validate:"dive,keys,alpha,endkeys,required"`
+	Internal  string            `json:"-"`
+}
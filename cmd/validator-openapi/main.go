@@ -0,0 +1,98 @@
+/*
+Command validator-openapi generates the OpenAPI 3.1 components/schemas of the structs of a package,
+with the constraints of their validations; see the openapi package.
+
+Usage:
+
+	validator-openapi [flags] [directory]
+
+	-type string
+		comma separated list of the struct types to generate schemas for, those nested
+		within them being generated too
+	-output string
+		name of the file to write, defaults to the standard output
+	-tag string
+		name of the struct tag holding the validations, defaults to validate
+	-name string
+		name of the struct tag naming the properties, defaults to json
+	-import string
+		import path of the validator package
+
+It is intended to be used with go:generate, eg.
+
+	//go:generate validator-openapi -type User,Address -output openapi.json
+
+The document written has the components object alone, to be merged into the service's spec:
+
+	{
+		"components": {
+			"schemas": {
+				...
+			}
+		}
+	}
+
+Being generated using reflection the package is built; a program using it, written to a temporary
+directory within the package's directory, is run using go run. Types within main packages can't be
+generated.
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+const defaultImportPath = "package/validator"
+
+func main() {
+
+	typeNames := flag.String("type", "", "comma separated list of the struct types to generate schemas for")
+	output := flag.String("output", "", "name of the file to write, defaults to the standard output")
+	tagName := flag.String("tag", "validate", "name of the struct tag holding the validations")
+	nameTag := flag.String("name", "json", "name of the struct tag naming the properties")
+	importPath := flag.String("import", defaultImportPath, "import path of the validator package")
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: validator-openapi [flags] [directory]\n")
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	if len(*typeNames) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := "."
+
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	g := &generator{
+		tagName:    *tagName,
+		nameTag:    *nameTag,
+		importPath: *importPath,
+	}
+
+	doc, err := g.generate(dir, strings.Split(*typeNames, ","))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "validator-openapi: %s\n", err)
+		os.Exit(1)
+	}
+
+	if len(*output) == 0 {
+		os.Stdout.Write(doc)
+		return
+	}
+
+	if err = ioutil.WriteFile(*output, doc, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "validator-openapi: %s\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,226 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultElemSeparator = "|"
+	badModifierParam     = "invalid param '%s' for modifier '%s' on kind %s"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setDefaults applies the default modifiers of the struct's fields, and those of the structs nested
+// within it; current must be addressable.
+func (v *validate) setDefaults(ctx context.Context, current reflect.Value, ns []byte) {
+
+	typ := current.Type()
+	cs := v.cachedStruct(current, typ, ns)
+
+	if len(ns) == 0 && len(cs.name) != 0 {
+		ns = append(ns, cs.name...)
+		ns = append(ns, '.')
+	}
+
+	var key visitKey
+
+	if cs.mayCycle {
+
+		key = visitKey{ptr: current.UnsafeAddr(), typ: typ}
+
+		if _, ok := v.visiting[key]; ok {
+			return
+		}
+
+		if v.visiting == nil {
+			v.visiting = make(map[visitKey]struct{})
+		}
+
+		v.visiting[key] = struct{}{}
+	}
+
+	for _, f := range cs.fields {
+
+		field := current.Field(f.idx)
+
+		if f.mods != nil {
+			v.modify(ctx, field, ns, f, f.mods)
+		}
+
+		v.nestedDefaults(ctx, field, append(ns, f.altName...))
+	}
+
+	if key.typ != nil {
+		delete(v.visiting, key)
+	}
+}
+
+// nestedDefaults applies the default modifiers of the structs within the field, including the
+// elements of slices and arrays; nil pointers are left alone and map values, which can't be
+// modified in place, are skipped.
+func (v *validate) nestedDefaults(ctx context.Context, field reflect.Value, ns []byte) {
+
+	for field.Kind() == reflect.Ptr || field.Kind() == reflect.Interface {
+
+		if field.IsNil() {
+			return
+		}
+
+		field = field.Elem()
+	}
+
+	switch field.Kind() {
+	case reflect.Struct:
+
+		if field.Type() == timeType || !field.CanAddr() {
+			return
+		}
+
+		if v.v.hasCustomFuncs {
+			if _, ok := v.v.customFuncs[field.Type()]; ok {
+				return
+			}
+		}
+
+		v.setDefaults(ctx, field, append(ns, '.'))
+
+	case reflect.Slice, reflect.Array:
+
+		for i := 0; i < field.Len(); i++ {
+
+			v.misc = append(ns, '[')
+			v.misc = strconv.AppendInt(v.misc, int64(i), 10)
+			v.misc = append(v.misc, ']')
+
+			v.nestedDefaults(ctx, field.Index(i), v.misc)
+		}
+	}
+}
+
+// setDefault sets field, which must be settable, to the value of param when it has it's zero value.
+func setDefault(field reflect.Value, param string) error {
+
+	if !field.IsZero() {
+		return nil
+	}
+
+	return parseDefault(field, param)
+}
+
+// parseDefault sets field, which must be settable, to the value of param parsed according to the
+// field's kind; supporting strings, bools, numbers, time.Duration, time.Time, formatted as RFC 3339,
+// pointers to those and slices of those, the elements being separated by '|'.
+func parseDefault(field reflect.Value, param string) error {
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(param)
+		return nil
+
+	case reflect.Bool:
+
+		b, err := strconv.ParseBool(param)
+		if err != nil {
+			break
+		}
+
+		field.SetBool(b)
+		return nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+
+		if field.Type() == durationType {
+
+			d, err := time.ParseDuration(param)
+			if err != nil {
+				break
+			}
+
+			field.SetInt(int64(d))
+			return nil
+		}
+
+		i, err := strconv.ParseInt(param, 0, field.Type().Bits())
+		if err != nil {
+			break
+		}
+
+		field.SetInt(i)
+		return nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+
+		u, err := strconv.ParseUint(param, 0, field.Type().Bits())
+		if err != nil {
+			break
+		}
+
+		field.SetUint(u)
+		return nil
+
+	case reflect.Float32, reflect.Float64:
+
+		f, err := strconv.ParseFloat(param, field.Type().Bits())
+		if err != nil {
+			break
+		}
+
+		field.SetFloat(f)
+		return nil
+
+	case reflect.Struct:
+
+		if field.Type() != timeType {
+			return fmt.Errorf(badModifierKind, defaultModifier, field.Kind())
+		}
+
+		t, err := time.Parse(time.RFC3339, param)
+		if err != nil {
+			break
+		}
+
+		field.Set(reflect.ValueOf(t))
+		return nil
+
+	case reflect.Ptr:
+
+		elem := reflect.New(field.Type().Elem())
+
+		if err := parseDefault(elem.Elem(), param); err != nil {
+			return err
+		}
+
+		field.Set(elem)
+		return nil
+
+	case reflect.Slice:
+
+		if len(param) == 0 {
+			field.Set(reflect.MakeSlice(field.Type(), 0, 0))
+			return nil
+		}
+
+		vals := strings.Split(param, defaultElemSeparator)
+		slice := reflect.MakeSlice(field.Type(), len(vals), len(vals))
+
+		for i, val := range vals {
+			if err := parseDefault(slice.Index(i), val); err != nil {
+				return err
+			}
+		}
+
+		field.Set(slice)
+		return nil
+
+	default:
+		return fmt.Errorf(badModifierKind, defaultModifier, field.Kind())
+	}
+
+	return fmt.Errorf(badModifierParam, param, defaultModifier, field.Kind())
+}
@@ -0,0 +1,162 @@
+package validator
+
+import (
+	"reflect"
+)
+
+// Rules are the parsed validations of a tag, as applied when validating; used to describe the
+// validations elsewhere, such as generating a JSON Schema. See StructFields and ParseTag.
+type Rules struct {
+	Validations   [][]Validation // validations the value must pass, each being alternatives or'ed together, most having a single one
+	OmitEmpty     bool           // the validations are skipped when the value is empty
+	StructOnly    bool           // only the struct's own validations run, not those of it's fields
+	NoStructLevel bool           // the struct level validations don't run
+	Keys          *Rules         // validations of a map's keys, from 'keys' to 'endkeys'; nil when not set
+	Dive          *Rules         // validations of the elements of a slice, array or map following 'dive'; nil when not diving
+}
+
+// Validation is a single validation of Rules
+type Validation struct {
+	Tag   string // validation tag, aliases being expanded, eg. "min"
+	Param string // param of the validation, if any, eg. "1"
+	Alias string // alias the validation comes from, if any, eg. "iscolor"
+}
+
+// StructField is a field of a struct along with it's validations, as validated by Struct
+type StructField struct {
+	Field reflect.StructField
+	Name  string // name of the field within namespaces, see RegisterTagNameFunc
+	Skip  bool   // the field isn't validated, only it's modifiers applied or validated within groups
+	Rules *Rules // validations of the field, having no validations when the field has no tag
+}
+
+// StructFields returns the fields of the struct type t, in order, along with their validations; as parsed
+// and cached for validation, so including rules registered using RegisterStructRules. Fields that are
+// never validated, eg. those not exported, are not returned.
+//
+// It returns InvalidValidationError when t is not a struct and an *InvalidTagError when a tag is invalid.
+func (v *Validate) StructFields(t reflect.Type) ([]StructField, error) {
+
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct || t == timeType {
+		return nil, &InvalidValidationError{Type: t}
+	}
+
+	cs, ok := v.structCache.Get(t)
+	if !ok {
+
+		var err error
+
+		if cs, err = v.extractStructCache(reflect.New(t).Elem(), t.Name()); err != nil {
+			err.(*InvalidTagError).Namespace = t.Name() + namespaceSeparator + err.(*InvalidTagError).Namespace
+			return nil, err
+		}
+	}
+
+	fields := make([]StructField, len(cs.fields))
+
+	for i, f := range cs.fields {
+		fields[i] = StructField{
+			Field: t.Field(f.idx),
+			Name:  f.altName,
+			Skip:  f.skip,
+			Rules: describeRules(f.cTags),
+		}
+	}
+
+	return fields, nil
+}
+
+// ParseTag returns the validations of the tag, such as one used with Var
+//
+// It returns an *InvalidTagError when the tag is invalid.
+func (v *Validate) ParseTag(tag string) (*Rules, error) {
+
+	if len(tag) == 0 || tag == skipValidationTag {
+		return &Rules{}, nil
+	}
+
+	ct, err := v.fetchCacheTag(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return describeRules(ct), nil
+}
+
+// describeRules returns the Rules of the chain of tags
+func describeRules(ct *cTag) *Rules {
+
+	rules := new(Rules)
+
+	for ; ct != nil; ct = ct.next {
+
+		switch ct.typeof {
+		case typeOmitEmpty:
+			rules.OmitEmpty = true
+
+		case typeStructOnly:
+			rules.StructOnly = true
+
+		case typeNoStructLevel:
+			rules.NoStructLevel = true
+
+		case typeEndKeys:
+			return rules
+
+		case typeDive:
+
+			ct = ct.next
+
+			if ct != nil && ct.typeof == typeKeys {
+				rules.Keys = describeRules(ct.keys)
+				ct = ct.next
+			}
+
+			rules.Dive = describeRules(ct)
+
+			return rules
+
+		case typeOr:
+
+			var or []Validation
+
+			for ; ct != nil && ct.typeof == typeOr; ct = ct.next {
+
+				or = append(or, describeValidation(ct))
+
+				if ct.isBlockEnd {
+					break
+				}
+			}
+
+			rules.Validations = append(rules.Validations, or)
+
+			if ct == nil {
+				return rules
+			}
+
+		default:
+
+			if ct.hasTag {
+				rules.Validations = append(rules.Validations, []Validation{describeValidation(ct)})
+			}
+		}
+	}
+
+	return rules
+}
+
+func describeValidation(ct *cTag) Validation {
+
+	val := Validation{Tag: ct.tag, Param: ct.param}
+
+	if ct.hasAlias {
+		val.Alias = ct.aliasTag
+	}
+
+	return val
+}
@@ -26,6 +26,51 @@ if the error returned is not nil, and if it's not check if error is
 InvalidValidationError ( if necessary, most of the time it isn't ) type cast
 it to type ValidationErrors like so err.(validator.ValidationErrors).
 
+ValidationErrors can be marshaled to JSON, as an array of objects holding each
+FieldError's namespaces, field names, tags, param, kind, type and, for bools,
+numbers and strings, value; MarshalJSONTranslated also includes each error's
+message translated using the provided ut.Translator. The JSON can be unmarshaled
+back into ValidationErrors, eg. by Go clients of an API.
+
+	b, err := err.(validator.ValidationErrors).MarshalJSONTranslated(trans)
+
+Each FieldError's Path holds the typed segments, struct fields, slice indexes and
+map keys, leading to the field; unlike the Namespace it is not ambiguous when map
+keys contain a '.' or ']' and can be rendered as a JSON Pointer or JSONPath.
+
+	pointer := fe.Path().JSONPointer() // eg. "/addresses/0/zip"
+
+The form package decodes url.Values, such as HTML forms, into structs using the
+same names, so that with it's TagNameFunc registered the Path of a FieldError is
+the name of the input that produced it, eg. "items[0].sku".
+
+When only the validity, or the first few errors, of a large value matter validation
+can stop once a maximum number of errors is found, using SetMaxErrors or per call
+using StructWithOptions and VarWithOptions; ValidationErrors.Truncated then reports
+whether the rest of the value was left unvalidated.
+
+	err := validate.VarWithOptions(ctx, rows, "dive", validator.ValidateOptions{FailFast: true})
+
+Cycles, such as a child pointing back to it's parent, are detected and a struct
+already being validated further up the same path is not validated again. As a
+safety limit for untrusted input the nesting of structs can also be limited using
+SetMaxDepth, or per call, exceeding it returning a *MaxDepthError.
+
+Other guardrails for public endpoints are set using SetLimits; the number of
+elements dived into, the number of fields visited, the length of strings matched
+against the regexes of baked in validations and a deadline per validation. When
+one is exceeded validation stops and a *LimitError, naming the limit, is returned.
+
+	validate.SetLimits(validator.Limits{
+		MaxDiveElements: 1000,
+		MaxRegexInput:   1024,
+		Timeout:         time.Second,
+	})
+
+The context passed to StructCtx, VarCtx and friends is also checked every so many
+fields and elements; once it is cancelled, eg. the client disconnected, validation
+stops and a *ContextError wrapping the context's error is returned.
+
 Custom Validation Functions
 
 Custom Validation functions can be added. Example:
@@ -98,6 +143,126 @@ used "eqcsfield" it could be multiple levels down. Example:
 	//       whatever you pass, struct, field...
 	//       when calling validate.Field(field, tag) val will be nil
 
+Struct Level Validation
+
+Validations involving multiple fields of a struct can be registered using
+RegisterStructValidation, or the struct can validate itself by implementing
+Validatable, or ValidatableCtx; these run after the struct's fields have been
+validated, including when nested within other structs or dived into.
+
+	func (u *User) ValidateStruct(sl validator.StructLevel) {
+
+		if len(u.FirstName) == 0 && len(u.LastName) == 0 {
+			sl.ReportError(u.FirstName, "FirstName", "FirstName", "fnameorlname", "")
+		}
+	}
+
+Methods promoted from an embedded struct are called when validating the embedded
+struct, rather than the struct embedding it; except when the embedded struct's type
+is unexported, its methods then being called by the struct embedding it. Promoted
+methods are told apart by the method sets of the embedded fields, so a method
+shadowing an embedded struct's with the same receiver, value or pointer, is taken
+to be promoted and isn't called.
+
+Validation Groups
+
+Fields can have different validations depending on the scenario, such as creating
+or updating, using group tags named after the tag name, a "." and the group. When
+calling StructGroups the first group, in order, a field has a tag for replaces the
+field's validations, "-" skipping the field, and fields without any use their
+usual validations.
+
+	type User struct {
+		ID       string `validate:"required" validate.create:"isdefault"`
+		Password string `validate:"-" validate.create:"required,min=8"`
+	}
+
+	err := validate.StructGroups(ctx, user, "create")
+
+Modifiers
+
+Fields can be normalized before they are validated using modifiers, in the 'mod'
+tag, see SetModTagName. All of a struct's fields are modified before any is
+validated, so cross field validations see the modified values; only fields that
+can be set are modified, so pass a pointer to the struct to Struct and friends.
+
+	type User struct {
+		Name  string   `mod:"trim,title" validate:"required"`
+		Email string   `mod:"trim,lcase" validate:"email"`
+		Role  string   `mod:"default=user" validate:"oneof=user admin"`
+		Tags  []string `mod:"dive,trim" validate:"dive,alpha"`
+	}
+
+	err := validate.Struct(&user)
+
+The baked in modifiers are trim, ltrim and rtrim, taking an optional cutset as
+their param; lcase, ucase and title; snake and camel; nfc, normalizing to
+Unicode normalization form C; and default, setting a zero value, allocating a
+nil pointer, from it's param for strings, bools, numbers, time.Duration,
+time.Time formatted as RFC 3339 and slices of those, the elements separated
+by '|'. dive applies the modifiers that follow it to the elements of a slice,
+array or map.
+
+Custom modifiers are added using RegisterModifier; eg. keeping only the digits
+of a phone number:
+
+	validate.RegisterModifier("digits", func(ctx context.Context, field reflect.Value, param string) {
+		if field.Kind() == reflect.String {
+			field.SetString(strings.Map(func(r rune) rune {
+				if unicode.IsDigit(r) {
+					return r
+				}
+				return -1
+			}, field.String()))
+		}
+	})
+
+SetDefaults applies only the default modifiers, of the struct and the structs
+nested within it, without validating; eg. when loading configuration.
+
+	type Config struct {
+		Addr    string        `mod:"default=:8080"`
+		Timeout time.Duration `mod:"default=30s"`
+		Origins []string      `mod:"default=a.com|b.com"`
+	}
+
+	err := validate.SetDefaults(&config)
+
+External Rules
+
+Types that can't have tags added, such as generated types, can have the tags of
+their fields registered using RegisterStructRules, or loaded from JSON or YAML
+using LoadStructRules; these take precedence over any tags the fields have.
+
+	err := validate.RegisterStructRules(pb.User{}, map[string]string{
+		"Name":  "required,max=64",
+		"Items": "dive,required",
+	})
+
+Validating Maps
+
+Payloads without a Go struct, such as JSON decoded into a map[string]interface{},
+can be validated using ValidateMap with rules keyed the same as the data; a rule
+being the tag for the value or the nested rules of a map, or slice of maps.
+
+	errs := validate.ValidateMap(data, map[string]interface{}{
+		"email": "required,email",
+		"addresses": map[string]interface{}{
+			"zip": "required,numeric",
+		},
+	})
+
+Describing Validations
+
+The validations of a struct's fields, as parsed for validation, are returned by
+StructFields, and those of a tag by ParseTag; eg. to generate documentation. The
+jsonschema package uses these to generate a JSON Schema of a struct type, and
+the openapi package the components of an OpenAPI 3.1 document.
+
+The reverse, JSON Schemas compiled into validators, is also in the jsonschema
+package; validating documents against tags, outside of a struct, is done using
+VarPath, returning FieldErrors at the path of the value within the document.
+
 Multiple Validators
 
 Multiple validators on a field will process in the order defined. Example:
@@ -245,6 +410,197 @@ ensures the value is not nil.
 
 	Usage: required
 
+Required If
+
+The field under validation must be present and not empty only if all
+the other specified fields are equal to the value following the specified
+field. For strings ensures value is not "". For slices, maps, pointers,
+interfaces, channels and functions ensures the value is not nil.
+
+	Usage: required_if
+
+Examples:
+
+	// require the field if the Field1 is equal to the parameter given:
+	Usage: required_if=Field1 foobar
+
+	// require the field if the Field1 and Field2 is equal to the value respectively:
+	Usage: required_if=Field1 foo Field2 bar
+
+Required Unless
+
+The field under validation must be present and not empty unless any of
+the other specified fields are equal to the value following the specified
+field. For strings ensures value is not "". For slices, maps, pointers,
+interfaces, channels and functions ensures the value is not nil.
+
+	Usage: required_unless
+
+Examples:
+
+	// require the field unless the Field1 is equal to the parameter given:
+	Usage: required_unless=Field1 foobar
+
+	// require the field unless the Field1 or Field2 is equal to the value respectively:
+	Usage: required_unless=Field1 foo Field2 bar
+
+Required With
+
+The field under validation must be present and not empty only if any
+of the other specified fields are present. For strings ensures value is
+not "". For slices, maps, pointers, interfaces, channels and functions
+ensures the value is not nil.
+
+	Usage: required_with
+
+Examples:
+
+	// require the field if the Field1 is present:
+	Usage: required_with=Field1
+
+	// require the field if the Field1 or Field2 is present:
+	Usage: required_with=Field1 Field2
+
+Required With All
+
+The field under validation must be present and not empty only if all
+of the other specified fields are present. For strings ensures value is
+not "". For slices, maps, pointers, interfaces, channels and functions
+ensures the value is not nil.
+
+	Usage: required_with_all
+
+Example:
+
+	// require the field if the Field1 and Field2 is present:
+	Usage: required_with_all=Field1 Field2
+
+Required Without
+
+The field under validation must be present and not empty only when any
+of the other specified fields are not present. For strings ensures value is
+not "". For slices, maps, pointers, interfaces, channels and functions
+ensures the value is not nil.
+
+	Usage: required_without
+
+Examples:
+
+	// require the field if the Field1 is not present:
+	Usage: required_without=Field1
+
+	// require the field if the Field1 or Field2 is not present:
+	Usage: required_without=Field1 Field2
+
+Required Without All
+
+The field under validation must be present and not empty only when all
+of the other specified fields are not present. For strings ensures value is
+not "". For slices, maps, pointers, interfaces, channels and functions
+ensures the value is not nil.
+
+	Usage: required_without_all
+
+Example:
+
+	// require the field if the Field1 and Field2 is not present:
+	Usage: required_without_all=Field1 Field2
+
+Excluded If
+
+The field under validation must not be present or must be empty only if
+all the other specified fields are equal to the value following the
+specified field.
+
+	Usage: excluded_if
+
+Examples:
+
+	// exclude the field if the Field1 is equal to the parameter given:
+	Usage: excluded_if=Field1 foobar
+
+	// exclude the field if the Field1 and Field2 is equal to the value respectively:
+	Usage: excluded_if=Field1 foo Field2 bar
+
+Excluded Unless
+
+The field under validation must not be present or must be empty unless
+any of the other specified fields are equal to the value following the
+specified field.
+
+	Usage: excluded_unless
+
+Examples:
+
+	// exclude the field unless the Field1 is equal to the parameter given:
+	Usage: excluded_unless=Field1 foobar
+
+	// exclude the field unless the Field1 or Field2 is equal to the value respectively:
+	Usage: excluded_unless=Field1 foo Field2 bar
+
+Excluded With
+
+The field under validation must not be present or must be empty if any
+of the other specified fields are present.
+
+	Usage: excluded_with
+
+Examples:
+
+	// exclude the field if the Field1 is present:
+	Usage: excluded_with=Field1
+
+	// exclude the field if the Field1 or Field2 is present:
+	Usage: excluded_with=Field1 Field2
+
+Excluded With All
+
+The field under validation must not be present or must be empty if all
+of the other specified fields are present.
+
+	Usage: excluded_with_all
+
+Example:
+
+	// exclude the field if the Field1 and Field2 is present:
+	Usage: excluded_with_all=Field1 Field2
+
+Excluded Without
+
+The field under validation must not be present or must be empty when any
+of the other specified fields are not present.
+
+	Usage: excluded_without
+
+Examples:
+
+	// exclude the field if the Field1 is not present:
+	Usage: excluded_without=Field1
+
+	// exclude the field if the Field1 or Field2 is not present:
+	Usage: excluded_without=Field1 Field2
+
+Excluded Without All
+
+The field under validation must not be present or must be empty when all
+of the other specified fields are not present.
+
+	Usage: excluded_without_all
+
+Example:
+
+	// exclude the field if the Field1 and Field2 is not present:
+	Usage: excluded_without_all=Field1 Field2
+
+Unlike required the required_* and excluded_* tags run even when the field
+is a nil pointer or interface. Once one of them passes on an empty field, the
+field isn't required, so the validations after it that need a value, such as
+email, are skipped; required and the other required_* and excluded_* tags
+still run. Pointers and values behave the same.
+
+	// Email is required when Phone is set, and must be an email when not empty
+	Usage: required_with=Phone,email
+
 Is Default
 
 This validates that the value is the default value and is almost the
@@ -366,6 +722,31 @@ For time.Time ensures the time value is less than or equal to time.Now.UTC().
 
 	Usage: lte
 
+Expression
+
+This validates that an expression over the fields of the struct the field is in
+is true, for rules comparing more than one field to another. The expression is
+compiled once, when the tag is parsed, and is the FieldError's Param.
+
+Fields are referenced by name, including those of nested structs eg. Customer.Limit,
+a field behind a nil pointer being nil. Supported are ints, floats, strings
+in single or double quotes, bools, nil, durations eg. 24h, the arithmetic operators
++ - * / %, the comparison operators == != < <= > >=, the logical operators && || !
+and parentheses; along with len(x) and now(). Times can be compared, subtracted
+giving a duration, and have durations added or subtracted. nil is never ordered,
+any comparison other than == and != with nil being false. The expression is
+validated even when the field is nil, and can't contain a comma. Ints dividing
+by zero or overflowing fail the validation.
+
+The fields, and the operands of the operators and functions, are checked against
+the struct when its tags are parsed, so Compile reports an undefined field or
+adding a string to an int; only the fields within interfaces are left to be
+checked when the expression is evaluated.
+
+	Usage: expr=EndDate >= StartDate + 24h
+	Usage: expr=Qty * UnitPrice <= Customer.CreditLimit
+	Usage: expr=len(Items) > 0 || Draft
+
 Field Equals Another Field
 
 This will validate the field value against another fields value either within
@@ -967,5 +1348,23 @@ that should not make it to production.
 	}
 
 	validate.Struct(t) // this will panic
+
+When tags come from a source that is not under your control, the panic can be
+turned off; a malformed tag, or a validation used on a field of a kind it does
+not support, is then returned as an *InvalidTagError instead, holding the
+namespace of the offending field, the tag and the reason it is invalid.
+
+	validate.SetPanicOnInvalidTag(false)
+
+	err := validate.Struct(t)
+	if tagErr, ok := err.(*validator.InvalidTagError); ok {
+		// tagErr.Namespace == "Test.TestField"
+	}
+
+Tags can also be checked ahead of time, at startup or within a unit test, using
+Compile or RegisterStruct; these walk every struct reachable from the types
+provided and return all of the invalid tags found as InvalidTagErrors.
+
+	err := validate.RegisterStruct(Test{})
 */
 package validator
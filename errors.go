@@ -2,8 +2,12 @@ package validator
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"math"
 	"reflect"
+	"strconv"
 	"strings"
 
 	ut "package/universal-translator"
@@ -32,6 +36,134 @@ func (e *InvalidValidationError) Error() string {
 	return "validator: (nil " + e.Type.String() + ")"
 }
 
+// InvalidTagError describes a validation tag that could not be parsed, or a
+// validation that was applied to a field of a kind it does not support.
+//
+// It is only returned when the Validate instance has been configured not to
+// panic on invalid tags, see SetPanicOnInvalidTag.
+type InvalidTagError struct {
+	Namespace string // namespace of the field the tag is on, blank when validating a single variable
+	Tag       string // the offending validation tag
+	Reason    string // why the tag is invalid
+}
+
+// Error returns InvalidTagError message
+func (e *InvalidTagError) Error() string {
+
+	if len(e.Namespace) == 0 {
+		return "validator: invalid tag '" + e.Tag + "': " + e.Reason
+	}
+
+	return "validator: invalid tag '" + e.Tag + "' on field '" + e.Namespace + "': " + e.Reason
+}
+
+// MaxDepthError is returned when validating structs nested deeper than the maximum depth
+// allowed, see SetMaxDepth.
+type MaxDepthError struct {
+	Namespace string // namespace of the struct that exceeded the maximum depth
+	MaxDepth  int
+}
+
+// Error returns MaxDepthError message
+func (e *MaxDepthError) Error() string {
+	return "validator: maximum depth of " + strconv.Itoa(e.MaxDepth) + " exceeded at '" + e.Namespace + "'"
+}
+
+// Limit is one of the Limits
+type Limit uint8
+
+// Limits that can be exceeded
+const (
+	LimitDiveElements Limit = iota // Limits.MaxDiveElements
+	LimitFields                    // Limits.MaxFields
+	LimitRegexInput                // Limits.MaxRegexInput
+	LimitDeadline                  // Limits.Timeout or the context's deadline
+)
+
+// String returns the Limit's name
+func (l Limit) String() string {
+
+	switch l {
+	case LimitDiveElements:
+		return "dive elements"
+	case LimitFields:
+		return "fields"
+	case LimitRegexInput:
+		return "regex input"
+	case LimitDeadline:
+		return "deadline"
+	}
+
+	return "unknown"
+}
+
+// LimitError is returned when validation exceeds one of the Limits set, see SetLimits;
+// eg. so that too large a payload can be responded to with a 413 status.
+type LimitError struct {
+	Limit     Limit  // the limit exceeded
+	Namespace string // namespace of the field being validated when the limit was exceeded
+}
+
+// Error returns LimitError message
+func (e *LimitError) Error() string {
+
+	if len(e.Namespace) == 0 {
+		return "validator: limit on " + e.Limit.String() + " exceeded"
+	}
+
+	return "validator: limit on " + e.Limit.String() + " exceeded at '" + e.Namespace + "'"
+}
+
+// Unwrap returns context.DeadlineExceeded when the deadline was exceeded, so that
+// errors.Is(err, context.DeadlineExceeded) holds, otherwise nil
+func (e *LimitError) Unwrap() error {
+
+	if e.Limit == LimitDeadline {
+		return context.DeadlineExceeded
+	}
+
+	return nil
+}
+
+// ContextError is returned when the context is cancelled, or it's deadline exceeded, before validation
+// completes; validation stops, the fields and elements left not being validated.
+type ContextError struct {
+	Namespace string // namespace of the field being validated when the context was found to be done
+	Err       error  // the context's error
+}
+
+// Error returns ContextError message
+func (e *ContextError) Error() string {
+
+	if len(e.Namespace) == 0 {
+		return "validator: " + e.Err.Error()
+	}
+
+	return "validator: " + e.Err.Error() + " at '" + e.Namespace + "'"
+}
+
+// Unwrap returns the context's error, so that errors.Is(err, context.Canceled) holds when cancelled
+func (e *ContextError) Unwrap() error {
+	return e.Err
+}
+
+// InvalidTagErrors is an array of InvalidTagError's, as returned by Compile
+// and RegisterStruct when one or more invalid tags are found.
+type InvalidTagErrors []*InvalidTagError
+
+// Error returns the InvalidTagErrors messages, one per line.
+func (ite InvalidTagErrors) Error() string {
+
+	buff := bytes.NewBufferString("")
+
+	for i := 0; i < len(ite); i++ {
+		buff.WriteString(ite[i].Error())
+		buff.WriteString("\n")
+	}
+
+	return strings.TrimSpace(buff.String())
+}
+
 // ValidationErrors is an array of FieldError's
 // for use in custom error messages post validation.
 type ValidationErrors []FieldError
@@ -56,6 +188,20 @@ func (ve ValidationErrors) Error() string {
 	return strings.TrimSpace(buff.String())
 }
 
+// Truncated returns if validation stopped before validating the whole value, as the maximum
+// number of errors was reached, so there may be more errors than those returned; see SetMaxErrors.
+func (ve ValidationErrors) Truncated() bool {
+
+	for i := 0; i < len(ve); i++ {
+
+		if fe, ok := ve[i].(*fieldError); ok && fe.truncated {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Translate translates all of the ValidationErrors
 func (ve ValidationErrors) Translate(ut ut.Translator) ValidationErrorsTranslations {
 
@@ -149,6 +295,13 @@ type FieldError interface {
 	// // eg. time.Time's type is time.Time
 	Type() reflect.Type
 
+	// Path returns the path of the field within the value validated, without
+	// the top level struct's name; see Path for rendering it as a string.
+	//
+	// eg. for namespace "User.Addresses[0].Tags[foo]" the segments are the
+	// fields Addresses and Tags, the index 0 and the map key "foo"
+	Path() Path
+
 	// returns the FieldError's translated error
 	// from the provided 'ut.Translator' and registered 'TranslationFunc'
 	//
@@ -160,6 +313,10 @@ type FieldError interface {
 // compile time interface checks
 var _ FieldError = new(fieldError)
 var _ error = new(fieldError)
+var _ json.Marshaler = new(fieldError)
+var _ json.Unmarshaler = new(fieldError)
+var _ json.Marshaler = ValidationErrors{}
+var _ json.Unmarshaler = new(ValidationErrors)
 
 // fieldError contains a single field's validation error along
 // with other properties that may be needed for error message creation
@@ -176,6 +333,9 @@ type fieldError struct {
 	param          string
 	kind           reflect.Kind
 	typ            reflect.Type
+	typName        string // name of typ when unmarshaled from JSON and typ could not be resolved
+	path           Path   // nil when not created while traversing the value, see Path
+	truncated      bool   // validation stopped after this error, see ValidationErrors.Truncated
 }
 
 // Tag returns the validation tag that failed.
@@ -246,6 +406,20 @@ func (fe *fieldError) Type() reflect.Type {
 	return fe.typ
 }
 
+// Path returns the path of the field within the value validated.
+//
+// NOTE: for FieldError's not created while validating, such as by NewFieldError or
+// when unmarshaled, the Path is parsed from the namespaces; so map keys are strings
+// and keys that are valid indexes are IndexSegment's.
+func (fe *fieldError) Path() Path {
+
+	if fe.path == nil {
+		fe.path = parsePath(fe.ns, fe.structNs, true)
+	}
+
+	return fe.path
+}
+
 // Error returns the fieldError's error message
 func (fe *fieldError) Error() string {
 	return fmt.Sprintf(fieldErrMsg, fe.ns, fe.Field(), fe.tag)
@@ -258,6 +432,11 @@ func (fe *fieldError) Error() string {
 // as calling fe.Error()
 func (fe *fieldError) Translate(ut ut.Translator) string {
 
+	// unmarshaled FieldError's have no translations
+	if fe.v == nil {
+		return fe.Error()
+	}
+
 	m, ok := fe.v.transTagFunc[ut]
 	if !ok {
 		return fe.Error()
@@ -270,3 +449,288 @@ func (fe *fieldError) Translate(ut ut.Translator) string {
 
 	return fn(ut, fe)
 }
+
+// FieldErrorOptions describes the FieldError returned by NewFieldError
+type FieldErrorOptions struct {
+	Namespace       string      // eg. User.email, using the names returned by the tag name func
+	StructNamespace string      // eg. User.Email, defaults to Namespace
+	Field           string      // last element of Namespace, eg. email
+	StructField     string      // last element of StructNamespace, defaults to Field
+	Tag             string      // validation tag that failed, or the alias when an alias was used
+	ActualTag       string      // validation tag within the alias that failed, defaults to Tag
+	Param           string      // param of the validation tag, if any
+	Value           interface{} // value of the field
+	Path            Path        // path of the field, parsed from the namespaces when nil
+}
+
+// NewFieldError returns a FieldError identical to the one Struct reports when the field
+// described by opts fails it's validation; it exists for validators that do not go through
+// Struct, such as those generated by cmd/validator-gen, and ties the error to v so that
+// translations registered with v are used by Translate.
+func (v *Validate) NewFieldError(opts FieldErrorOptions) FieldError {
+
+	if len(opts.StructNamespace) == 0 {
+		opts.StructNamespace = opts.Namespace
+	}
+
+	if len(opts.StructField) == 0 {
+		opts.StructField = opts.Field
+	}
+
+	if len(opts.ActualTag) == 0 {
+		opts.ActualTag = opts.Tag
+	}
+
+	fe := &fieldError{
+		v:              v,
+		tag:            opts.Tag,
+		actualTag:      opts.ActualTag,
+		ns:             opts.Namespace,
+		structNs:       opts.StructNamespace,
+		fieldLen:       uint8(len(opts.Field)),
+		structfieldLen: uint8(len(opts.StructField)),
+		value:          opts.Value,
+		param:          opts.Param,
+		path:           opts.Path,
+	}
+
+	if opts.Value != nil {
+		fe.typ = reflect.TypeOf(opts.Value)
+		fe.kind = fe.typ.Kind()
+	}
+
+	return fe
+}
+
+// jsonFieldError is the JSON representation of a FieldError
+type jsonFieldError struct {
+	Namespace       string          `json:"namespace"`
+	StructNamespace string          `json:"struct_namespace"`
+	Field           string          `json:"field"`
+	StructField     string          `json:"struct_field"`
+	Tag             string          `json:"tag"`
+	ActualTag       string          `json:"actual_tag"`
+	Param           string          `json:"param"`
+	Kind            string          `json:"kind"`
+	Type            string          `json:"type"`
+	Value           json.RawMessage `json:"value,omitempty"`
+	Message         string          `json:"message,omitempty"`
+	Truncated       bool            `json:"truncated,omitempty"`
+}
+
+// MarshalJSON returns the ValidationErrors as a JSON array of FieldError's, see
+// MarshalJSONTranslated for the schema used; it is always an array, even when empty.
+func (ve ValidationErrors) MarshalJSON() ([]byte, error) {
+	return ve.MarshalJSONTranslated(nil)
+}
+
+// MarshalJSONTranslated returns the ValidationErrors as a JSON array of FieldError's,
+// including each one's message translated using trans when it is not nil.
+//
+// Each FieldError is an object of the form:
+//
+//	{
+//		"namespace": "User.email",
+//		"struct_namespace": "User.Email",
+//		"field": "email",
+//		"struct_field": "Email",
+//		"tag": "email",
+//		"actual_tag": "email",
+//		"param": "",
+//		"kind": "string",
+//		"type": "string",
+//		"value": "not an email",
+//		"message": "email must be a valid email address"
+//	}
+//
+// value is only included for bools, numbers and strings, the values of other kinds,
+// such as structs, maps and pointers, may not be representable or be too large to include.
+// message is only included when translated. "truncated": true is included on the last
+// FieldError when validation stopped at the maximum number of errors, see Truncated.
+func (ve ValidationErrors) MarshalJSONTranslated(trans ut.Translator) ([]byte, error) {
+
+	errs := make([]*jsonFieldError, len(ve))
+
+	for i := 0; i < len(ve); i++ {
+		errs[i] = newJSONFieldError(ve[i], trans)
+	}
+
+	return json.Marshal(errs)
+}
+
+// UnmarshalJSON reconstructs ValidationErrors from the JSON returned by MarshalJSON
+// or MarshalJSONTranslated; the translated messages are discarded.
+//
+// Type only returns the Field's reflect Type for bools, numbers and strings, otherwise nil,
+// and Translate always returns the same as calling fe.Error() as the FieldError's are
+// not tied to a Validate instance.
+func (ve *ValidationErrors) UnmarshalJSON(b []byte) error {
+
+	var errs []*fieldError
+
+	if err := json.Unmarshal(b, &errs); err != nil {
+		return err
+	}
+
+	*ve = make(ValidationErrors, len(errs))
+
+	for i := 0; i < len(errs); i++ {
+		(*ve)[i] = errs[i]
+	}
+
+	return nil
+}
+
+// MarshalJSON returns the fieldError as JSON, see ValidationErrors.MarshalJSONTranslated
+func (fe *fieldError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newJSONFieldError(fe, nil))
+}
+
+// UnmarshalJSON reconstructs the fieldError from JSON, see ValidationErrors.UnmarshalJSON
+func (fe *fieldError) UnmarshalJSON(b []byte) error {
+
+	var jfe jsonFieldError
+
+	if err := json.Unmarshal(b, &jfe); err != nil {
+		return err
+	}
+
+	if !strings.HasSuffix(jfe.Namespace, jfe.Field) || !strings.HasSuffix(jfe.StructNamespace, jfe.StructField) {
+		return fmt.Errorf("validator: field '%s' is not within namespace '%s'", jfe.Field, jfe.Namespace)
+	}
+
+	if len(jfe.Field) > math.MaxUint8 || len(jfe.StructField) > math.MaxUint8 {
+		return fmt.Errorf("validator: field '%s' is too long", jfe.Field)
+	}
+
+	kind, ok := kindsByName[jfe.Kind]
+	if !ok {
+		return fmt.Errorf("validator: unknown kind '%s'", jfe.Kind)
+	}
+
+	*fe = fieldError{
+		tag:            jfe.Tag,
+		actualTag:      jfe.ActualTag,
+		ns:             jfe.Namespace,
+		structNs:       jfe.StructNamespace,
+		fieldLen:       uint8(len(jfe.Field)),
+		structfieldLen: uint8(len(jfe.StructField)),
+		param:          jfe.Param,
+		kind:           kind,
+		typ:            basicTypesByName[jfe.Type],
+		truncated:      jfe.Truncated,
+	}
+
+	if fe.typ == nil {
+		fe.typName = jfe.Type
+	}
+
+	if len(jfe.Value) == 0 {
+		return nil
+	}
+
+	if fe.typ == nil {
+		return json.Unmarshal(jfe.Value, &fe.value)
+	}
+
+	val := reflect.New(fe.typ)
+
+	if err := json.Unmarshal(jfe.Value, val.Interface()); err != nil {
+		return err
+	}
+
+	fe.value = val.Elem().Interface()
+
+	return nil
+}
+
+func newJSONFieldError(fe FieldError, trans ut.Translator) *jsonFieldError {
+
+	jfe := &jsonFieldError{
+		Namespace:       fe.Namespace(),
+		StructNamespace: fe.StructNamespace(),
+		Field:           fe.Field(),
+		StructField:     fe.StructField(),
+		Tag:             fe.Tag(),
+		ActualTag:       fe.ActualTag(),
+		Param:           fe.Param(),
+		Kind:            fe.Kind().String(),
+	}
+
+	f, ok := fe.(*fieldError)
+
+	if typ := fe.Type(); typ != nil {
+		jfe.Type = typ.String()
+	} else if ok {
+		jfe.Type = f.typName
+	}
+
+	if ok {
+		jfe.Truncated = f.truncated
+	}
+
+	if trans != nil {
+		jfe.Message = fe.Translate(trans)
+	}
+
+	if isJSONSafe(fe.Value()) {
+		// can't fail for the kinds allowed
+		jfe.Value, _ = json.Marshal(fe.Value())
+	}
+
+	return jfe
+}
+
+// isJSONSafe returns if the value is a bool, number or string that can be
+// represented in JSON
+func isJSONSafe(value interface{}) bool {
+
+	if value == nil {
+		return false
+	}
+
+	val := reflect.ValueOf(value)
+
+	switch val.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+
+	case reflect.Float32, reflect.Float64:
+		f := val.Float()
+		return !math.IsNaN(f) && !math.IsInf(f, 0)
+	}
+
+	return false
+}
+
+var (
+	kindsByName = func() map[string]reflect.Kind {
+
+		m := make(map[string]reflect.Kind)
+
+		for k := reflect.Invalid; k <= reflect.UnsafePointer; k++ {
+			m[k.String()] = k
+		}
+
+		return m
+	}()
+
+	basicTypesByName = func() map[string]reflect.Type {
+
+		m := make(map[string]reflect.Type)
+
+		for _, v := range []interface{}{
+			false, "",
+			int(0), int8(0), int16(0), int32(0), int64(0),
+			uint(0), uint8(0), uint16(0), uint32(0), uint64(0),
+			float32(0), float64(0),
+		} {
+			typ := reflect.TypeOf(v)
+			m[typ.String()] = typ
+		}
+
+		return m
+	}()
+)
@@ -0,0 +1,1201 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const exprTag = "expr"
+
+// exprOp is the kind of an exprNode
+type exprOp uint8
+
+const (
+	exprLiteral exprOp = iota
+	exprIdent
+	exprUnary
+	exprBinary
+	exprCall
+)
+
+// exprNode is a node of a compiled expression, see the expr tag
+type exprNode struct {
+	op    exprOp
+	tok   string      // operator or function name
+	val   interface{} // exprLiteral only
+	names []string    // exprIdent only, the path of the field within the parent struct
+	args  []*exprNode
+}
+
+// exprFuncs are the functions that can be called, by number of arguments
+var exprFuncs = map[string]int{
+	"len": 1,
+	"now": 0,
+}
+
+// binary operators by precedence, the higher binding tighter
+var exprPrecedence = map[string]int{
+	"||": 1,
+	"&&": 2,
+	"==": 3, "!=": 3, "<": 3, "<=": 3, ">": 3, ">=": 3,
+	"+": 4, "-": 4,
+	"*": 5, "/": 5, "%": 5,
+}
+
+// compileExpr parses the expression of an expr tag
+func compileExpr(s string) (*exprNode, error) {
+
+	p := &exprParser{lex: exprLexer{s: s}}
+	p.next()
+
+	node, err := p.parseBinary(1)
+	if err != nil {
+		return nil, err
+	}
+
+	switch p.tok.kind {
+	case tokEOF:
+	case tokErr:
+		return nil, errors.New(p.tok.s)
+	default:
+		return nil, fmt.Errorf("unexpected '%s'", p.tok.s)
+	}
+
+	return node, nil
+}
+
+type exprTokenKind uint8
+
+const (
+	tokEOF exprTokenKind = iota
+	tokNumber
+	tokString
+	tokIdent
+	tokOp
+	tokErr
+)
+
+type exprToken struct {
+	kind exprTokenKind
+	s    string
+	val  interface{} // tokNumber and tokString only
+}
+
+type exprLexer struct {
+	s   string
+	pos int
+}
+
+func (l *exprLexer) next() exprToken {
+
+	for l.pos < len(l.s) && (l.s[l.pos] == ' ' || l.s[l.pos] == '\t') {
+		l.pos++
+	}
+
+	if l.pos >= len(l.s) {
+		return exprToken{kind: tokEOF}
+	}
+
+	start := l.pos
+	c := l.s[l.pos]
+
+	switch {
+	case c >= '0' && c <= '9':
+		return l.number()
+
+	case c == '\'' || c == '"':
+
+		l.pos++
+
+		for l.pos < len(l.s) && l.s[l.pos] != c {
+			l.pos++
+		}
+
+		if l.pos >= len(l.s) {
+			return exprToken{kind: tokErr, s: "unterminated string"}
+		}
+
+		l.pos++
+
+		return exprToken{kind: tokString, s: l.s[start:l.pos], val: l.s[start+1 : l.pos-1]}
+
+	case c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+
+		for l.pos < len(l.s) && (l.s[l.pos] == '_' || l.s[l.pos] == '.' || isAlphaNumeric(l.s[l.pos])) {
+			l.pos++
+		}
+
+		return exprToken{kind: tokIdent, s: l.s[start:l.pos]}
+	}
+
+	for _, op := range []string{"==", "!=", "<=", ">=", "&&", "||"} {
+		if strings.HasPrefix(l.s[l.pos:], op) {
+			l.pos += 2
+			return exprToken{kind: tokOp, s: op}
+		}
+	}
+
+	if strings.IndexByte("+-*/%<>!()", c) != -1 {
+		l.pos++
+		return exprToken{kind: tokOp, s: string(c)}
+	}
+
+	return exprToken{kind: tokErr, s: fmt.Sprintf("unexpected '%c'", c)}
+}
+
+// number lexes an int, float or duration such as 1h30m
+func (l *exprLexer) number() exprToken {
+
+	start := l.pos
+	isFloat := false
+
+	for l.pos < len(l.s) && (l.s[l.pos] >= '0' && l.s[l.pos] <= '9' || l.s[l.pos] == '.') {
+		isFloat = isFloat || l.s[l.pos] == '.'
+		l.pos++
+	}
+
+	if l.pos < len(l.s) && isAlphaNumeric(l.s[l.pos]) || strings.HasPrefix(l.s[l.pos:], "µ") {
+
+		for l.pos < len(l.s) && (isAlphaNumeric(l.s[l.pos]) || l.s[l.pos] == '.' || strings.HasPrefix(l.s[l.pos:], "µ")) {
+			_, size := utf8.DecodeRuneInString(l.s[l.pos:])
+			l.pos += size
+		}
+
+		d, err := time.ParseDuration(l.s[start:l.pos])
+		if err != nil {
+			return exprToken{kind: tokErr, s: fmt.Sprintf("invalid duration '%s'", l.s[start:l.pos])}
+		}
+
+		return exprToken{kind: tokNumber, s: l.s[start:l.pos], val: d}
+	}
+
+	s := l.s[start:l.pos]
+
+	if isFloat {
+
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return exprToken{kind: tokErr, s: fmt.Sprintf("invalid number '%s'", s)}
+		}
+
+		return exprToken{kind: tokNumber, s: s, val: f}
+	}
+
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return exprToken{kind: tokErr, s: fmt.Sprintf("invalid number '%s'", s)}
+	}
+
+	return exprToken{kind: tokNumber, s: s, val: i}
+}
+
+func isAlphaNumeric(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+type exprParser struct {
+	lex exprLexer
+	tok exprToken
+}
+
+func (p *exprParser) next() {
+	p.tok = p.lex.next()
+}
+
+// parseBinary parses binary operations binding at least as tightly as prec
+func (p *exprParser) parseBinary(prec int) (*exprNode, error) {
+
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+
+		opPrec, ok := exprPrecedence[p.tok.s]
+		if p.tok.kind != tokOp || !ok || opPrec < prec {
+			return left, nil
+		}
+
+		op := p.tok.s
+		p.next()
+
+		right, err := p.parseBinary(opPrec + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		left = &exprNode{op: exprBinary, tok: op, args: []*exprNode{left, right}}
+	}
+}
+
+func (p *exprParser) parseUnary() (*exprNode, error) {
+
+	if p.tok.kind == tokOp && (p.tok.s == "!" || p.tok.s == "-") {
+
+		op := p.tok.s
+		p.next()
+
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &exprNode{op: exprUnary, tok: op, args: []*exprNode{operand}}, nil
+	}
+
+	return p.parseOperand()
+}
+
+func (p *exprParser) parseOperand() (*exprNode, error) {
+
+	tok := p.tok
+
+	switch tok.kind {
+	case tokErr:
+		return nil, errors.New(tok.s)
+
+	case tokEOF:
+		return nil, errors.New("unexpected end of expression")
+
+	case tokNumber, tokString:
+		p.next()
+		return &exprNode{op: exprLiteral, val: tok.val}, nil
+
+	case tokIdent:
+
+		p.next()
+
+		switch tok.s {
+		case "true":
+			return &exprNode{op: exprLiteral, val: true}, nil
+		case "false":
+			return &exprNode{op: exprLiteral, val: false}, nil
+		case "nil":
+			return &exprNode{op: exprLiteral}, nil
+		}
+
+		if p.tok.kind == tokOp && p.tok.s == "(" {
+			return p.parseCall(tok.s)
+		}
+
+		names := strings.Split(tok.s, namespaceSeparator)
+
+		for _, name := range names {
+			if len(name) == 0 {
+				return nil, fmt.Errorf("invalid field '%s'", tok.s)
+			}
+		}
+
+		return &exprNode{op: exprIdent, names: names}, nil
+	}
+
+	if tok.s == "(" {
+
+		p.next()
+
+		node, err := p.parseBinary(1)
+		if err != nil {
+			return nil, err
+		}
+
+		if p.tok.kind != tokOp || p.tok.s != ")" {
+			return nil, errors.New("missing ')'")
+		}
+
+		p.next()
+
+		return node, nil
+	}
+
+	return nil, fmt.Errorf("unexpected '%s'", tok.s)
+}
+
+func (p *exprParser) parseCall(name string) (*exprNode, error) {
+
+	nargs, ok := exprFuncs[name]
+	if !ok {
+		return nil, fmt.Errorf("undefined function '%s'", name)
+	}
+
+	// skip the '('
+	p.next()
+
+	node := &exprNode{op: exprCall, tok: name}
+
+	if p.tok.kind != tokOp || p.tok.s != ")" {
+
+		arg, err := p.parseBinary(1)
+		if err != nil {
+			return nil, err
+		}
+
+		node.args = append(node.args, arg)
+	}
+
+	if p.tok.kind != tokOp || p.tok.s != ")" {
+		return nil, fmt.Errorf("missing ')' calling '%s'", name)
+	}
+
+	p.next()
+
+	if len(node.args) != nargs {
+		return nil, fmt.Errorf("'%s' takes %d argument(s)", name, nargs)
+	}
+
+	return node, nil
+}
+
+// eval evaluates the node, the fields being those of parent; it panics, as baked in validations do
+// when misused, when the expression can't be evaluated such as when adding a string to an int, and
+// with an exprEvalError when the values of the fields can't be, such as when dividing by zero.
+func (n *exprNode) eval(parent reflect.Value) interface{} {
+
+	switch n.op {
+	case exprLiteral:
+		return n.val
+
+	case exprIdent:
+		return exprField(parent, n.names)
+
+	case exprUnary:
+
+		val := n.args[0].eval(parent)
+
+		if n.tok == "!" {
+			return !exprBool(val, n.tok)
+		}
+
+		switch v := val.(type) {
+		case int64:
+
+			if v == math.MinInt64 {
+				panic(exprEvalError("integer overflow"))
+			}
+
+			return -v
+
+		case float64:
+			return -v
+
+		case time.Duration:
+
+			if v == math.MinInt64 {
+				panic(exprEvalError("integer overflow"))
+			}
+
+			return -v
+		}
+
+		panic(fmt.Sprintf("expr: can't negate %s", exprTypeName(val)))
+
+	case exprCall:
+
+		if n.tok == "now" {
+			return time.Now()
+		}
+
+		return exprLen(n.args[0].eval(parent))
+	}
+
+	// binary, the logical operators short circuiting
+	switch n.tok {
+	case "&&":
+		return exprBool(n.args[0].eval(parent), n.tok) && exprBool(n.args[1].eval(parent), n.tok)
+	case "||":
+		return exprBool(n.args[0].eval(parent), n.tok) || exprBool(n.args[1].eval(parent), n.tok)
+	}
+
+	return exprBinaryOp(n.tok, n.args[0].eval(parent), n.args[1].eval(parent))
+}
+
+// exprField returns the value of the field at the path within parent, nil when a pointer along
+// the way is nil.
+func exprField(parent reflect.Value, names []string) interface{} {
+
+	current := parent
+
+	for _, name := range names {
+
+		for current.Kind() == reflect.Ptr || current.Kind() == reflect.Interface {
+
+			if current.IsNil() {
+				return nil
+			}
+
+			current = current.Elem()
+		}
+
+		if current.Kind() != reflect.Struct {
+			panic(fmt.Sprintf("expr: can't get field '%s' of %s", strings.Join(names, namespaceSeparator), current.Kind()))
+		}
+
+		fld, ok := current.Type().FieldByName(name)
+		if !ok {
+			panic(fmt.Sprintf("expr: undefined field '%s'", strings.Join(names, namespaceSeparator)))
+		}
+
+		current = current.FieldByIndex(fld.Index)
+	}
+
+	return exprValue(current)
+}
+
+// exprValue converts the value to one of the types expressions operate on: int64, float64, string, bool,
+// time.Time, time.Duration or nil; collections, and structs, being kept as a reflect.Value.
+func exprValue(current reflect.Value) interface{} {
+
+	for current.Kind() == reflect.Ptr || current.Kind() == reflect.Interface {
+
+		if current.IsNil() {
+			return nil
+		}
+
+		current = current.Elem()
+	}
+
+	switch current.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+
+		if current.Type() == durationType {
+			return time.Duration(current.Int())
+		}
+
+		return current.Int()
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+
+		// values that don't fit an int64 are floats, rather than overflowing
+		if u := current.Uint(); u <= math.MaxInt64 {
+			return int64(u)
+		}
+
+		return float64(current.Uint())
+
+	case reflect.Float32, reflect.Float64:
+		return current.Float()
+
+	case reflect.String:
+		return current.String()
+
+	case reflect.Bool:
+		return current.Bool()
+
+	case reflect.Struct:
+
+		if current.Type() == timeType {
+
+			if !current.CanInterface() {
+				panic("expr: can't read unexported time.Time field")
+			}
+
+			return current.Interface()
+		}
+	}
+
+	return current
+}
+
+func exprBool(val interface{}, op string) bool {
+
+	b, ok := val.(bool)
+	if !ok {
+		panic(fmt.Sprintf("expr: '%s' requires bools, not %s", op, exprTypeName(val)))
+	}
+
+	return b
+}
+
+func exprLen(val interface{}) int64 {
+
+	switch v := val.(type) {
+	case nil:
+		return 0
+	case string:
+		return int64(utf8.RuneCountInString(v))
+	case reflect.Value:
+		switch v.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+			return int64(v.Len())
+		}
+	}
+
+	panic(fmt.Sprintf("expr: can't get len of %s", exprTypeName(val)))
+}
+
+func exprTypeName(val interface{}) string {
+
+	switch v := val.(type) {
+	case nil:
+		return "nil"
+	case reflect.Value:
+		return v.Type().String()
+	case int64:
+		return "int"
+	case float64:
+		return "float"
+	}
+
+	return reflect.TypeOf(val).String()
+}
+
+// exprBinaryOp applies the arithmetic or comparison operator to the values
+func exprBinaryOp(op string, left, right interface{}) interface{} {
+
+	switch op {
+	case "==":
+		return exprEqual(left, right)
+	case "!=":
+		return !exprEqual(left, right)
+	}
+
+	// nil, such as a field behind a nil pointer, is nil when used in arithmetic and never ordered
+	if left == nil || right == nil {
+
+		switch op {
+		case "<", "<=", ">", ">=":
+			return false
+		}
+
+		return nil
+	}
+
+	// time arithmetic and comparisons
+	if lt, ok := left.(time.Time); ok {
+
+		switch r := right.(type) {
+		case time.Time:
+
+			switch op {
+			case "-":
+				return lt.Sub(r)
+			case "<":
+				return lt.Before(r)
+			case "<=":
+				return !lt.After(r)
+			case ">":
+				return lt.After(r)
+			case ">=":
+				return !lt.Before(r)
+			}
+
+		case time.Duration:
+
+			switch op {
+			case "+":
+				return lt.Add(r)
+			case "-":
+				return lt.Add(-r)
+			}
+		}
+
+	} else if rt, ok := right.(time.Time); ok {
+
+		if ld, ok := left.(time.Duration); ok && op == "+" {
+			return rt.Add(ld)
+		}
+
+	} else if ls, ok := left.(string); ok {
+
+		if rs, ok := right.(string); ok {
+
+			switch op {
+			case "+":
+				return ls + rs
+			case "<":
+				return ls < rs
+			case "<=":
+				return ls <= rs
+			case ">":
+				return ls > rs
+			case ">=":
+				return ls >= rs
+			}
+		}
+
+	} else if result, ok := exprNumberOp(op, left, right); ok {
+		return result
+	}
+
+	panic(fmt.Sprintf("expr: invalid operation %s %s %s", exprTypeName(left), op, exprTypeName(right)))
+}
+
+// exprNumberOp applies the operator to ints, floats and durations; an int and a float giving a float and
+// a duration combined with an int giving a duration, except when comparing.
+func exprNumberOp(op string, left, right interface{}) (interface{}, bool) {
+
+	ld, lDur := left.(time.Duration)
+	rd, rDur := right.(time.Duration)
+
+	if lDur {
+		left = int64(ld)
+	}
+
+	if rDur {
+		right = int64(rd)
+	}
+
+	li, lInt := left.(int64)
+	ri, rInt := right.(int64)
+
+	if lInt && rInt {
+
+		var result int64
+
+		switch op {
+		case "+":
+
+			result = li + ri
+
+			if (result > li) != (ri > 0) {
+				panic(exprEvalError("integer overflow"))
+			}
+
+		case "-":
+
+			result = li - ri
+
+			if (result < li) != (ri > 0) {
+				panic(exprEvalError("integer overflow"))
+			}
+
+		case "*":
+
+			result = li * ri
+
+			if li != 0 && (result/li != ri || (li == -1 && ri == math.MinInt64)) {
+				panic(exprEvalError("integer overflow"))
+			}
+
+		case "/", "%":
+
+			if ri == 0 {
+				panic(exprEvalError("integer division by zero"))
+			}
+
+			if li == math.MinInt64 && ri == -1 {
+
+				if op == "/" {
+					panic(exprEvalError("integer overflow"))
+				}
+
+				break
+			}
+
+			if op == "/" {
+				result = li / ri
+			} else {
+				result = li % ri
+			}
+
+		case "<":
+			return li < ri, true
+		case "<=":
+			return li <= ri, true
+		case ">":
+			return li > ri, true
+		case ">=":
+			return li >= ri, true
+		default:
+			return nil, false
+		}
+
+		// a duration divided by a duration is a plain number, as is a duration multiplied by a duration
+		if (lDur || rDur) && !(lDur && rDur && (op == "/" || op == "*")) {
+			return time.Duration(result), true
+		}
+
+		return result, true
+	}
+
+	if lDur || rDur {
+		return nil, false
+	}
+
+	lf, lOK := exprFloat(left)
+	rf, rOK := exprFloat(right)
+
+	if !lOK || !rOK {
+		return nil, false
+	}
+
+	switch op {
+	case "+":
+		return lf + rf, true
+	case "-":
+		return lf - rf, true
+	case "*":
+		return lf * rf, true
+	case "/":
+		return lf / rf, true
+	case "%":
+		return math.Mod(lf, rf), true
+	case "<":
+		return lf < rf, true
+	case "<=":
+		return lf <= rf, true
+	case ">":
+		return lf > rf, true
+	case ">=":
+		return lf >= rf, true
+	}
+
+	return nil, false
+}
+
+func exprFloat(val interface{}) (float64, bool) {
+
+	switch v := val.(type) {
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+
+	return 0, false
+}
+
+// exprEqual returns if the values are equal, numbers being compared by value regardless of type and
+// nil being equal to nil pointers, slices and maps.
+func exprEqual(left, right interface{}) bool {
+
+	if left == nil || right == nil {
+		return exprIsNil(left) && exprIsNil(right)
+	}
+
+	// durations are compared as ints, so they can be compared to 0
+	if d, ok := left.(time.Duration); ok {
+		left = int64(d)
+	}
+
+	if d, ok := right.(time.Duration); ok {
+		right = int64(d)
+	}
+
+	if lt, ok := left.(time.Time); ok {
+		rt, ok := right.(time.Time)
+		return ok && lt.Equal(rt)
+	}
+
+	if lf, ok := exprFloat(left); ok {
+		rf, ok := exprFloat(right)
+		return ok && lf == rf
+	}
+
+	if _, ok := left.(reflect.Value); ok {
+		panic(fmt.Sprintf("expr: can't compare %s", exprTypeName(left)))
+	}
+
+	if _, ok := right.(reflect.Value); ok {
+		panic(fmt.Sprintf("expr: can't compare %s", exprTypeName(right)))
+	}
+
+	return left == right
+}
+
+func exprIsNil(val interface{}) bool {
+
+	if val == nil {
+		return true
+	}
+
+	if v, ok := val.(reflect.Value); ok {
+		switch v.Kind() {
+		case reflect.Slice, reflect.Map, reflect.Chan, reflect.Func:
+			return v.IsNil()
+		}
+	}
+
+	return false
+}
+
+// exprEvalError is panicked when an expression can't be evaluated because of the values of the
+// fields, rather than it being misused, such as when dividing by zero; failing the validation.
+type exprEvalError string
+
+// isExpr is the validation of the expr tag, the expression being compiled once when the tag is parsed
+func isExpr(fl FieldLevel) (valid bool) {
+
+	v := fl.(*validate)
+
+	defer func() {
+		if r := recover(); r != nil {
+
+			if _, ok := r.(exprEvalError); !ok {
+				panic(r)
+			}
+
+			valid = false
+		}
+	}()
+
+	b, ok := v.ct.expr.eval(v.slflParent).(bool)
+	if !ok {
+		panic(fmt.Sprintf("expr: '%s' doesn't evaluate to a bool", v.ct.param))
+	}
+
+	return b
+}
+
+// splitExprOr splits or'ed tags on the '|' separator, but not on the '||' operator of an expression
+func splitExprOr(t string) []string {
+
+	var vals []string
+
+	start := 0
+
+	for i := 0; i < len(t); i++ {
+
+		if t[i] != '|' {
+			continue
+		}
+
+		if i+1 < len(t) && t[i+1] == '|' {
+			i++
+			continue
+		}
+
+		vals = append(vals, t[start:i])
+		start = i + 1
+	}
+
+	return append(vals, t[start:])
+}
+
+// compileExprTag compiles the expression of an expr tag
+func (v *Validate) compileExprTag(param string) (*exprNode, *InvalidTagError) {
+
+	node, err := compileExpr(param)
+	if err != nil {
+		return nil, &InvalidTagError{Reason: fmt.Sprintf("Invalid expression '%s': %s", param, err)}
+	}
+
+	return node, nil
+}
+
+// checkExprTags checks the expressions of the expr tags of ct, and of the tags of its keys, against the
+// struct type parent the expressions are evaluated over; see exprNode.check.
+func checkExprTags(ct *cTag, parent reflect.Type, fieldName string) *InvalidTagError {
+
+	for ; ct != nil; ct = ct.next {
+
+		if ct.keys != nil {
+			if err := checkExprTags(ct.keys, parent, fieldName); err != nil {
+				return err
+			}
+		}
+
+		if ct.expr == nil {
+			continue
+		}
+
+		kind, typ, err := ct.expr.check(parent)
+		if err == nil && kind != exprAnyKind && kind != exprBoolKind {
+			err = fmt.Errorf("evaluates to %s, not a bool", kind.name(typ))
+		}
+
+		if err != nil {
+			return &InvalidTagError{
+				Tag:    exprTag + tagKeySeparator + ct.param,
+				Reason: fmt.Sprintf("Invalid expression '%s': %s on field '%s'", ct.param, err, fieldName),
+			}
+		}
+	}
+
+	return nil
+}
+
+// exprKind is the kind of value a node evaluates to, as known before evaluating it, see exprNode.check
+type exprKind uint8
+
+const (
+	exprAnyKind exprKind = iota // of an interface, only known once evaluated
+	exprNilKind
+	exprIntKind
+	exprFloatKind
+	exprDurationKind
+	exprStringKind
+	exprBoolKind
+	exprTimeKind
+	exprValueKind // collections and structs, kept as a reflect.Value
+)
+
+// name returns the name of the kind as the panics of eval name values, typ being that of an exprValueKind
+func (k exprKind) name(typ reflect.Type) string {
+
+	switch k {
+	case exprNilKind:
+		return "nil"
+	case exprIntKind:
+		return "int"
+	case exprFloatKind:
+		return "float"
+	case exprDurationKind:
+		return durationType.String()
+	case exprStringKind:
+		return "string"
+	case exprBoolKind:
+		return "bool"
+	case exprTimeKind:
+		return timeType.String()
+	case exprValueKind:
+		return typ.String()
+	}
+
+	return "interface{}"
+}
+
+// exprKindOf returns the kind of value a field of type typ evaluates to, as exprValue converts it
+func exprKindOf(typ reflect.Type) (exprKind, reflect.Type) {
+
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case reflect.Interface:
+		return exprAnyKind, nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+
+		if typ == durationType {
+			return exprDurationKind, nil
+		}
+
+		return exprIntKind, nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return exprIntKind, nil
+
+	case reflect.Float32, reflect.Float64:
+		return exprFloatKind, nil
+
+	case reflect.String:
+		return exprStringKind, nil
+
+	case reflect.Bool:
+		return exprBoolKind, nil
+
+	case reflect.Struct:
+
+		if typ == timeType {
+			return exprTimeKind, nil
+		}
+	}
+
+	return exprValueKind, typ
+}
+
+// check resolves the fields of the node against the struct type parent, and checks the operands of its
+// operators and functions, as eval would; returning the kind of value the node evaluates to, along with
+// its type when an exprValueKind. Fields within interfaces are only known once evaluated, so the nodes
+// using them aren't checked.
+func (n *exprNode) check(parent reflect.Type) (exprKind, reflect.Type, error) {
+
+	switch n.op {
+	case exprLiteral:
+
+		switch n.val.(type) {
+		case nil:
+			return exprNilKind, nil, nil
+		case int64:
+			return exprIntKind, nil, nil
+		case float64:
+			return exprFloatKind, nil, nil
+		case time.Duration:
+			return exprDurationKind, nil, nil
+		case bool:
+			return exprBoolKind, nil, nil
+		}
+
+		return exprStringKind, nil, nil
+
+	case exprIdent:
+		return exprCheckField(parent, n.names)
+
+	case exprCall:
+
+		if n.tok == "now" {
+			return exprTimeKind, nil, nil
+		}
+
+		kind, typ, err := n.args[0].check(parent)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		switch kind {
+		case exprAnyKind, exprNilKind, exprStringKind:
+			return exprIntKind, nil, nil
+		case exprValueKind:
+			switch typ.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+				return exprIntKind, nil, nil
+			}
+		}
+
+		return 0, nil, fmt.Errorf("can't get len of %s", kind.name(typ))
+	}
+
+	left, ltyp, err := n.args[0].check(parent)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if n.op == exprUnary {
+
+		switch {
+		case left == exprAnyKind:
+		case n.tok == "!" && left != exprBoolKind:
+			return 0, nil, fmt.Errorf("'%s' requires bools, not %s", n.tok, left.name(ltyp))
+		case n.tok == "-" && left != exprIntKind && left != exprFloatKind && left != exprDurationKind:
+			return 0, nil, fmt.Errorf("can't negate %s", left.name(ltyp))
+		}
+
+		return left, nil, nil
+	}
+
+	right, rtyp, err := n.args[1].check(parent)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	switch n.tok {
+	case "&&", "||":
+
+		if left != exprAnyKind && left != exprBoolKind {
+			return 0, nil, fmt.Errorf("'%s' requires bools, not %s", n.tok, left.name(ltyp))
+		}
+
+		if right != exprAnyKind && right != exprBoolKind {
+			return 0, nil, fmt.Errorf("'%s' requires bools, not %s", n.tok, right.name(rtyp))
+		}
+
+		return exprBoolKind, nil, nil
+
+	case "==", "!=":
+
+		if left != exprNilKind && right != exprNilKind {
+
+			if left == exprValueKind {
+				return 0, nil, fmt.Errorf("can't compare %s", left.name(ltyp))
+			}
+
+			if right == exprValueKind {
+				return 0, nil, fmt.Errorf("can't compare %s", right.name(rtyp))
+			}
+		}
+
+		return exprBoolKind, nil, nil
+	}
+
+	if kind, ok := exprCheckBinaryOp(n.tok, left, right); ok {
+		return kind, nil, nil
+	}
+
+	return 0, nil, fmt.Errorf("invalid operation %s %s %s", left.name(ltyp), n.tok, right.name(rtyp))
+}
+
+// exprCheckField returns the kind of value the field at the path within the struct type parent evaluates to
+func exprCheckField(parent reflect.Type, names []string) (exprKind, reflect.Type, error) {
+
+	current := parent
+
+	for _, name := range names {
+
+		for current.Kind() == reflect.Ptr {
+			current = current.Elem()
+		}
+
+		if current.Kind() == reflect.Interface {
+			return exprAnyKind, nil, nil
+		}
+
+		if current.Kind() != reflect.Struct {
+			return 0, nil, fmt.Errorf("can't get field '%s' of %s", strings.Join(names, namespaceSeparator), current.Kind())
+		}
+
+		fld, ok := current.FieldByName(name)
+		if !ok {
+			return 0, nil, fmt.Errorf("undefined field '%s'", strings.Join(names, namespaceSeparator))
+		}
+
+		current = fld.Type
+	}
+
+	kind, typ := exprKindOf(current)
+
+	return kind, typ, nil
+}
+
+// exprCheckBinaryOp returns the kind of value the arithmetic or comparison operator gives, as exprBinaryOp
+// does, or false when the operator can't be applied to the kinds of values.
+func exprCheckBinaryOp(op string, left, right exprKind) (exprKind, bool) {
+
+	compare := op == "<" || op == "<=" || op == ">" || op == ">="
+
+	result := func(kind exprKind) (exprKind, bool) {
+		if compare {
+			return exprBoolKind, true
+		}
+		return kind, true
+	}
+
+	switch {
+	case left == exprAnyKind || right == exprAnyKind:
+		return result(exprAnyKind)
+
+	case left == exprNilKind || right == exprNilKind:
+		return result(exprNilKind)
+
+	case left == exprTimeKind && right == exprTimeKind:
+
+		if op == "-" {
+			return exprDurationKind, true
+		}
+
+		if compare {
+			return exprBoolKind, true
+		}
+
+	case left == exprTimeKind && right == exprDurationKind:
+
+		if op == "+" || op == "-" {
+			return exprTimeKind, true
+		}
+
+	case left == exprDurationKind && right == exprTimeKind:
+
+		if op == "+" {
+			return exprTimeKind, true
+		}
+
+	case left == exprStringKind && right == exprStringKind:
+
+		if op == "+" || compare {
+			return result(exprStringKind)
+		}
+
+	case (left == exprIntKind || left == exprDurationKind) && (right == exprIntKind || right == exprDurationKind):
+
+		lDur, rDur := left == exprDurationKind, right == exprDurationKind
+
+		// a duration divided by a duration is a plain number, as is a duration multiplied by a duration
+		if (lDur || rDur) && !(lDur && rDur && (op == "/" || op == "*")) {
+			return result(exprDurationKind)
+		}
+
+		return result(exprIntKind)
+
+	case (left == exprIntKind || left == exprFloatKind) && (right == exprIntKind || right == exprFloatKind):
+		return result(exprFloatKind)
+	}
+
+	return 0, false
+}
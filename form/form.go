@@ -0,0 +1,615 @@
+/*
+Package form decodes url.Values, such as the parameters of HTML forms and query strings, into
+structs; the parameters being named the same as the namespaces of FieldError's, so that a
+validation error can be mapped back to the input that produced it.
+
+Parameters are named by the path of the field they're decoded into, without the top level
+struct's name:
+
+	name=joeybloggs                a field, named by it's form tag or the field's name
+	address.city=Sydney            a field of a nested struct
+	tags=a&tags=b                  the elements of a slice, or array, of scalars
+	items[0].sku=A1                an element of a slice or array, by index
+	attrs[color]=red               an element of a map, by key
+
+Embedded structs are nested under their type's name, eg. Base.id, the same as within namespaces;
+and a form tag of "-" skips the field. Parameters that don't name a field are ignored.
+
+Fields of the basic kinds, those implementing encoding.TextUnmarshaler, such as time.Time, and
+pointers to those are scalars, decoded from a single value; the first when the parameter has
+several. Empty values leave the field unset, as browsers submit empty inputs, and booleans also
+accept "on" and "off", the value submitted by checkboxes.
+
+Validating the decoded struct using a Validate instance with the decoder's TagNameFunc registered,
+the Path of each FieldError is the name of the parameter:
+
+	validate := validator.New()
+	validate.RegisterTagNameFunc(form.TagNameFunc)
+
+	var order Order
+
+	if err := form.Decode(r.PostForm, &order); err != nil {
+		...
+	}
+
+	if err := validate.Struct(order); err != nil {
+
+		for _, fe := range err.(validator.ValidationErrors) {
+			fmt.Println(form.Name(fe)) // eg. items[0].sku
+		}
+	}
+
+Map keys are within brackets as they are within namespaces, without escaping, so a key containing
+a ']' can't be decoded.
+*/
+package form
+
+import (
+	"bytes"
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"package/validator"
+)
+
+const (
+	defaultTagName = "form"
+	skipTag        = "-"
+)
+
+// DefaultMaxIndex is the largest index of a slice decoded, unless set using SetMaxIndex
+const DefaultMaxIndex = 1000
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// InvalidDecodeError describes an invalid argument passed to Decode
+type InvalidDecodeError struct {
+	Type reflect.Type
+}
+
+// Error returns InvalidDecodeError message
+func (e *InvalidDecodeError) Error() string {
+
+	if e.Type == nil {
+		return "form: Decode(nil)"
+	}
+
+	return "form: Decode(non-pointer to struct " + e.Type.String() + ")"
+}
+
+// DecodeError is the error decoding a single parameter
+type DecodeError struct {
+	Name string // name of the parameter
+	Err  error  // error decoding it's value
+}
+
+// Error returns DecodeError message
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("form: invalid value for '%s': %s", e.Name, e.Err)
+}
+
+// Unwrap returns the error decoding the parameter's value
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeErrors is an array of DecodeError's, one for each parameter that couldn't be decoded,
+// ordered by the parameters' names.
+type DecodeErrors []*DecodeError
+
+// Error is intended for use in development + debugging and not intended to be a production error message.
+func (de DecodeErrors) Error() string {
+
+	buff := bytes.NewBufferString("")
+
+	for i := 0; i < len(de); i++ {
+		buff.WriteString(de[i].Error())
+		buff.WriteString("\n")
+	}
+
+	return strings.TrimSpace(buff.String())
+}
+
+// Decoder decodes url.Values into structs, caching the fields of each struct type
+type Decoder struct {
+	tagName  string
+	maxIndex int
+	fields   sync.Map // map[reflect.Type]map[string]int, the index of each field by name
+}
+
+// NewDecoder returns a new Decoder using the form tag to name fields
+func NewDecoder() *Decoder {
+	return &Decoder{
+		tagName:  defaultTagName,
+		maxIndex: DefaultMaxIndex,
+	}
+}
+
+// SetTagName sets the tag naming the parameter of a field, form by default
+func (d *Decoder) SetTagName(name string) {
+	d.tagName = name
+	d.fields = sync.Map{}
+}
+
+// SetMaxIndex sets the largest index of a slice decoded, DefaultMaxIndex by default; larger indexes
+// being a DecodeError rather than growing the slice.
+func (d *Decoder) SetMaxIndex(n int) {
+	d.maxIndex = n
+}
+
+// TagNameFunc returns the name of the field's parameter, for registering with
+// Validate.RegisterTagNameFunc so that namespaces use the names of the parameters;
+// the field's name being used when it has none.
+func (d *Decoder) TagNameFunc(fld reflect.StructField) string {
+
+	name := strings.SplitN(fld.Tag.Get(d.tagName), ",", 2)[0]
+
+	if name == skipTag {
+		return ""
+	}
+
+	return name
+}
+
+var defaultDecoder = NewDecoder()
+
+// Decode decodes values into the struct dst points to, using the form tag to name fields; the same
+// as Decoder.Decode.
+func Decode(values url.Values, dst interface{}) error {
+	return defaultDecoder.Decode(values, dst)
+}
+
+// TagNameFunc returns the name of the field's parameter, using the form tag; the same as
+// Decoder.TagNameFunc.
+func TagNameFunc(fld reflect.StructField) string {
+	return defaultDecoder.TagNameFunc(fld)
+}
+
+// Name returns the name of the parameter the FieldError's field was decoded from, which is
+// it's Path when the Validate instance has the decoder's TagNameFunc registered.
+//
+// eg. "items[0].sku"
+func Name(fe validator.FieldError) string {
+	return fe.Path().String()
+}
+
+// Decode decodes values into the struct dst points to.
+//
+// It returns InvalidDecodeError when dst isn't a non nil pointer to a struct and DecodeErrors
+// when any of the parameters can't be decoded, the other parameters still being decoded.
+func (d *Decoder) Decode(values url.Values, dst interface{}) error {
+
+	val := reflect.ValueOf(dst)
+
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return &InvalidDecodeError{Type: reflect.TypeOf(dst)}
+	}
+
+	names := make([]string, 0, len(values))
+
+	for name := range values {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var errs DecodeErrors
+
+	for _, name := range names {
+
+		vals := values[name]
+		if len(vals) == 0 {
+			continue
+		}
+
+		segs, ok := parseName(name)
+		if !ok {
+			continue
+		}
+
+		if _, err := d.decode(val.Elem(), segs, vals); err != nil {
+			errs = append(errs, &DecodeError{Name: name, Err: err})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// segment is a single step of a parameter's name; a field's name or the bracketed index or key
+type segment struct {
+	s         string
+	bracketed bool
+}
+
+// parseName splits the parameter's name into it's segments, returning false when it's malformed
+func parseName(name string) ([]segment, bool) {
+
+	var segs []segment
+
+	for i := 0; i < len(name); {
+
+		switch {
+		case name[i] == '[':
+
+			end := strings.IndexByte(name[i:], ']')
+			if end < 0 {
+				return nil, false
+			}
+
+			segs = append(segs, segment{s: name[i+1 : i+end], bracketed: true})
+			i += end + 1
+
+			// a bracket is followed by another, a field or nothing
+			if i < len(name) && name[i] != '[' && name[i] != '.' {
+				return nil, false
+			}
+
+		default:
+
+			if name[i] == '.' {
+
+				if i == 0 {
+					return nil, false
+				}
+
+				i++
+			}
+
+			end := strings.IndexAny(name[i:], ".[")
+			if end < 0 {
+				end = len(name) - i
+			}
+
+			if end == 0 {
+				return nil, false
+			}
+
+			segs = append(segs, segment{s: name[i : i+end]})
+			i += end
+		}
+	}
+
+	if len(segs) == 0 || segs[0].bracketed {
+		return nil, false
+	}
+
+	return segs, true
+}
+
+// decode decodes the values into the field named by the segments within current, returning
+// false when they don't name a field; the parameter then being ignored.
+func (d *Decoder) decode(current reflect.Value, segs []segment, vals []string) (bool, error) {
+
+	if len(segs) == 0 {
+		return true, decodeField(current, vals)
+	}
+
+	if current.Kind() == reflect.Ptr {
+
+		if current.IsNil() {
+
+			if !current.CanSet() {
+				return false, nil
+			}
+
+			// only set once decoded, so that ignored parameters don't allocate
+			elem := reflect.New(current.Type().Elem())
+
+			found, err := d.decode(elem.Elem(), segs, vals)
+			if found {
+				current.Set(elem)
+			}
+
+			return found, err
+		}
+
+		current = current.Elem()
+	}
+
+	seg := segs[0]
+
+	if !seg.bracketed {
+
+		if current.Kind() != reflect.Struct || isScalar(current.Type()) {
+			return false, nil
+		}
+
+		idx, ok := d.structFields(current.Type())[seg.s]
+		if !ok {
+			return false, nil
+		}
+
+		return d.decode(current.Field(idx), segs[1:], vals)
+	}
+
+	switch current.Kind() {
+	case reflect.Slice:
+
+		if !current.CanSet() {
+			return false, nil
+		}
+
+		idx, err := d.index(seg.s, d.maxIndex)
+		if err != nil {
+			return true, err
+		}
+
+		if idx < current.Len() {
+			return d.decode(current.Index(idx), segs[1:], vals)
+		}
+
+		// the slice is only grown once decoded, so that ignored parameters don't grow it
+		elem := reflect.New(current.Type().Elem()).Elem()
+
+		found, err := d.decode(elem, segs[1:], vals)
+		if found {
+
+			grown := reflect.MakeSlice(current.Type(), idx+1, idx+1)
+			reflect.Copy(grown, current)
+			grown.Index(idx).Set(elem)
+			current.Set(grown)
+		}
+
+		return found, err
+
+	case reflect.Array:
+
+		idx, err := d.index(seg.s, current.Len()-1)
+		if err != nil {
+			return true, err
+		}
+
+		return d.decode(current.Index(idx), segs[1:], vals)
+
+	case reflect.Map:
+
+		if !current.CanSet() {
+			return false, nil
+		}
+
+		typ := current.Type()
+
+		key := reflect.New(typ.Key()).Elem()
+
+		if err := decodeValue(key, seg.s); err != nil {
+			return true, fmt.Errorf("invalid key '%s': %s", seg.s, err)
+		}
+
+		// map elements aren't addressable, so it's decoded into a copy that replaces the element
+		elem := reflect.New(typ.Elem()).Elem()
+
+		if !current.IsNil() {
+			if existing := current.MapIndex(key); existing.IsValid() {
+				elem.Set(existing)
+			}
+		}
+
+		found, err := d.decode(elem, segs[1:], vals)
+		if found {
+
+			if current.IsNil() {
+				current.Set(reflect.MakeMap(typ))
+			}
+
+			current.SetMapIndex(key, elem)
+		}
+
+		return found, err
+	}
+
+	return false, nil
+}
+
+// index parses the bracketed index of a slice or array, no larger than max
+func (d *Decoder) index(s string, max int) (int, error) {
+
+	idx, err := strconv.Atoi(s)
+	if err != nil || idx < 0 || strconv.Itoa(idx) != s {
+		return 0, fmt.Errorf("invalid index '%s'", s)
+	}
+
+	if idx > max {
+		return 0, fmt.Errorf("index %d out of range [0:%d]", idx, max+1)
+	}
+
+	return idx, nil
+}
+
+// structFields returns the index of each of the struct type's fields by the name of it's parameter
+func (d *Decoder) structFields(typ reflect.Type) map[string]int {
+
+	if fields, ok := d.fields.Load(typ); ok {
+		return fields.(map[string]int)
+	}
+
+	fields := make(map[string]int, typ.NumField())
+
+	for i := 0; i < typ.NumField(); i++ {
+
+		fld := typ.Field(i)
+
+		// embedded structs are named by their type, exported or not, the same as within namespaces
+		if !fld.Anonymous && len(fld.PkgPath) > 0 {
+			continue
+		}
+
+		tag := strings.SplitN(fld.Tag.Get(d.tagName), ",", 2)[0]
+
+		switch tag {
+		case skipTag:
+			continue
+		case "":
+			fields[fld.Name] = i
+		default:
+			fields[tag] = i
+		}
+	}
+
+	d.fields.Store(typ, fields)
+
+	return fields
+}
+
+// isScalar returns whether values of the type are decoded from a single parameter value
+func isScalar(typ reflect.Type) bool {
+
+	if reflect.PtrTo(typ).Implements(textUnmarshalerType) {
+		return true
+	}
+
+	switch typ.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
+
+// decodeField decodes the values into the field, a slice or array of scalars having all of them
+func decodeField(field reflect.Value, vals []string) error {
+
+	if !field.CanSet() {
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.Slice:
+
+		if isScalar(field.Type()) {
+			break
+		}
+
+		slice := reflect.MakeSlice(field.Type(), len(vals), len(vals))
+
+		for i, val := range vals {
+			if err := decodeValue(slice.Index(i), val); err != nil {
+				return err
+			}
+		}
+
+		field.Set(slice)
+
+		return nil
+
+	case reflect.Array:
+
+		if isScalar(field.Type()) {
+			break
+		}
+
+		if len(vals) > field.Len() {
+			return fmt.Errorf("%d values exceed the length of %s", len(vals), field.Type())
+		}
+
+		for i, val := range vals {
+			if err := decodeValue(field.Index(i), val); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return decodeValue(field, vals[0])
+}
+
+// decodeValue decodes a single value into the field, leaving it unset when the value is empty
+func decodeValue(field reflect.Value, val string) error {
+
+	if field.Kind() == reflect.Ptr {
+
+		if len(val) == 0 {
+			return nil
+		}
+
+		elem := reflect.New(field.Type().Elem())
+
+		if err := decodeValue(elem.Elem(), val); err != nil {
+			return err
+		}
+
+		field.Set(elem)
+
+		return nil
+	}
+
+	if field.CanAddr() && field.Addr().Type().Implements(textUnmarshalerType) {
+
+		if len(val) == 0 {
+			return nil
+		}
+
+		return field.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(val))
+	}
+
+	if len(val) == 0 && isScalar(field.Type()) {
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(val)
+
+	case reflect.Bool:
+
+		switch val {
+		case "on":
+			field.SetBool(true)
+		case "off":
+			field.SetBool(false)
+		default:
+
+			b, err := strconv.ParseBool(val)
+			if err != nil {
+				return err
+			}
+
+			field.SetBool(b)
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+
+		i, err := strconv.ParseInt(val, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		field.SetInt(i)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+
+		u, err := strconv.ParseUint(val, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		field.SetUint(u)
+
+	case reflect.Float32, reflect.Float64:
+
+		f, err := strconv.ParseFloat(val, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		field.SetFloat(f)
+
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+
+	return nil
+}
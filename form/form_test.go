@@ -0,0 +1,286 @@
+package form
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"package/validator"
+
+	. "gopkg.in/go-playground/assert.v1"
+)
+
+type Base struct {
+	ID int `form:"id"`
+}
+
+type Item struct {
+	SKU string `form:"sku" validate:"required"`
+	Qty int    `form:"qty" validate:"gte=1"`
+}
+
+type Address struct {
+	City string `form:"city" validate:"required"`
+}
+
+type Order struct {
+	Base
+	Name     string            `form:"name" validate:"required"`
+	Items    []Item            `form:"items" validate:"min=1,dive"`
+	Attrs    map[string]string `form:"attrs" validate:"dive,max=3"`
+	Lines    map[string]*Item  `form:"lines" validate:"dive"`
+	Counts   map[int]int       `form:"counts"`
+	Address  *Address          `form:"address"`
+	Tags     []string          `form:"tag" validate:"dive,alpha"`
+	Grid     [2][2]int         `form:"grid"`
+	Pair     [2]string         `form:"pair"`
+	Agree    bool              `form:"agree"`
+	Born     time.Time         `form:"born"`
+	Score    *float64          `form:"score"`
+	Note     string
+	Skipped  string `form:"-"`
+	internal string
+}
+
+func TestDecode(t *testing.T) {
+
+	values := url.Values{
+		"Base.id":         {"7"},
+		"name":            {"joeybloggs"},
+		"items[1].sku":    {"B2"},
+		"items[0].sku":    {"A1"},
+		"items[0].qty":    {"3"},
+		"attrs[color]":    {"red"},
+		"attrs[size]":     {"XL"},
+		"lines[a].sku":    {"C3"},
+		"lines[a].qty":    {"2"},
+		"counts[5]":       {"10"},
+		"address.city":    {"Sydney"},
+		"tag":             {"a", "b"},
+		"grid[1][0]":      {"4"},
+		"pair":            {"x", "y"},
+		"agree":           {"on"},
+		"born":            {"2020-01-02T03:04:05Z"},
+		"score":           {"1.5"},
+		"Note":            {"first", "second"},
+		"Skipped":         {"x"},
+		"-":               {"x"},
+		"internal":        {"x"},
+		"unknown":         {"x"},
+		"name.first":      {"x"},
+		"items[0].colour": {"x"},
+		"items[5].colour": {"x"},
+		"lines[b].colour": {"x"},
+		"attrs[":          {"x"},
+		"[0]":             {"x"},
+		"items[0]sku":     {"x"},
+		"items..sku":      {"x"},
+		"empty":           {},
+	}
+
+	var order Order
+
+	err := Decode(values, &order)
+	Equal(t, err, nil)
+	Equal(t, order.ID, 7)
+	Equal(t, order.Name, "joeybloggs")
+	Equal(t, order.Items, []Item{{SKU: "A1", Qty: 3}, {SKU: "B2"}})
+	Equal(t, order.Attrs, map[string]string{"color": "red", "size": "XL"})
+	Equal(t, len(order.Lines), 1)
+	Equal(t, *order.Lines["a"], Item{SKU: "C3", Qty: 2})
+	Equal(t, order.Counts, map[int]int{5: 10})
+	Equal(t, order.Address.City, "Sydney")
+	Equal(t, order.Tags, []string{"a", "b"})
+	Equal(t, order.Grid, [2][2]int{{0, 0}, {4, 0}})
+	Equal(t, order.Pair, [2]string{"x", "y"})
+	Equal(t, order.Agree, true)
+	Equal(t, order.Born, time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))
+	Equal(t, *order.Score, 1.5)
+	Equal(t, order.Note, "first")
+	Equal(t, order.Skipped, "")
+	Equal(t, order.internal, "")
+
+	// empty values leave the fields unset
+	order = Order{}
+
+	err = Decode(url.Values{"agree": {""}, "score": {""}, "born": {""}, "counts[1]": {""}, "name": {""}}, &order)
+	Equal(t, err, nil)
+	Equal(t, order.Agree, false)
+	Equal(t, order.Score, nil)
+	Equal(t, order.Born.IsZero(), true)
+	Equal(t, order.Counts, map[int]int{1: 0})
+
+	// ignored parameters don't allocate
+	order = Order{}
+
+	err = Decode(url.Values{"address.street": {"x"}, "lines[a].colour": {"x"}, "items[2].colour": {"x"}}, &order)
+	Equal(t, err, nil)
+	Equal(t, order.Address, nil)
+	Equal(t, order.Lines, nil)
+	Equal(t, order.Items, nil)
+
+	// decoding into existing elements
+	order = Order{Items: []Item{{SKU: "A1", Qty: 1}}, Lines: map[string]*Item{"a": {SKU: "C3"}}}
+
+	err = Decode(url.Values{"items[0].qty": {"5"}, "lines[a].qty": {"6"}, "agree": {"off"}}, &order)
+	Equal(t, err, nil)
+	Equal(t, order.Items, []Item{{SKU: "A1", Qty: 5}})
+	Equal(t, *order.Lines["a"], Item{SKU: "C3", Qty: 6})
+	Equal(t, order.Agree, false)
+}
+
+func TestDecodeErrors(t *testing.T) {
+
+	values := url.Values{
+		"name":          {"joeybloggs"},
+		"items[0].qty":  {"x"},
+		"items[x].sku":  {"A1"},
+		"items[01].sku": {"A1"},
+		"items[1001]":   {"A1"},
+		"grid[2][0]":    {"1"},
+		"counts[x]":     {"1"},
+		"pair":          {"a", "b", "c"},
+		"agree":         {"yes"},
+		"Base.id":       {"1.5"},
+	}
+
+	var order Order
+
+	err := Decode(values, &order)
+	NotEqual(t, err, nil)
+
+	errs, ok := err.(DecodeErrors)
+	Equal(t, ok, true)
+
+	expected := []struct {
+		name string
+		err  string
+	}{
+		{name: "Base.id", err: `form: invalid value for 'Base.id': strconv.ParseInt: parsing "1.5": invalid syntax`},
+		{name: "agree", err: `form: invalid value for 'agree': strconv.ParseBool: parsing "yes": invalid syntax`},
+		{name: "counts[x]", err: `form: invalid value for 'counts[x]': invalid key 'x': strconv.ParseInt: parsing "x": invalid syntax`},
+		{name: "grid[2][0]", err: `form: invalid value for 'grid[2][0]': index 2 out of range [0:2]`},
+		{name: "items[01].sku", err: `form: invalid value for 'items[01].sku': invalid index '01'`},
+		{name: "items[0].qty", err: `form: invalid value for 'items[0].qty': strconv.ParseInt: parsing "x": invalid syntax`},
+		{name: "items[1001]", err: `form: invalid value for 'items[1001]': index 1001 out of range [0:1001]`},
+		{name: "items[x].sku", err: `form: invalid value for 'items[x].sku': invalid index 'x'`},
+		{name: "pair", err: `form: invalid value for 'pair': 3 values exceed the length of [2]string`},
+	}
+
+	Equal(t, len(errs), len(expected))
+
+	for i, e := range expected {
+		Equal(t, errs[i].Name, e.name)
+		Equal(t, errs[i].Error(), e.err)
+	}
+
+	// the other parameters are still decoded
+	Equal(t, order.Name, "joeybloggs")
+
+	d := NewDecoder()
+	d.SetMaxIndex(1)
+
+	err = d.Decode(url.Values{"items[2].sku": {"A1"}}, &order)
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "form: invalid value for 'items[2].sku': index 2 out of range [0:2]")
+
+	err = Decode(url.Values{"Grid": {"1"}}, &struct{ Grid map[string]string }{})
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "form: invalid value for 'Grid': unsupported type map[string]string")
+
+	tests := []struct {
+		dst interface{}
+		err string
+	}{
+		{dst: nil, err: "form: Decode(nil)"},
+		{dst: order, err: "form: Decode(non-pointer to struct form.Order)"},
+		{dst: (*Order)(nil), err: "form: Decode(non-pointer to struct *form.Order)"},
+		{dst: new(string), err: "form: Decode(non-pointer to struct *string)"},
+	}
+
+	for _, tt := range tests {
+
+		err = Decode(url.Values{}, tt.dst)
+		NotEqual(t, err, nil)
+
+		_, ok = err.(*InvalidDecodeError)
+		Equal(t, ok, true)
+		Equal(t, err.Error(), tt.err)
+	}
+}
+
+func TestName(t *testing.T) {
+
+	validate := validator.New()
+	validate.RegisterTagNameFunc(TagNameFunc)
+
+	values := url.Values{
+		"name":          {""},
+		"items[0].sku":  {"A1"},
+		"items[1].sku":  {""},
+		"items[1].qty":  {"2"},
+		"attrs[colour]": {"blue"},
+		"lines[a].qty":  {"1"},
+		"address.city":  {""},
+		"tag":           {"a", "b1"},
+	}
+
+	var order Order
+
+	err := Decode(values, &order)
+	Equal(t, err, nil)
+
+	err = validate.Struct(order)
+	NotEqual(t, err, nil)
+
+	errs := err.(validator.ValidationErrors)
+
+	expected := map[string]string{
+		"name":          "required",
+		"items[0].qty":  "gte",
+		"items[1].sku":  "required",
+		"attrs[colour]": "max",
+		"lines[a].sku":  "required",
+		"address.city":  "required",
+		"tag[1]":        "alpha",
+	}
+
+	Equal(t, len(errs), len(expected))
+
+	for _, fe := range errs {
+
+		name := Name(fe)
+
+		tag, ok := expected[name]
+		if !ok {
+			t.Fatalf("unexpected error for '%s': %s", name, fe)
+		}
+
+		Equal(t, fe.Tag(), tag)
+		Equal(t, fe.Namespace(), "Order."+name)
+	}
+}
+
+func TestSetTagName(t *testing.T) {
+
+	type Profile struct {
+		Name  string `schema:"full_name" validate:"required"`
+		Email string `schema:"-" form:"email"`
+	}
+
+	d := NewDecoder()
+	d.SetTagName("schema")
+
+	var profile Profile
+
+	err := d.Decode(url.Values{"full_name": {"joeybloggs"}, "email": {"x"}}, &profile)
+	Equal(t, err, nil)
+	Equal(t, profile, Profile{Name: "joeybloggs"})
+
+	typ := reflect.TypeOf(profile)
+
+	Equal(t, d.TagNameFunc(typ.Field(0)), "full_name")
+	Equal(t, d.TagNameFunc(typ.Field(1)), "")
+	Equal(t, TagNameFunc(typ.Field(1)), "email")
+}
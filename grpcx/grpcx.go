@@ -0,0 +1,240 @@
+/*
+Package grpcx provides gRPC server interceptors validating the request messages, and optionally
+the responses, of unary and streaming RPCs using StructCtx.
+
+	interceptor := grpcx.New(validate, trans)
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(interceptor.Unary()),
+		grpc.ChainStreamInterceptor(interceptor.Stream()),
+	)
+
+Requests failing validation are rejected, without calling the handler, with an InvalidArgument
+status carrying a google.rpc.BadRequest detail; each FieldError being a field violation:
+
+	field               the field's Path, eg. items[0].sku
+	description         the FieldError's Error()
+	reason              the validation tag in upper snake case, eg. REQUIRED
+	localized_message   the translated error, when a translator is set
+
+Messages generated by protoc-gen-go name their fields using the protobuf tag, so registering
+TagNameFunc makes the field violations name the fields as they are within the .proto file.
+
+	validate.RegisterTagNameFunc(grpcx.TagNameFunc)
+
+Messages that aren't structs, or pointers to them, are not validated.
+*/
+package grpcx
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"strings"
+
+	ut "package/universal-translator"
+	"package/validator"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Interceptor validates the messages of RPCs
+type Interceptor struct {
+	v                 *validator.Validate
+	trans             ut.Translator
+	validateResponses bool
+}
+
+// New returns an Interceptor validating using v, the field violations also having the errors
+// translated using trans when not nil.
+func New(v *validator.Validate, trans ut.Translator) *Interceptor {
+	return &Interceptor{
+		v:     v,
+		trans: trans,
+	}
+}
+
+// ValidateResponses causes the responses to also be validated, those failing validation being
+// replaced by an Internal status; as it's the server, not the client, at fault.
+func (i *Interceptor) ValidateResponses() {
+	i.validateResponses = true
+}
+
+// Unary returns the interceptor for unary RPCs
+func (i *Interceptor) Unary() grpc.UnaryServerInterceptor {
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+
+		if err := i.validateRequest(ctx, req); err != nil {
+			return nil, err
+		}
+
+		resp, err := handler(ctx, req)
+		if err != nil || !i.validateResponses {
+			return resp, err
+		}
+
+		if err := i.validateResponse(ctx, resp); err != nil {
+			return nil, err
+		}
+
+		return resp, nil
+	}
+}
+
+// Stream returns the interceptor for streaming RPCs, validating each message received, and
+// sent, by the handler; RecvMsg returning the error of a message failing validation.
+func (i *Interceptor) Stream() grpc.StreamServerInterceptor {
+
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss, i: i})
+	}
+}
+
+// serverStream validates the messages of a stream
+type serverStream struct {
+	grpc.ServerStream
+	i *Interceptor
+}
+
+func (s *serverStream) RecvMsg(m interface{}) error {
+
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	return s.i.validateRequest(s.Context(), m)
+}
+
+func (s *serverStream) SendMsg(m interface{}) error {
+
+	if s.i.validateResponses {
+		if err := s.i.validateResponse(s.Context(), m); err != nil {
+			return err
+		}
+	}
+
+	return s.ServerStream.SendMsg(m)
+}
+
+// validateRequest validates the request message, returning the error as a status
+func (i *Interceptor) validateRequest(ctx context.Context, req interface{}) error {
+
+	err := i.validate(ctx, req)
+	if err == nil {
+		return nil
+	}
+
+	switch e := err.(type) {
+	case validator.ValidationErrors:
+		return i.BadRequest(e).Err()
+	case *validator.LimitError, *validator.MaxDepthError:
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return statusError(err)
+}
+
+// validateResponse validates the response message, returning the error as a status
+func (i *Interceptor) validateResponse(ctx context.Context, resp interface{}) error {
+
+	err := i.validate(ctx, resp)
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := err.(validator.ValidationErrors); ok {
+		return status.Error(codes.Internal, "response failed validation")
+	}
+
+	return statusError(err)
+}
+
+// validate validates the message when it's a struct, or pointer to one
+func (i *Interceptor) validate(ctx context.Context, m interface{}) error {
+
+	val := reflect.ValueOf(m)
+
+	if val.Kind() == reflect.Ptr && !val.IsNil() {
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Struct {
+		return nil
+	}
+
+	return i.v.StructCtx(ctx, m)
+}
+
+// statusError returns the error, other than ValidationErrors, as a status
+func statusError(err error) error {
+
+	if s := status.FromContextError(err); s.Code() != codes.Unknown {
+		return s.Err()
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
+
+// BadRequest returns an InvalidArgument status carrying a google.rpc.BadRequest detail with a
+// field violation for each FieldError.
+func (i *Interceptor) BadRequest(errs validator.ValidationErrors) *status.Status {
+
+	var msg string
+
+	if len(errs) == 1 {
+		msg = "1 field failed validation"
+	} else {
+		msg = strconv.Itoa(len(errs)) + " fields failed validation"
+	}
+
+	br := &errdetails.BadRequest{
+		FieldViolations: make([]*errdetails.BadRequest_FieldViolation, len(errs)),
+	}
+
+	for j, fe := range errs {
+
+		fv := &errdetails.BadRequest_FieldViolation{
+			Field:       fe.Path().String(),
+			Description: fe.(error).Error(),
+			Reason:      strings.ToUpper(fe.Tag()),
+		}
+
+		if i.trans != nil {
+			fv.LocalizedMessage = &errdetails.LocalizedMessage{
+				Locale:  strings.Replace(i.trans.Locale(), "_", "-", -1),
+				Message: fe.Translate(i.trans),
+			}
+		}
+
+		br.FieldViolations[j] = fv
+	}
+
+	s, err := status.New(codes.InvalidArgument, msg).WithDetails(br)
+	if err != nil {
+		return status.New(codes.InvalidArgument, msg)
+	}
+
+	return s
+}
+
+// TagNameFunc returns the name of the field within the .proto file, from the protobuf tag of
+// messages generated by protoc-gen-go, for use with Validate.RegisterTagNameFunc; oneof fields
+// being named by the oneof.
+func TagNameFunc(fld reflect.StructField) string {
+
+	if name := fld.Tag.Get("protobuf_oneof"); len(name) > 0 {
+		return name
+	}
+
+	for _, opt := range strings.Split(fld.Tag.Get("protobuf"), ",") {
+		if strings.HasPrefix(opt, "name=") {
+			return opt[len("name="):]
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,337 @@
+package grpcx
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"reflect"
+	"testing"
+
+	english "package/locales/en"
+	ut "package/universal-translator"
+	"package/validator"
+	en_translations "package/validator/translations/en"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	. "gopkg.in/go-playground/assert.v1"
+)
+
+// messages are tagged as protoc-gen-go would, but encoded as JSON so no generated code is needed
+
+type Order struct {
+	Name  string  `protobuf:"bytes,1,opt,name=customer_name,json=customerName,proto3" validate:"required"`
+	Items []*Item `protobuf:"bytes,2,rep,name=items,proto3" validate:"min=1,dive"`
+}
+
+type Item struct {
+	SKU string `protobuf:"bytes,1,opt,name=sku,proto3" validate:"required"`
+	Qty int32  `protobuf:"varint,2,opt,name=qty,proto3" validate:"gte=1"`
+}
+
+type Receipt struct {
+	ID string `protobuf:"bytes,1,opt,name=id,proto3" validate:"required"`
+}
+
+// invalidResponse is the name of an order the server responds to with an invalid Receipt
+const invalidResponse = "invalid response"
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+func (jsonCodec) Name() string                               { return "json" }
+
+type orders struct {
+	calls int
+}
+
+func (o *orders) receipt(order *Order) *Receipt {
+
+	o.calls++
+
+	if order.Name == invalidResponse {
+		return &Receipt{}
+	}
+
+	return &Receipt{ID: "receipt-" + order.Name}
+}
+
+var ordersDesc = grpc.ServiceDesc{
+	ServiceName: "grpcx.test.Orders",
+	HandlerType: (*interface{})(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+
+				order := new(Order)
+
+				if err := dec(order); err != nil {
+					return nil, err
+				}
+
+				handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+					return srv.(*orders).receipt(req.(*Order)), nil
+				}
+
+				info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/grpcx.test.Orders/Create"}
+
+				return interceptor(ctx, order, info, handler)
+			},
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName: "Import",
+			Handler: func(srv interface{}, stream grpc.ServerStream) error {
+
+				for {
+					order := new(Order)
+
+					if err := stream.RecvMsg(order); err != nil {
+
+						if err == io.EOF {
+							return nil
+						}
+
+						return err
+					}
+
+					if err := stream.SendMsg(srv.(*orders).receipt(order)); err != nil {
+						return err
+					}
+				}
+			},
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+}
+
+func newInterceptor(t *testing.T) *Interceptor {
+
+	eng := english.New()
+	uni := ut.New(eng, eng)
+	trans, _ := uni.GetTranslator("en")
+
+	validate := validator.New()
+	validate.RegisterTagNameFunc(TagNameFunc)
+
+	err := en_translations.RegisterDefaultTranslations(validate, trans)
+	Equal(t, err, nil)
+
+	return New(validate, trans)
+}
+
+// serve serves the orders service, using the interceptor, in process returning a client connection
+func serve(t *testing.T, i *Interceptor) (*grpc.ClientConn, *orders) {
+
+	lis := bufconn.Listen(1 << 20)
+
+	srv := &orders{}
+
+	server := grpc.NewServer(
+		grpc.ForceServerCodec(jsonCodec{}),
+		grpc.ChainUnaryInterceptor(i.Unary()),
+		grpc.ChainStreamInterceptor(i.Stream()),
+	)
+	server.RegisterService(&ordersDesc, srv)
+
+	go server.Serve(lis)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(jsonCodec{})),
+	)
+	Equal(t, err, nil)
+
+	t.Cleanup(func() {
+		conn.Close()
+		server.Stop()
+	})
+
+	return conn, srv
+}
+
+// assertBadRequest asserts the error is an InvalidArgument status with the field violations
+func assertBadRequest(t *testing.T, err error, msg string, violations []*errdetails.BadRequest_FieldViolation) {
+
+	s, ok := status.FromError(err)
+	Equal(t, ok, true)
+	Equal(t, s.Code(), codes.InvalidArgument)
+	Equal(t, s.Message(), msg)
+
+	details := s.Details()
+	Equal(t, len(details), 1)
+
+	br, ok := details[0].(*errdetails.BadRequest)
+	Equal(t, ok, true)
+	Equal(t, len(br.FieldViolations), len(violations))
+
+	for i, fv := range violations {
+		Equal(t, br.FieldViolations[i].Field, fv.Field)
+		Equal(t, br.FieldViolations[i].Reason, fv.Reason)
+		Equal(t, br.FieldViolations[i].Description, fv.Description)
+		Equal(t, br.FieldViolations[i].LocalizedMessage.Locale, fv.LocalizedMessage.Locale)
+		Equal(t, br.FieldViolations[i].LocalizedMessage.Message, fv.LocalizedMessage.Message)
+	}
+}
+
+func TestUnary(t *testing.T) {
+
+	conn, srv := serve(t, newInterceptor(t))
+	ctx := context.Background()
+
+	var receipt Receipt
+
+	err := conn.Invoke(ctx, "/grpcx.test.Orders/Create", &Order{Name: "joeybloggs", Items: []*Item{{SKU: "A1", Qty: 1}}}, &receipt)
+	Equal(t, err, nil)
+	Equal(t, receipt.ID, "receipt-joeybloggs")
+	Equal(t, srv.calls, 1)
+
+	err = conn.Invoke(ctx, "/grpcx.test.Orders/Create", &Order{Items: []*Item{{Qty: 1}, {SKU: "B2"}}}, &receipt)
+	NotEqual(t, err, nil)
+	Equal(t, srv.calls, 1)
+
+	assertBadRequest(t, err, "3 fields failed validation", []*errdetails.BadRequest_FieldViolation{
+		{
+			Field:            "customer_name",
+			Reason:           "REQUIRED",
+			Description:      "Key: 'Order.customer_name' Error:Field validation for 'customer_name' failed on the 'required' tag",
+			LocalizedMessage: &errdetails.LocalizedMessage{Locale: "en", Message: "customer_name is a required field"},
+		},
+		{
+			Field:            "items[0].sku",
+			Reason:           "REQUIRED",
+			Description:      "Key: 'Order.items[0].sku' Error:Field validation for 'sku' failed on the 'required' tag",
+			LocalizedMessage: &errdetails.LocalizedMessage{Locale: "en", Message: "sku is a required field"},
+		},
+		{
+			Field:            "items[1].qty",
+			Reason:           "GTE",
+			Description:      "Key: 'Order.items[1].qty' Error:Field validation for 'qty' failed on the 'gte' tag",
+			LocalizedMessage: &errdetails.LocalizedMessage{Locale: "en", Message: "qty must be 1 or greater"},
+		},
+	})
+
+	// responses are only validated when enabled
+	err = conn.Invoke(ctx, "/grpcx.test.Orders/Create", &Order{Name: invalidResponse, Items: []*Item{{SKU: "A1", Qty: 1}}}, &receipt)
+	Equal(t, err, nil)
+	Equal(t, receipt.ID, "")
+
+	i := newInterceptor(t)
+	i.ValidateResponses()
+
+	conn, _ = serve(t, i)
+
+	err = conn.Invoke(ctx, "/grpcx.test.Orders/Create", &Order{Name: invalidResponse, Items: []*Item{{SKU: "A1", Qty: 1}}}, &receipt)
+	NotEqual(t, err, nil)
+	Equal(t, status.Code(err), codes.Internal)
+	Equal(t, status.Convert(err).Message(), "response failed validation")
+
+	err = conn.Invoke(ctx, "/grpcx.test.Orders/Create", &Order{Name: "joeybloggs", Items: []*Item{{SKU: "A1", Qty: 1}}}, &receipt)
+	Equal(t, err, nil)
+	Equal(t, receipt.ID, "receipt-joeybloggs")
+}
+
+func TestStream(t *testing.T) {
+
+	i := newInterceptor(t)
+	i.ValidateResponses()
+
+	conn, srv := serve(t, i)
+
+	desc := &ordersDesc.Streams[0]
+
+	stream, err := conn.NewStream(context.Background(), desc, "/grpcx.test.Orders/Import")
+	Equal(t, err, nil)
+
+	err = stream.SendMsg(&Order{Name: "joeybloggs", Items: []*Item{{SKU: "A1", Qty: 1}}})
+	Equal(t, err, nil)
+
+	var receipt Receipt
+
+	err = stream.RecvMsg(&receipt)
+	Equal(t, err, nil)
+	Equal(t, receipt.ID, "receipt-joeybloggs")
+
+	err = stream.SendMsg(&Order{Name: "joeybloggs"})
+	Equal(t, err, nil)
+
+	err = stream.RecvMsg(&receipt)
+	NotEqual(t, err, nil)
+	Equal(t, srv.calls, 1)
+
+	assertBadRequest(t, err, "1 field failed validation", []*errdetails.BadRequest_FieldViolation{
+		{
+			Field:            "items",
+			Reason:           "MIN",
+			Description:      "Key: 'Order.items' Error:Field validation for 'items' failed on the 'min' tag",
+			LocalizedMessage: &errdetails.LocalizedMessage{Locale: "en", Message: "items must contain at least 1 item"},
+		},
+	})
+
+	stream, err = conn.NewStream(context.Background(), desc, "/grpcx.test.Orders/Import")
+	Equal(t, err, nil)
+
+	err = stream.SendMsg(&Order{Name: invalidResponse, Items: []*Item{{SKU: "A1", Qty: 1}}})
+	Equal(t, err, nil)
+
+	err = stream.RecvMsg(&receipt)
+	NotEqual(t, err, nil)
+	Equal(t, status.Code(err), codes.Internal)
+	Equal(t, status.Convert(err).Message(), "response failed validation")
+}
+
+func TestValidateRequest(t *testing.T) {
+
+	i := New(validator.New(), nil)
+	ctx := context.Background()
+
+	// non struct messages aren't validated
+	Equal(t, i.validateRequest(ctx, "joeybloggs"), nil)
+	Equal(t, i.validateRequest(ctx, (*Order)(nil)), nil)
+	Equal(t, i.validateRequest(ctx, nil), nil)
+
+	err := i.validateRequest(ctx, Order{Items: []*Item{{SKU: "A1", Qty: 1}}})
+	NotEqual(t, err, nil)
+
+	s := status.Convert(err)
+	Equal(t, s.Code(), codes.InvalidArgument)
+
+	br := s.Details()[0].(*errdetails.BadRequest)
+	Equal(t, br.FieldViolations[0].Field, "Name")
+	Equal(t, br.FieldViolations[0].LocalizedMessage, nil)
+
+	i.v.SetMaxDepth(1)
+
+	err = i.validateRequest(ctx, &Order{Name: "joeybloggs", Items: []*Item{{SKU: "A1", Qty: 1}}})
+	NotEqual(t, err, nil)
+	Equal(t, status.Code(err), codes.InvalidArgument)
+	Equal(t, status.Convert(err).Message(), "validator: maximum depth of 1 exceeded at 'Order.Items[0]'")
+}
+
+func TestTagNameFunc(t *testing.T) {
+
+	type Message struct {
+		Name    string      `protobuf:"bytes,1,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+		Kind    interface{} `protobuf_oneof:"kind"`
+		Comment string      `json:"comment"`
+	}
+
+	typ := reflect.TypeOf(Message{})
+
+	Equal(t, TagNameFunc(typ.Field(0)), "display_name")
+	Equal(t, TagNameFunc(typ.Field(1)), "kind")
+	Equal(t, TagNameFunc(typ.Field(2)), "")
+}
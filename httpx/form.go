@@ -0,0 +1,31 @@
+package httpx
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+
+	"package/validator"
+)
+
+// checkDst returns InvalidValidationError when dst isn't a pointer to a struct
+func checkDst(dst interface{}) error {
+
+	val := reflect.ValueOf(dst)
+
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return &validator.InvalidValidationError{Type: reflect.TypeOf(dst)}
+	}
+
+	return nil
+}
+
+// decodeValues decodes the parameters into the struct dst points to, see the form package
+func (d *Decoder) decodeValues(values url.Values, dst interface{}) error {
+
+	if err := d.form.Decode(values, dst); err != nil {
+		return d.error(http.StatusBadRequest, err)
+	}
+
+	return nil
+}
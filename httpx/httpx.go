@@ -0,0 +1,376 @@
+/*
+Package httpx decodes and validates the bodies, or query parameters, of HTTP requests; replacing
+the decode, validate and render an error steps repeated by handlers.
+
+	func createUser(w http.ResponseWriter, r *http.Request) {
+
+		var user User
+
+		if err := decoder.DecodeAndValidate(r, &user); err != nil {
+
+			if e, ok := err.(*httpx.Error); ok {
+				e.ServeHTTP(w, r)
+				return
+			}
+
+			...
+		}
+
+		...
+	}
+
+The Content-Type of the request chooses how it's decoded:
+
+	application/json, and any +json type   the body is decoded using encoding/json
+	application/x-www-form-urlencoded      the body, and query, parameters are decoded
+	multipart/form-data                    the body, and query, parameters are decoded; files
+	                                       being left within the request's MultipartForm
+	none, such as for GET requests         the query parameters are decoded
+
+Parameters are decoded using the form package, so are named the same as the namespaces of the
+ValidationErrors; eg. items[0].sku or attrs[color].
+
+Errors are returned as an *Error, having the HTTP status; 400 Bad Request for malformed bodies,
+413 Request Entity Too Large for bodies larger than the limit, see SetMaxBodySize, 415 Unsupported
+Media Type for other content types and 422 Unprocessable Entity when it fails validation. When
+validation exceeds one of the validator's Limits, see SetLimits, it's 413 for too many dive elements
+or fields, 503 Service Unavailable for the deadline and 422 otherwise. *Error
+renders itself as an RFC 7807 problem details document, see the problem package; the ValidationErrors
+translated using the translator for the request's Accept-Language header.
+*/
+package httpx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	ut "package/universal-translator"
+	"package/validator"
+	"package/validator/form"
+	"package/validator/problem"
+)
+
+// DefaultMaxBodySize is the maximum size of request bodies, unless set using SetMaxBodySize
+const DefaultMaxBodySize = 1 << 20
+
+var errBodyTooLarge = errors.New("httpx: request body too large")
+
+// Error is the error decoding or validating a request, rendered as the response using ServeHTTP
+type Error struct {
+	Status int   // HTTP status of the response
+	Err    error // error decoding the request, or ValidationErrors
+
+	uni *ut.UniversalTranslator
+}
+
+// Error returns the error's message
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the error decoding the request, or ValidationErrors
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// ServeHTTP writes the error as an RFC 7807 problem details document; ValidationErrors being the
+// invalid-params, translated using the translator of the UniversalTranslator for the request's
+// Accept-Language header, or the fallback when none match.
+func (e *Error) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	if errs, ok := e.Err.(validator.ValidationErrors); ok {
+
+		var trans ut.Translator
+
+		if e.uni != nil {
+			trans, _ = e.uni.FindTranslator(AcceptLanguages(r)...)
+		}
+
+		p := problem.New(errs, trans)
+		p.Status = e.Status
+		p.Title = http.StatusText(e.Status)
+		p.ServeHTTP(w, r)
+
+		return
+	}
+
+	p := &problem.Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(e.Status),
+		Status: e.Status,
+		Detail: e.Err.Error(),
+	}
+
+	p.ServeHTTP(w, r)
+}
+
+// Decoder decodes and validates requests
+type Decoder struct {
+	v                     *validator.Validate
+	uni                   *ut.UniversalTranslator
+	form                  *form.Decoder
+	maxBodySize           int64
+	disallowUnknownFields bool
+}
+
+// New returns a Decoder validating using v, the errors being translated using uni when not nil
+func New(v *validator.Validate, uni *ut.UniversalTranslator) *Decoder {
+	return &Decoder{
+		v:           v,
+		uni:         uni,
+		form:        form.NewDecoder(),
+		maxBodySize: DefaultMaxBodySize,
+	}
+}
+
+// SetMaxBodySize sets the maximum size of request bodies, DefaultMaxBodySize by default; no
+// limit when n <= 0.
+func (d *Decoder) SetMaxBodySize(n int64) {
+	d.maxBodySize = n
+}
+
+// DisallowUnknownFields causes JSON bodies having fields that don't match the destination
+// to fail decoding, see json.Decoder.DisallowUnknownFields.
+func (d *Decoder) DisallowUnknownFields() {
+	d.disallowUnknownFields = true
+}
+
+var defaultDecoder = New(validator.New(), nil)
+
+// DecodeAndValidate decodes and validates the request into dst, the same as Decoder.DecodeAndValidate,
+// using a validator with the default configuration and without translations.
+func DecodeAndValidate(r *http.Request, dst interface{}) error {
+	return defaultDecoder.DecodeAndValidate(r, dst)
+}
+
+// DecodeAndValidate decodes the request into dst, a pointer to a struct, depending on the request's
+// Content-Type and then validates it using StructCtx with the request's context.
+//
+// It returns an *Error when the request can't be decoded or fails validation, and any other error,
+// such as InvalidValidationError when dst isn't a pointer to a struct, as is.
+func (d *Decoder) DecodeAndValidate(r *http.Request, dst interface{}) error {
+
+	if err := d.decode(r, dst); err != nil {
+		return err
+	}
+
+	err := d.v.StructCtx(r.Context(), dst)
+	if err == nil {
+		return nil
+	}
+
+	switch e := err.(type) {
+	case validator.ValidationErrors:
+		return &Error{Status: http.StatusUnprocessableEntity, Err: err, uni: d.uni}
+	case *validator.LimitError:
+		return &Error{Status: limitStatus(e.Limit), Err: err, uni: d.uni}
+	}
+
+	return err
+}
+
+// limitStatus returns the HTTP status of a request exceeding the limit when validated
+func limitStatus(limit validator.Limit) int {
+
+	switch limit {
+	case validator.LimitDiveElements, validator.LimitFields:
+		return http.StatusRequestEntityTooLarge
+	case validator.LimitDeadline:
+		return http.StatusServiceUnavailable
+	}
+
+	return http.StatusUnprocessableEntity
+}
+
+func (d *Decoder) decode(r *http.Request, dst interface{}) error {
+
+	if err := checkDst(dst); err != nil {
+		return err
+	}
+
+	contentType := r.Header.Get("Content-Type")
+
+	if len(contentType) == 0 {
+		return d.decodeValues(r.URL.Query(), dst)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return d.error(http.StatusBadRequest, fmt.Errorf("httpx: invalid Content-Type: %s", err))
+	}
+
+	var body *limitedBody
+
+	if r.Body != nil && d.maxBodySize > 0 {
+		body = &limitedBody{ReadCloser: r.Body, n: d.maxBodySize}
+		r.Body = body
+	}
+
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+
+		if r.Body == nil {
+			return d.error(http.StatusBadRequest, errors.New("httpx: request body is empty"))
+		}
+
+		dec := json.NewDecoder(r.Body)
+
+		if d.disallowUnknownFields {
+			dec.DisallowUnknownFields()
+		}
+
+		if err = dec.Decode(dst); err == nil && dec.More() {
+			err = errors.New("httpx: request body must contain a single JSON value")
+		}
+
+		if err == io.EOF {
+			err = errors.New("httpx: request body is empty")
+		}
+
+	case mediaType == "application/x-www-form-urlencoded":
+
+		if err = r.ParseForm(); err == nil {
+			err = d.decodeValues(r.Form, dst)
+		}
+
+	case mediaType == "multipart/form-data":
+
+		maxMemory := d.maxBodySize
+		if maxMemory <= 0 {
+			maxMemory = DefaultMaxBodySize
+		}
+
+		if err = r.ParseMultipartForm(maxMemory); err == nil {
+			err = d.decodeValues(r.Form, dst)
+		}
+
+	default:
+		return d.error(http.StatusUnsupportedMediaType, fmt.Errorf("httpx: unsupported Content-Type '%s'", mediaType))
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	if body != nil && body.exceeded {
+		return d.error(http.StatusRequestEntityTooLarge, errBodyTooLarge)
+	}
+
+	if _, ok := err.(*Error); ok {
+		return err
+	}
+
+	return d.error(http.StatusBadRequest, err)
+}
+
+func (d *Decoder) error(status int, err error) *Error {
+	return &Error{Status: status, Err: err, uni: d.uni}
+}
+
+// limitedBody limits the size of a request body, noting when it's exceeded
+type limitedBody struct {
+	io.ReadCloser
+	n        int64
+	exceeded bool
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+
+	if b.exceeded {
+		return 0, errBodyTooLarge
+	}
+
+	// reads a byte more than the limit to tell whether it's exceeded
+	if int64(len(p)) > b.n+1 {
+		p = p[:b.n+1]
+	}
+
+	n, err := b.ReadCloser.Read(p)
+
+	if int64(n) <= b.n {
+		b.n -= int64(n)
+		return n, err
+	}
+
+	n = int(b.n)
+	b.n = 0
+	b.exceeded = true
+
+	return n, errBodyTooLarge
+}
+
+// AcceptLanguages returns the locales of the request's Accept-Language header, most preferred
+// first and in the form used by the locales package; each region being followed by it's language,
+// eg. "en-US,fa;q=0.5" returns en_US, en and fa.
+func AcceptLanguages(r *http.Request) []string {
+
+	type language struct {
+		tag string
+		q   float64
+	}
+
+	var langs []language
+
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+
+		params := strings.Split(part, ";")
+		tag := strings.TrimSpace(params[0])
+
+		if len(tag) == 0 || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+
+		for _, param := range params[1:] {
+
+			param = strings.TrimSpace(param)
+
+			if strings.HasPrefix(param, "q=") {
+
+				var err error
+
+				if q, err = strconv.ParseFloat(param[2:], 64); err != nil {
+					q = 0
+				}
+			}
+		}
+
+		if q > 0 {
+			langs = append(langs, language{tag: tag, q: q})
+		}
+	}
+
+	sort.SliceStable(langs, func(i, j int) bool {
+		return langs[i].q > langs[j].q
+	})
+
+	locales := make([]string, 0, len(langs)*2)
+	seen := make(map[string]bool, len(langs)*2)
+
+	add := func(locale string) {
+		if !seen[locale] {
+			seen[locale] = true
+			locales = append(locales, locale)
+		}
+	}
+
+	for _, lang := range langs {
+
+		locale := strings.Replace(lang.tag, "-", "_", -1)
+		add(locale)
+
+		if i := strings.IndexByte(locale, '_'); i > 0 {
+			add(locale[:i])
+		}
+	}
+
+	return locales
+}
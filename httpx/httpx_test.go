@@ -0,0 +1,323 @@
+package httpx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	english "package/locales/en"
+	persian "package/locales/fa"
+	ut "package/universal-translator"
+	"package/validator"
+	en_translations "package/validator/translations/en"
+	fa_translations "package/validator/translations/fa"
+
+	. "gopkg.in/go-playground/assert.v1"
+)
+
+type Base struct {
+	ID int `json:"id" form:"id"`
+}
+
+type User struct {
+	Base
+	Name    string    `json:"name" form:"name" validate:"required"`
+	Age     int       `json:"age" form:"age" validate:"gte=18"`
+	Tags    []string  `json:"tags" form:"tag" validate:"max=2"`
+	Admin   *bool     `json:"admin" form:"admin"`
+	Born    time.Time `json:"born" form:"born"`
+	Score   float64   `json:"score" form:"score"`
+	Skipped string    `json:"-" form:"-"`
+	private string
+}
+
+func newDecoder(t *testing.T) *Decoder {
+
+	eng := english.New()
+	fa := persian.New()
+	uni := ut.New(eng, eng, fa)
+
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		return strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+	})
+
+	trans, _ := uni.GetTranslator("en")
+	err := en_translations.RegisterDefaultTranslations(validate, trans)
+	Equal(t, err, nil)
+
+	trans, _ = uni.GetTranslator("fa")
+	err = fa_translations.RegisterDefaultTranslations(validate, trans)
+	Equal(t, err, nil)
+
+	err = trans.Add("name", "نام", false)
+	Equal(t, err, nil)
+
+	return New(validate, uni)
+}
+
+func TestDecodeJSON(t *testing.T) {
+
+	d := newDecoder(t)
+
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"id": 1, "name": "joeybloggs", "age": 21, "tags": ["a"], "admin": true}`))
+	r.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	var user User
+
+	err := d.DecodeAndValidate(r, &user)
+	Equal(t, err, nil)
+	Equal(t, user.ID, 1)
+	Equal(t, user.Name, "joeybloggs")
+	Equal(t, user.Age, 21)
+	Equal(t, user.Tags, []string{"a"})
+	Equal(t, *user.Admin, true)
+
+	r = httptest.NewRequest("POST", "/", strings.NewReader(`{"age": 17}`))
+	r.Header.Set("Content-Type", "application/vnd.api+json")
+
+	err = d.DecodeAndValidate(r, &User{})
+	NotEqual(t, err, nil)
+
+	e, ok := err.(*Error)
+	Equal(t, ok, true)
+	Equal(t, e.Status, http.StatusUnprocessableEntity)
+
+	_, ok = e.Err.(validator.ValidationErrors)
+	Equal(t, ok, true)
+}
+
+func TestDecodeLimits(t *testing.T) {
+
+	d := newDecoder(t)
+
+	deadlineCtx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	tests := []struct {
+		limits validator.Limits
+		ctx    context.Context
+		limit  validator.Limit
+		status int
+	}{
+		{limits: validator.Limits{MaxFields: 2}, ctx: context.Background(), limit: validator.LimitFields, status: http.StatusRequestEntityTooLarge},
+		{limits: validator.Limits{Timeout: time.Hour}, ctx: deadlineCtx, limit: validator.LimitDeadline, status: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+
+		d.v.SetLimits(tt.limits)
+
+		r := httptest.NewRequest("POST", "/", strings.NewReader(`{"name": "joeybloggs", "age": 21}`)).WithContext(tt.ctx)
+		r.Header.Set("Content-Type", "application/json")
+
+		err := d.DecodeAndValidate(r, &User{})
+		NotEqual(t, err, nil)
+
+		e, ok := err.(*Error)
+		Equal(t, ok, true)
+		Equal(t, e.Status, tt.status)
+		Equal(t, e.Err.(*validator.LimitError).Limit, tt.limit)
+	}
+
+	Equal(t, limitStatus(validator.LimitDiveElements), http.StatusRequestEntityTooLarge)
+	Equal(t, limitStatus(validator.LimitRegexInput), http.StatusUnprocessableEntity)
+}
+
+func TestDecodeForm(t *testing.T) {
+
+	d := newDecoder(t)
+
+	r := httptest.NewRequest("POST", "/?Base.id=7", strings.NewReader("name=joeybloggs&age=21&tag=a&tag=b&admin=false&born=2020-01-02T03:04:05Z&score=1.5&Skipped=x"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var user User
+
+	err := d.DecodeAndValidate(r, &user)
+	Equal(t, err, nil)
+	Equal(t, user.ID, 7)
+	Equal(t, user.Name, "joeybloggs")
+	Equal(t, user.Age, 21)
+	Equal(t, user.Tags, []string{"a", "b"})
+	Equal(t, *user.Admin, false)
+	Equal(t, user.Born, time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))
+	Equal(t, user.Score, 1.5)
+	Equal(t, user.Skipped, "")
+
+	var body bytes.Buffer
+
+	mw := multipart.NewWriter(&body)
+	mw.WriteField("name", "joeybloggs")
+	mw.WriteField("age", "30")
+	mw.Close()
+
+	r = httptest.NewRequest("POST", "/", &body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+
+	user = User{}
+
+	err = d.DecodeAndValidate(r, &user)
+	Equal(t, err, nil)
+	Equal(t, user.Name, "joeybloggs")
+	Equal(t, user.Age, 30)
+
+	r = httptest.NewRequest("GET", "/?name=joeybloggs&age=x", nil)
+
+	err = d.DecodeAndValidate(r, &User{})
+	NotEqual(t, err, nil)
+	Equal(t, err.(*Error).Status, http.StatusBadRequest)
+	Equal(t, err.Error(), `form: invalid value for 'age': strconv.ParseInt: parsing "x": invalid syntax`)
+}
+
+func TestDecodeQuery(t *testing.T) {
+
+	r := httptest.NewRequest("GET", "/?name=joeybloggs&age=21&tag=a", nil)
+
+	var user User
+
+	err := DecodeAndValidate(r, &user)
+	Equal(t, err, nil)
+	Equal(t, user.Name, "joeybloggs")
+	Equal(t, user.Age, 21)
+	Equal(t, user.Tags, []string{"a"})
+}
+
+func TestDecodeErrors(t *testing.T) {
+
+	d := newDecoder(t)
+	d.SetMaxBodySize(16)
+
+	tests := []struct {
+		contentType string
+		body        string
+		status      int
+		err         string
+	}{
+		{contentType: "application/json", body: `{"name": "joeybloggs, the longest"}`, status: http.StatusRequestEntityTooLarge, err: "httpx: request body too large"},
+		{contentType: "application/x-www-form-urlencoded", body: "name=joeybloggs&age=21", status: http.StatusRequestEntityTooLarge, err: "httpx: request body too large"},
+		{contentType: "application/json", body: `{"name": 1}`, status: http.StatusBadRequest, err: "json: cannot unmarshal number into Go struct field User.name of type string"},
+		{contentType: "application/json", body: `{} {}`, status: http.StatusBadRequest, err: "httpx: request body must contain a single JSON value"},
+		{contentType: "application/json", body: ``, status: http.StatusBadRequest, err: "httpx: request body is empty"},
+		{contentType: "text/plain", body: `name`, status: http.StatusUnsupportedMediaType, err: "httpx: unsupported Content-Type 'text/plain'"},
+		{contentType: "application/json; charset", body: `{}`, status: http.StatusBadRequest, err: "httpx: invalid Content-Type: mime: invalid media parameter"},
+	}
+
+	for i, tt := range tests {
+
+		r := httptest.NewRequest("POST", "/", strings.NewReader(tt.body))
+		r.Header.Set("Content-Type", tt.contentType)
+
+		err := d.DecodeAndValidate(r, &User{})
+
+		e, ok := err.(*Error)
+		if !ok {
+			t.Fatalf("Index: %d expected *Error got %#v", i, err)
+		}
+
+		Equal(t, e.Status, tt.status)
+		Equal(t, e.Error(), tt.err)
+	}
+
+	d = newDecoder(t)
+	d.DisallowUnknownFields()
+
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"unknown": 1}`))
+	r.Header.Set("Content-Type", "application/json")
+
+	err := d.DecodeAndValidate(r, &User{})
+	NotEqual(t, err, nil)
+	Equal(t, err.(*Error).Status, http.StatusBadRequest)
+
+	r = httptest.NewRequest("GET", "/", nil)
+
+	err = d.DecodeAndValidate(r, User{})
+	NotEqual(t, err, nil)
+
+	_, ok := err.(*validator.InvalidValidationError)
+	Equal(t, ok, true)
+}
+
+func TestErrorServeHTTP(t *testing.T) {
+
+	d := newDecoder(t)
+
+	tests := []struct {
+		acceptLanguage string
+		reason         string
+	}{
+		{acceptLanguage: "", reason: "name is a required field"},
+		{acceptLanguage: "de-DE, fa;q=0.8, en;q=0.5", reason: "نام نمیتونه خالی باشه"},
+		{acceptLanguage: "en-GB,fa;q=0.5", reason: "name is a required field"},
+	}
+
+	for _, tt := range tests {
+
+		r := httptest.NewRequest("POST", "/", strings.NewReader(`{"age": 21}`))
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set("Accept-Language", tt.acceptLanguage)
+
+		err := d.DecodeAndValidate(r, &User{})
+		NotEqual(t, err, nil)
+
+		w := httptest.NewRecorder()
+		err.(*Error).ServeHTTP(w, r)
+
+		Equal(t, w.Code, http.StatusUnprocessableEntity)
+		Equal(t, w.Header().Get("Content-Type"), "application/problem+json")
+
+		var p struct {
+			Status        int `json:"status"`
+			InvalidParams []struct {
+				Name   string `json:"name"`
+				Reason string `json:"reason"`
+			} `json:"invalid-params"`
+		}
+
+		err = json.Unmarshal(w.Body.Bytes(), &p)
+		Equal(t, err, nil)
+		Equal(t, p.Status, http.StatusUnprocessableEntity)
+		Equal(t, len(p.InvalidParams), 1)
+		Equal(t, p.InvalidParams[0].Name, "/name")
+		Equal(t, p.InvalidParams[0].Reason, tt.reason)
+	}
+
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{`))
+	r.Header.Set("Content-Type", "application/json")
+
+	err := d.DecodeAndValidate(r, &User{})
+	NotEqual(t, err, nil)
+
+	w := httptest.NewRecorder()
+	err.(*Error).ServeHTTP(w, r)
+
+	Equal(t, w.Code, http.StatusBadRequest)
+	Equal(t, w.Body.String(), `{"type":"about:blank","title":"Bad Request","status":400,"detail":"unexpected EOF"}`)
+}
+
+func TestAcceptLanguages(t *testing.T) {
+
+	tests := []struct {
+		header  string
+		locales []string
+	}{
+		{header: "", locales: []string{}},
+		{header: "en-US,fa;q=0.5", locales: []string{"en_US", "en", "fa"}},
+		{header: "fa;q=0.5, en-GB;q=0.8, *, de;q=0", locales: []string{"en_GB", "en", "fa"}},
+		{header: "en, en-US;q=0.9", locales: []string{"en", "en_US"}},
+	}
+
+	for _, tt := range tests {
+
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set("Accept-Language", tt.header)
+
+		Equal(t, AcceptLanguages(r), tt.locales)
+	}
+}
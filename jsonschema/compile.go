@@ -0,0 +1,1289 @@
+package jsonschema
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"package/validator"
+)
+
+// Tags of the validations registered by Compile, for the keywords without an equivalent baked in
+// validation; the rest being reported using the baked in validations' tags, eg. minLength as min
+// and format email as email, so the same translations apply.
+//
+// The validations of the applicators, such as anyOf, always fail, being reported by the Validator
+// having evaluated the subschemas. The params of PatternTag, ConstTag and EnumTag are held by the
+// Validator, it's tags only having an index, as they can't always be escaped within a tag; used
+// elsewhere, eg. by Var, their param is the pattern, or the JSON of the const or enum, itself.
+const (
+	TypeTag                 = "jsonschema_type"                 // type, param is the types separated by spaces
+	PatternTag              = "jsonschema_pattern"              // pattern, param is the regular expression
+	FormatTag               = "jsonschema_format"               // format date-time, date and time, param is the format
+	MultipleOfTag           = "jsonschema_multipleof"           // multipleOf, param is the number
+	ConstTag                = "jsonschema_const"                // const, param is the value as JSON
+	EnumTag                 = "jsonschema_enum"                 // enum, param is the values as JSON
+	UniqueItemsTag          = "jsonschema_uniqueitems"          // uniqueItems
+	AdditionalPropertiesTag = "jsonschema_additionalproperties" // additionalProperties false, on the property not allowed
+	AnyOfTag                = "jsonschema_anyof"                // anyOf
+	OneOfTag                = "jsonschema_oneof"                // oneOf
+	NotTag                  = "jsonschema_not"                  // not
+	FalseTag                = "jsonschema_false"                // the false schema
+)
+
+// unsupported are the assertion and applicator keywords that can't be compiled; rather than
+// being ignored, validating less than the schema requires
+var unsupported = map[string]bool{
+	"patternProperties":     true,
+	"dependentRequired":     true,
+	"dependentSchemas":      true,
+	"dependencies":          true,
+	"if":                    true,
+	"then":                  true,
+	"else":                  true,
+	"contains":              true,
+	"minContains":           true,
+	"maxContains":           true,
+	"prefixItems":           true,
+	"additionalItems":       true,
+	"unevaluatedItems":      true,
+	"unevaluatedProperties": true,
+	"$dynamicRef":           true,
+	"$recursiveRef":         true,
+}
+
+// formats are the formats of strings validated by baked in validations
+var formats = map[string]string{
+	"email":        "email",
+	"idn-email":    "email",
+	"uri":          "uri",
+	"iri":          "uri",
+	"uuid":         "uuid",
+	"ipv4":         "ipv4",
+	"ipv6":         "ipv6",
+	"hostname":     "hostname_rfc1123",
+	"idn-hostname": "hostname_rfc1123",
+	"date-time":    FormatTag + "=date-time",
+	"date":         FormatTag + "=date",
+	"time":         FormatTag + "=time",
+	"regex":        FormatTag + "=regex",
+	"json-pointer": FormatTag + "=json-pointer",
+}
+
+var jsonTypes = map[string]bool{
+	"null":    true,
+	"boolean": true,
+	"object":  true,
+	"array":   true,
+	"number":  true,
+	"string":  true,
+	"integer": true,
+}
+
+// categories are the JSON types having validations, integers being numbers
+var categories = []string{"boolean", "number", "string", "array", "object"}
+
+// Validator validates values against a compiled JSON Schema, see Compile
+type Validator struct {
+	v      *validator.Validate
+	root   *node
+	params []*param
+}
+
+// param is the param of a pattern, const or enum; which can't be escaped within a tag, so the tag
+// holds it's index within the Validator's params, passed to the validations using the context.
+type param struct {
+	s     string         // the pattern, or the JSON of the const or enum; as reported
+	re    *regexp.Regexp // the pattern compiled
+	value interface{}    // the const or enum decoded
+}
+
+type paramsKey struct{}
+
+// paramTags are the tags of the validations whose param is an index within the Validator's params
+var paramTags = map[string]bool{
+	PatternTag: true,
+	ConstTag:   true,
+	EnumTag:    true,
+}
+
+// node is a compiled schema
+type node struct {
+	never         bool              // the false schema
+	types         []string          // types allowed, any when empty
+	tags          map[string]string // validations of values of each category
+	null          []nullCheck       // const and enum validations of null, by index of their param
+	properties    map[string]*node
+	names         []string // names of properties, sorted
+	required      []string
+	additional    *node
+	noAdditional  bool
+	propertyNames *node
+	items         *node
+	allOf         []*node
+	anyOf         []*node
+	oneOf         []*node
+	not           *node
+}
+
+func (n *node) addTag(tag string, cats ...string) {
+
+	if n.tags == nil {
+		n.tags = make(map[string]string)
+	}
+
+	if len(cats) == 0 {
+		cats = categories
+	}
+
+	for _, cat := range cats {
+
+		if len(n.tags[cat]) > 0 {
+			n.tags[cat] += ","
+		}
+
+		n.tags[cat] += tag
+	}
+}
+
+// nullCheck is a const or enum validation of null, nil failing the first validation of a tag
+type nullCheck struct {
+	tag   string
+	param string
+}
+
+type compiler struct {
+	doc    interface{}
+	nodes  map[string]*node
+	params []*param
+}
+
+// Compile compiles the JSON Schema, draft 2020-12 or earlier drafts' equivalent keywords, into a
+// Validator validating values using v; returning ValidationErrors having the tags of the baked in
+// validations where there's an equivalent, so translated the same as those of Struct.
+//
+// The validations of the keywords without an equivalent, see TypeTag and friends, are registered on
+// v by the first Compile using it, unless registered already using Register; the translations packages
+// have translations of them.
+//
+// NOTE: registering validations is not thread-safe, see RegisterValidation, so the first Compile using
+// v must not run concurrently with any validation using v; call Register when setting up v to Compile
+// at any time.
+//
+// Only $refs within the document are supported, and it returns an error when the schema has keywords
+// that can't be compiled, such as if and patternProperties, patterns that aren't valid Go regular
+// expressions, or a $ref cycle that would apply a schema to the value it's validating itself, rather
+// than a property or item of it; unknown keywords and formats being ignored.
+func Compile(v *validator.Validate, schema []byte) (*Validator, error) {
+
+	var doc interface{}
+
+	if err := json.Unmarshal(schema, &doc); err != nil {
+		return nil, err
+	}
+
+	// registered once, as registering again would race with validations using v
+	if _, err := v.ParseTag(FalseTag); err != nil {
+
+		if err = Register(v); err != nil {
+			return nil, err
+		}
+	}
+
+	c := &compiler{doc: doc, nodes: make(map[string]*node)}
+
+	root, err := c.compile(doc, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.checkCycles(); err != nil {
+		return nil, err
+	}
+
+	return &Validator{v: v, root: root, params: c.params}, nil
+}
+
+// Register registers the validations of the keywords without an equivalent baked in validation on v,
+// see TypeTag and friends; as Compile does when they aren't registered.
+//
+// NOTE: like RegisterValidation, it is not thread-safe and is intended to be called when setting up v,
+// prior to any validation; after which Compile can be called at any time.
+func Register(v *validator.Validate) error {
+
+	validations := []struct {
+		tag string
+		fn  validator.Func
+	}{
+		{TypeTag, isType},
+		{FormatTag, isFormat},
+		{MultipleOfTag, isMultipleOf},
+		{UniqueItemsTag, hasUniqueItems},
+		{AdditionalPropertiesTag, fail},
+		{AnyOfTag, fail},
+		{OneOfTag, fail},
+		{NotTag, fail},
+		{FalseTag, fail},
+	}
+
+	for _, val := range validations {
+		if err := v.RegisterValidation(val.tag, val.fn); err != nil {
+			return err
+		}
+	}
+
+	// those having their param within the Validator's params
+	paramValidations := []struct {
+		tag string
+		fn  validator.FuncCtx
+	}{
+		{PatternTag, matchesPattern},
+		{ConstTag, isConst},
+		{EnumTag, isEnum},
+	}
+
+	for _, val := range paramValidations {
+		if err := v.RegisterValidationCtx(val.tag, val.fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addParam adds the param to the table, returning it's index for the tag
+func (c *compiler) addParam(p *param) string {
+	c.params = append(c.params, p)
+	return strconv.Itoa(len(c.params) - 1)
+}
+
+// checkCycles returns an error when a schema applies to the value it validates itself, through $refs
+// and the applicators validating the same value, such as allOf; which would never end, unlike cycles
+// through properties and items, which validate the values within it.
+func (c *compiler) checkCycles() error {
+
+	ptrs := make([]string, 0, len(c.nodes))
+	ptrOf := make(map[*node]string, len(c.nodes))
+
+	for ptr, n := range c.nodes {
+		ptrs = append(ptrs, ptr)
+		ptrOf[n] = ptr
+	}
+
+	sort.Strings(ptrs)
+
+	const (
+		visiting = iota + 1
+		visited
+	)
+
+	state := make(map[*node]int, len(c.nodes))
+
+	// visit returns the node closing a cycle reachable from n, if any
+	var visit func(n *node) *node
+
+	visit = func(n *node) *node {
+
+		switch state[n] {
+		case visiting:
+			return n
+		case visited:
+			return nil
+		}
+
+		state[n] = visiting
+
+		subs := append(append(append([]*node{}, n.allOf...), n.anyOf...), n.oneOf...)
+
+		if n.not != nil {
+			subs = append(subs, n.not)
+		}
+
+		for _, sub := range subs {
+			if cycle := visit(sub); cycle != nil {
+				return cycle
+			}
+		}
+
+		state[n] = visited
+
+		return nil
+	}
+
+	for _, ptr := range ptrs {
+		if cycle := visit(c.nodes[ptr]); cycle != nil {
+			return compileErr(ptrOf[cycle], "$ref cycle applying the schema to the value it validates")
+		}
+	}
+
+	return nil
+}
+
+func compileErr(ptr string, format string, args ...interface{}) error {
+	return fmt.Errorf("jsonschema: "+format+" at '#%s'", append(args, ptr)...)
+}
+
+// compile compiles the schema at the JSON Pointer ptr within the document
+func (c *compiler) compile(schema interface{}, ptr string) (*node, error) {
+
+	if n, ok := c.nodes[ptr]; ok {
+		return n, nil
+	}
+
+	n := new(node)
+	c.nodes[ptr] = n
+
+	switch s := schema.(type) {
+	case bool:
+		n.never = !s
+		return n, nil
+
+	case map[string]interface{}:
+
+		keywords := make([]string, 0, len(s))
+
+		for kw := range s {
+			keywords = append(keywords, kw)
+		}
+
+		sort.Strings(keywords)
+
+		for _, kw := range keywords {
+			if err := c.keyword(n, kw, s[kw], ptr); err != nil {
+				return nil, err
+			}
+		}
+
+		return n, nil
+	}
+
+	return nil, compileErr(ptr, "schema must be an object or boolean")
+}
+
+// keyword compiles the keyword of the schema at ptr into n
+func (c *compiler) keyword(n *node, kw string, val interface{}, ptr string) (err error) {
+
+	kwPtr := ptr + "/" + escapePointer(kw)
+
+	if unsupported[kw] {
+		return compileErr(ptr, "unsupported keyword '%s'", kw)
+	}
+
+	switch kw {
+	case "$ref":
+
+		ref, ok := val.(string)
+		if !ok || !strings.HasPrefix(ref, "#") {
+			return compileErr(ptr, "unsupported $ref '%v', only those within the document are", val)
+		}
+
+		target, err := c.resolve(ref[1:])
+		if err != nil {
+			return compileErr(ptr, "%s", err)
+		}
+
+		refNode, err := c.compile(target, ref[1:])
+		if err != nil {
+			return err
+		}
+
+		n.allOf = append(n.allOf, refNode)
+
+	case "type":
+
+		switch t := val.(type) {
+		case string:
+			n.types = []string{t}
+
+		case []interface{}:
+
+			for _, tt := range t {
+
+				name, ok := tt.(string)
+				if !ok {
+					return compileErr(kwPtr, "type must be a string")
+				}
+
+				n.types = append(n.types, name)
+			}
+		}
+
+		if len(n.types) == 0 {
+			return compileErr(kwPtr, "type must be a string or array of strings")
+		}
+
+		for _, t := range n.types {
+			if !jsonTypes[t] {
+				return compileErr(kwPtr, "unknown type '%s'", t)
+			}
+		}
+
+	case "const":
+
+		b, _ := json.Marshal(val)
+		c.constant(n, val, string(b))
+
+	case "enum":
+
+		vals, ok := val.([]interface{})
+		if !ok || len(vals) == 0 {
+			return compileErr(kwPtr, "enum must be a non empty array")
+		}
+
+		c.enum(n, vals)
+
+	case "minLength", "maxLength":
+		return c.count(n, kw, val, kwPtr, "string")
+
+	case "minItems", "maxItems":
+		return c.count(n, kw, val, kwPtr, "array")
+
+	case "minProperties", "maxProperties":
+		return c.count(n, kw, val, kwPtr, "object")
+
+	case "minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "multipleOf":
+
+		num, ok := val.(float64)
+		if !ok {
+			return compileErr(kwPtr, "%s must be a number", kw)
+		}
+
+		param := strconv.FormatFloat(num, 'f', -1, 64)
+
+		switch kw {
+		case "minimum":
+			n.addTag("min="+param, "number")
+		case "maximum":
+			n.addTag("max="+param, "number")
+		case "exclusiveMinimum":
+			n.addTag("gt="+param, "number")
+		case "exclusiveMaximum":
+			n.addTag("lt="+param, "number")
+		default:
+
+			if num <= 0 {
+				return compileErr(kwPtr, "multipleOf must be greater than 0")
+			}
+
+			n.addTag(MultipleOfTag+"="+param, "number")
+		}
+
+	case "pattern":
+
+		p, ok := val.(string)
+		if !ok {
+			return compileErr(kwPtr, "pattern must be a string")
+		}
+
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return compileErr(kwPtr, "invalid pattern: %s", err)
+		}
+
+		n.addTag(PatternTag+"="+c.addParam(&param{s: p, re: re}), "string")
+
+	case "format":
+
+		if f, ok := val.(string); ok && len(formats[f]) > 0 {
+			n.addTag(formats[f], "string")
+		}
+
+	case "uniqueItems":
+
+		if unique, ok := val.(bool); ok && unique {
+			n.addTag(UniqueItemsTag, "array")
+		}
+
+	case "required":
+
+		names, ok := val.([]interface{})
+		if !ok {
+			return compileErr(kwPtr, "required must be an array of strings")
+		}
+
+		for _, name := range names {
+
+			s, ok := name.(string)
+			if !ok {
+				return compileErr(kwPtr, "required must be an array of strings")
+			}
+
+			n.required = append(n.required, s)
+		}
+
+	case "properties":
+
+		props, ok := val.(map[string]interface{})
+		if !ok {
+			return compileErr(kwPtr, "properties must be an object")
+		}
+
+		n.properties = make(map[string]*node, len(props))
+
+		for name, prop := range props {
+
+			if n.properties[name], err = c.compile(prop, kwPtr+"/"+escapePointer(name)); err != nil {
+				return err
+			}
+
+			n.names = append(n.names, name)
+		}
+
+		sort.Strings(n.names)
+
+	case "additionalProperties":
+
+		if b, ok := val.(bool); ok && !b {
+			n.noAdditional = true
+			return nil
+		}
+
+		n.additional, err = c.compile(val, kwPtr)
+
+	case "propertyNames":
+		n.propertyNames, err = c.compile(val, kwPtr)
+
+	case "items":
+
+		if _, ok := val.([]interface{}); ok {
+			return compileErr(kwPtr, "unsupported items array, use prefixItems")
+		}
+
+		n.items, err = c.compile(val, kwPtr)
+
+	case "not":
+		n.not, err = c.compile(val, kwPtr)
+
+	case "allOf", "anyOf", "oneOf":
+
+		schemas, ok := val.([]interface{})
+		if !ok || len(schemas) == 0 {
+			return compileErr(kwPtr, "%s must be a non empty array", kw)
+		}
+
+		nodes := make([]*node, len(schemas))
+
+		for i, s := range schemas {
+			if nodes[i], err = c.compile(s, kwPtr+"/"+strconv.Itoa(i)); err != nil {
+				return err
+			}
+		}
+
+		switch kw {
+		case "allOf":
+			n.allOf = append(n.allOf, nodes...)
+		case "anyOf":
+			n.anyOf = nodes
+		default:
+			n.oneOf = nodes
+		}
+	}
+
+	// any other keyword is an annotation, such as title, or unknown; and so ignored
+
+	return
+}
+
+// count compiles a keyword limiting the length of the category
+func (c *compiler) count(n *node, kw string, val interface{}, ptr string, cat string) error {
+
+	num, ok := val.(float64)
+	if !ok || num < 0 || num != math.Trunc(num) {
+		return compileErr(ptr, "%s must be a non negative integer", kw)
+	}
+
+	tag := "max="
+	if strings.HasPrefix(kw, "min") {
+		tag = "min="
+	}
+
+	n.addTag(tag+strconv.FormatFloat(num, 'f', -1, 64), cat)
+
+	return nil
+}
+
+// constant compiles const; using eq for strings, so translated the same, unless it can't be escaped
+func (c *compiler) constant(n *node, val interface{}, js string) {
+
+	idx := c.addParam(&param{s: js, value: val})
+
+	n.null = append(n.null, nullCheck{tag: ConstTag, param: idx})
+
+	if s, ok := val.(string); ok && canEscape(s) {
+		n.addTag("eq="+escapeParam(s), "string")
+		n.addTag(ConstTag+"="+idx, "boolean", "number", "array", "object")
+		return
+	}
+
+	n.addTag(ConstTag + "=" + idx)
+}
+
+// enum compiles enum; using oneof for strings, so translated the same, when they have no spaces
+// and can be escaped
+func (c *compiler) enum(n *node, vals []interface{}) {
+
+	b, _ := json.Marshal(vals)
+	idx := c.addParam(&param{s: string(b), value: vals})
+
+	n.null = append(n.null, nullCheck{tag: EnumTag, param: idx})
+
+	strs := make([]string, 0, len(vals))
+
+	for _, val := range vals {
+
+		s, ok := val.(string)
+		if !ok || len(s) == 0 || strings.IndexFunc(s, isSpace) != -1 || !canEscape(s) {
+			n.addTag(EnumTag + "=" + idx)
+			return
+		}
+
+		strs = append(strs, s)
+	}
+
+	n.addTag("oneof="+escapeParam(strings.Join(strs, " ")), "string")
+	n.addTag(EnumTag+"="+idx, "boolean", "number", "array", "object")
+}
+
+func isSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == '\v' || r == '\f'
+}
+
+// resolve returns the schema at the JSON Pointer within the document
+func (c *compiler) resolve(ptr string) (interface{}, error) {
+
+	cur := c.doc
+
+	if len(ptr) == 0 {
+		return cur, nil
+	}
+
+	if ptr[0] != '/' {
+		return nil, fmt.Errorf("unsupported $ref '#%s', only JSON Pointers are", ptr)
+	}
+
+	for _, tok := range strings.Split(ptr[1:], "/") {
+
+		tok = strings.Replace(strings.Replace(tok, "~1", "/", -1), "~0", "~", -1)
+
+		switch d := cur.(type) {
+		case map[string]interface{}:
+
+			next, ok := d[tok]
+			if !ok {
+				return nil, fmt.Errorf("$ref '#%s' not found", ptr)
+			}
+
+			cur = next
+
+		case []interface{}:
+
+			i, err := strconv.Atoi(tok)
+			if err != nil || i < 0 || i >= len(d) {
+				return nil, fmt.Errorf("$ref '#%s' not found", ptr)
+			}
+
+			cur = d[i]
+
+		default:
+			return nil, fmt.Errorf("$ref '#%s' not found", ptr)
+		}
+	}
+
+	return cur, nil
+}
+
+func escapePointer(s string) string {
+	return strings.Replace(strings.Replace(s, "~", "~0", -1), "/", "~1", -1)
+}
+
+var paramReplacer = strings.NewReplacer(",", "0x2C", "|", "0x7C")
+
+// escapeParam escapes the separators within the param, as they are within tags
+func escapeParam(param string) string {
+	return paramReplacer.Replace(param)
+}
+
+// canEscape returns whether the param is the same once escaped and parsed from a tag, which
+// it isn't when it has an escape sequence itself
+func canEscape(param string) bool {
+	return !strings.Contains(param, "0x2C") && !strings.Contains(param, "0x7C")
+}
+
+// Validate validates the data against the schema; a map[string]interface{}, or any value decoded from
+// JSON, or a struct, which is validated as encoded using encoding/json, so the fields map by json name.
+//
+// The FieldErrors' namespaces are the paths of the values within the data, without a top level name,
+// eg. "addresses[0].zip", the same as ValidateMap's.
+//
+// It returns nil or ValidationErrors as error, or the error encoding a struct.
+func (sv *Validator) Validate(data interface{}) error {
+	return sv.ValidateCtx(context.Background(), data)
+}
+
+// ValidateCtx validates the data against the schema, the same as Validate, and allows passing of contextual
+// validation information via context.Context.
+func (sv *Validator) ValidateCtx(ctx context.Context, data interface{}) error {
+
+	errs, err := sv.check(context.WithValue(ctx, paramsKey{}, sv.params), sv.root, data, nil)
+	if err != nil {
+		return err
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// check returns the ValidationErrors of the value at path against the schema, and any other error
+// aborting validation
+func (sv *Validator) check(ctx context.Context, n *node, val interface{}, path validator.Path) (validator.ValidationErrors, error) {
+
+	var errs validator.ValidationErrors
+
+	report := func(val interface{}, tag string, path validator.Path) error {
+
+		err := sv.v.VarPathCtx(ctx, val, tag, path)
+		if err == nil {
+			return nil
+		}
+
+		ve, ok := err.(validator.ValidationErrors)
+		if !ok {
+			return err
+		}
+
+		for _, fe := range ve {
+
+			// reported with the param itself, rather than it's index
+			if paramTags[fe.Tag()] {
+				fe = sv.v.NewFieldError(validator.FieldErrorOptions{
+					Namespace:       fe.Namespace(),
+					StructNamespace: fe.StructNamespace(),
+					Field:           fe.Field(),
+					StructField:     fe.StructField(),
+					Tag:             fe.Tag(),
+					ActualTag:       fe.ActualTag(),
+					Param:           sv.param(fe.Param()).s,
+					Value:           fe.Value(),
+					Path:            fe.Path(),
+				})
+			}
+
+			errs = append(errs, fe)
+		}
+
+		return nil
+	}
+
+	val, err := normalize(val)
+	if err != nil {
+		return nil, err
+	}
+
+	if n.never {
+		return errs, report(val, FalseTag, path)
+	}
+
+	typ := jsonType(reflect.ValueOf(val))
+
+	if len(n.types) > 0 && !typeMatches(typ, n.types) {
+		return errs, report(val, TypeTag+"="+strings.Join(n.types, " "), path)
+	}
+
+	if typ == "null" {
+
+		// nil fails the first validation of a tag, so validated here
+		for _, check := range n.null {
+
+			if !isJSONValue(check.tag, sv.param(check.param).value, nil) {
+
+				if err = report(val, check.tag+"="+check.param, path); err != nil {
+					return nil, err
+				}
+
+				break
+			}
+		}
+
+	} else if cat := category(typ); len(n.tags[cat]) > 0 {
+
+		if err = report(val, n.tags[cat], path); err != nil {
+			return nil, err
+		}
+	}
+
+	current := reflect.ValueOf(val)
+
+	switch typ {
+	case "object":
+
+		if err = sv.object(ctx, n, current, path, &errs, report); err != nil {
+			return nil, err
+		}
+
+	case "array":
+
+		if n.items != nil {
+
+			for i := 0; i < current.Len(); i++ {
+
+				itemErrs, err := sv.check(ctx, n.items, current.Index(i).Interface(), appendPath(path, validator.PathSegment{Kind: validator.IndexSegment, Index: i}))
+				if err != nil {
+					return nil, err
+				}
+
+				errs = append(errs, itemErrs...)
+			}
+		}
+	}
+
+	for _, sub := range n.allOf {
+
+		subErrs, err := sv.check(ctx, sub, val, path)
+		if err != nil {
+			return nil, err
+		}
+
+		errs = append(errs, subErrs...)
+	}
+
+	if len(n.anyOf) > 0 {
+
+		passed, err := sv.passes(ctx, n.anyOf, val, path)
+		if err != nil {
+			return nil, err
+		}
+
+		if passed == 0 {
+			if err = report(val, AnyOfTag, path); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(n.oneOf) > 0 {
+
+		passed, err := sv.passes(ctx, n.oneOf, val, path)
+		if err != nil {
+			return nil, err
+		}
+
+		if passed != 1 {
+			if err = report(val, OneOfTag, path); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if n.not != nil {
+
+		passed, err := sv.passes(ctx, []*node{n.not}, val, path)
+		if err != nil {
+			return nil, err
+		}
+
+		if passed == 1 {
+			if err = report(val, NotTag, path); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return errs, nil
+}
+
+// object checks the properties of the object
+func (sv *Validator) object(ctx context.Context, n *node, current reflect.Value, path validator.Path, errs *validator.ValidationErrors,
+	report func(interface{}, string, validator.Path) error) error {
+
+	keys := make([]string, 0, current.Len())
+	values := make(map[string]interface{}, current.Len())
+
+	for _, key := range current.MapKeys() {
+		keys = append(keys, key.String())
+		values[key.String()] = current.MapIndex(key).Interface()
+	}
+
+	sort.Strings(keys)
+
+	field := func(name string) validator.Path {
+		return appendPath(path, validator.PathSegment{Kind: validator.FieldSegment, Name: name, StructName: name})
+	}
+
+	for _, name := range n.required {
+		if _, ok := values[name]; !ok {
+			if err := report(nil, "required", field(name)); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, name := range keys {
+
+		var sub *node
+
+		if prop, ok := n.properties[name]; ok {
+			sub = prop
+		} else if n.noAdditional {
+
+			if err := report(values[name], AdditionalPropertiesTag, field(name)); err != nil {
+				return err
+			}
+
+			continue
+		} else {
+			sub = n.additional
+		}
+
+		if n.propertyNames != nil {
+
+			nameErrs, err := sv.check(ctx, n.propertyNames, name, field(name))
+			if err != nil {
+				return err
+			}
+
+			*errs = append(*errs, nameErrs...)
+		}
+
+		if sub == nil {
+			continue
+		}
+
+		propErrs, err := sv.check(ctx, sub, values[name], field(name))
+		if err != nil {
+			return err
+		}
+
+		*errs = append(*errs, propErrs...)
+	}
+
+	return nil
+}
+
+// passes returns how many of the schemas the value passes
+func (sv *Validator) passes(ctx context.Context, nodes []*node, val interface{}, path validator.Path) (int, error) {
+
+	passed := 0
+
+	for _, sub := range nodes {
+
+		errs, err := sv.check(ctx, sub, val, path)
+		if err != nil {
+			return 0, err
+		}
+
+		if len(errs) == 0 {
+			passed++
+		}
+	}
+
+	return passed, nil
+}
+
+func appendPath(path validator.Path, seg validator.PathSegment) validator.Path {
+	return append(path[:len(path):len(path)], seg)
+}
+
+// normalize returns structs, and pointers to them, as decoded from their JSON encoding
+func normalize(val interface{}) (interface{}, error) {
+
+	current := reflect.ValueOf(val)
+
+	for current.Kind() == reflect.Ptr || current.Kind() == reflect.Interface {
+
+		if current.IsNil() {
+			return nil, nil
+		}
+
+		current = current.Elem()
+	}
+
+	if current.Kind() != reflect.Struct {
+		return val, nil
+	}
+
+	return roundTrip(val)
+}
+
+func roundTrip(val interface{}) (interface{}, error) {
+
+	b, err := json.Marshal(val)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded interface{}
+
+	if err = json.Unmarshal(b, &decoded); err != nil {
+		return nil, err
+	}
+
+	return decoded, nil
+}
+
+// jsonType returns the JSON type of the value, integer for whole numbers
+func jsonType(current reflect.Value) string {
+
+	for current.Kind() == reflect.Ptr || current.Kind() == reflect.Interface {
+
+		if current.IsNil() {
+			return "null"
+		}
+
+		current = current.Elem()
+	}
+
+	switch current.Kind() {
+	case reflect.Invalid:
+		return "null"
+
+	case reflect.Bool:
+		return "boolean"
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return "integer"
+
+	case reflect.Float32, reflect.Float64:
+
+		if f := current.Float(); f == math.Trunc(f) && !math.IsInf(f, 0) {
+			return "integer"
+		}
+
+		return "number"
+
+	case reflect.String:
+		return "string"
+
+	case reflect.Slice, reflect.Array:
+
+		if current.Kind() == reflect.Slice && current.IsNil() {
+			return "null"
+		}
+
+		return "array"
+
+	case reflect.Map:
+
+		if current.IsNil() {
+			return "null"
+		}
+
+		if current.Type().Key().Kind() == reflect.String {
+			return "object"
+		}
+	}
+
+	return "unknown"
+}
+
+func category(typ string) string {
+
+	if typ == "integer" {
+		return "number"
+	}
+
+	return typ
+}
+
+func typeMatches(typ string, types []string) bool {
+
+	for _, t := range types {
+		if t == typ || (t == "number" && typ == "integer") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// param returns the param at the index, as held by a tag
+func (sv *Validator) param(idx string) *param {
+	return paramAt(sv.params, idx)
+}
+
+// contextParam returns the param at the index of the validation's param, within the params of the
+// Validator validating; or, when used outside of a Validator, eg. by Var, the param of the tag itself.
+func contextParam(ctx context.Context, fl validator.FieldLevel, tag string) *param {
+
+	if params, ok := ctx.Value(paramsKey{}).([]*param); ok {
+		return paramAt(params, fl.Param())
+	}
+
+	return literalParam(tag, fl.Param())
+}
+
+// literalParams are the params of the validations used outside of a Validator, by tag and param
+var literalParams sync.Map
+
+// literalParam returns the param of the tag when it's param is the pattern, or the JSON of the const
+// or enum, itself; as when used by Var, eg. "jsonschema_pattern=^[a-z]+$".
+func literalParam(tag string, s string) *param {
+
+	key := tag + "=" + s
+
+	if p, ok := literalParams.Load(key); ok {
+		return p.(*param)
+	}
+
+	p := &param{s: s}
+
+	if tag == PatternTag {
+
+		re, err := regexp.Compile(s)
+		if err != nil {
+			panic(fmt.Sprintf("Bad param %s for %s, %s", s, tag, err))
+		}
+
+		p.re = re
+
+	} else if err := json.Unmarshal([]byte(s), &p.value); err != nil {
+		panic(fmt.Sprintf("Bad param %s for %s, %s", s, tag, err))
+	}
+
+	if _, ok := p.value.([]interface{}); tag == EnumTag && !ok {
+		panic(fmt.Sprintf("Bad param %s for %s, not an array", s, tag))
+	}
+
+	literalParams.Store(key, p)
+
+	return p
+}
+
+func paramAt(params []*param, idx string) *param {
+
+	i, err := strconv.Atoi(idx)
+	if err != nil || i < 0 || i >= len(params) {
+		panic(fmt.Sprintf("Bad param %s, not compiled by this Validator", idx))
+	}
+
+	return params[i]
+}
+
+// isJSONValue returns whether the value equals the const, or is one of the enum, expected
+func isJSONValue(tag string, expected interface{}, val interface{}) bool {
+
+	decoded, err := roundTrip(val)
+	if err != nil {
+		return false
+	}
+
+	if tag == ConstTag {
+		return reflect.DeepEqual(decoded, expected)
+	}
+
+	for _, e := range expected.([]interface{}) {
+		if reflect.DeepEqual(decoded, e) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isType(fl validator.FieldLevel) bool {
+	return typeMatches(jsonType(fl.Field()), strings.Fields(fl.Param()))
+}
+
+func matchesPattern(ctx context.Context, fl validator.FieldLevel) bool {
+	return contextParam(ctx, fl, PatternTag).re.MatchString(fl.Field().String())
+}
+
+func isFormat(fl validator.FieldLevel) bool {
+
+	s := fl.Field().String()
+
+	var err error
+
+	switch fl.Param() {
+	case "date-time":
+		_, err = time.Parse(time.RFC3339, s)
+	case "date":
+		_, err = time.Parse("2006-01-02", s)
+	case "time":
+		_, err = time.Parse("15:04:05Z07:00", s)
+	case "regex":
+		_, err = regexp.Compile(s)
+	case "json-pointer":
+		if len(s) > 0 && s[0] != '/' {
+			return false
+		}
+	default:
+		panic(fmt.Sprintf("Bad format %s for %s", fl.Param(), FormatTag))
+	}
+
+	return err == nil
+}
+
+func isMultipleOf(fl validator.FieldLevel) bool {
+
+	m, err := strconv.ParseFloat(fl.Param(), 64)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	var f float64
+
+	switch fl.Field().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f = float64(fl.Field().Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		f = float64(fl.Field().Uint())
+	case reflect.Float32, reflect.Float64:
+		f = fl.Field().Float()
+	default:
+		panic(fmt.Sprintf("Bad field type %T", fl.Field().Interface()))
+	}
+
+	q := f / m
+
+	return math.Abs(q-math.Round(q)) < 1e-9
+}
+
+func isConst(ctx context.Context, fl validator.FieldLevel) bool {
+	return isJSONValue(ConstTag, contextParam(ctx, fl, ConstTag).value, fl.Field().Interface())
+}
+
+func isEnum(ctx context.Context, fl validator.FieldLevel) bool {
+	return isJSONValue(EnumTag, contextParam(ctx, fl, EnumTag).value, fl.Field().Interface())
+}
+
+func hasUniqueItems(fl validator.FieldLevel) bool {
+
+	field := fl.Field()
+
+	items := make([]interface{}, field.Len())
+
+	for i := range items {
+
+		item, err := roundTrip(field.Index(i).Interface())
+		if err != nil {
+			return false
+		}
+
+		for _, prev := range items[:i] {
+			if reflect.DeepEqual(prev, item) {
+				return false
+			}
+		}
+
+		items[i] = item
+	}
+
+	return true
+}
+
+// fail is the validation of the applicators, reported having evaluated the subschemas
+func fail(fl validator.FieldLevel) bool {
+	return false
+}
@@ -0,0 +1,369 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	english "package/locales/en"
+	ut "package/universal-translator"
+	"package/validator"
+	en_translations "package/validator/translations/en"
+
+	. "gopkg.in/go-playground/assert.v1"
+)
+
+const userSchema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"title": "User",
+	"type": "object",
+	"required": ["name", "email"],
+	"additionalProperties": false,
+	"properties": {
+		"name": {"type": "string", "minLength": 2, "maxLength": 8},
+		"email": {"type": "string", "format": "email"},
+		"age": {"type": "integer", "minimum": 18, "exclusiveMaximum": 150},
+		"role": {"enum": ["admin", "user"]},
+		"code": {"type": "string", "pattern": "^[A-Z]{2,3}$"},
+		"score": {"type": "number", "multipleOf": 0.5},
+		"tags": {"type": "array", "maxItems": 2, "uniqueItems": true, "items": {"type": "string"}},
+		"labels": {"type": "object", "propertyNames": {"minLength": 2}, "additionalProperties": {"type": "integer"}},
+		"address": {"$ref": "#/$defs/Address"},
+		"created": {"type": "string", "format": "date-time"},
+		"parent": {"anyOf": [{"type": "null"}, {"$ref": "#"}]}
+	},
+	"$defs": {
+		"Address": {
+			"type": "object",
+			"required": ["zip"],
+			"properties": {
+				"zip": {"type": "string", "minLength": 5, "maxLength": 5}
+			}
+		}
+	}
+}`
+
+type fieldErr struct {
+	ns    string
+	tag   string
+	param string
+}
+
+func fieldErrs(t *testing.T, err error) []fieldErr {
+
+	if err == nil {
+		return nil
+	}
+
+	ve, ok := err.(validator.ValidationErrors)
+	if !ok {
+		t.Fatalf("expected ValidationErrors, got %#v", err)
+	}
+
+	errs := make([]fieldErr, len(ve))
+
+	for i, fe := range ve {
+		errs[i] = fieldErr{ns: fe.Namespace(), tag: fe.Tag(), param: fe.Param()}
+	}
+
+	return errs
+}
+
+func decode(t *testing.T, s string) interface{} {
+
+	var data interface{}
+
+	err := json.Unmarshal([]byte(s), &data)
+	Equal(t, err, nil)
+
+	return data
+}
+
+func TestCompile(t *testing.T) {
+
+	sv, err := Compile(validator.New(), []byte(userSchema))
+	Equal(t, err, nil)
+
+	err = sv.Validate(decode(t, `{
+		"name": "joeybloggs",
+		"email": "joey@example.com",
+		"age": 21,
+		"role": "admin",
+		"code": "NZ",
+		"score": 4.5,
+		"tags": ["a", "b"],
+		"labels": {"ab": 1},
+		"address": {"zip": "12345"},
+		"created": "2020-01-02T03:04:05Z",
+		"parent": {"name": "jo", "email": "jo@example.com"}
+	}`))
+	Equal(t, fieldErrs(t, err), []fieldErr{{ns: "name", tag: "max", param: "8"}})
+
+	err = sv.Validate(decode(t, `{
+		"name": "j",
+		"age": 17.5,
+		"role": "owner",
+		"code": "nz",
+		"score": 4.2,
+		"tags": ["a", "a"],
+		"labels": {"a": 1, "bc": "x"},
+		"address": {},
+		"created": "yesterday",
+		"parent": {"name": 1},
+		"extra": true
+	}`))
+	Equal(t, fieldErrs(t, err), []fieldErr{
+		{ns: "email", tag: "required"},
+		{ns: "address.zip", tag: "required"},
+		{ns: "age", tag: TypeTag, param: "integer"},
+		{ns: "code", tag: PatternTag, param: "^[A-Z]{2,3}$"},
+		{ns: "created", tag: FormatTag, param: "date-time"},
+		{ns: "extra", tag: AdditionalPropertiesTag},
+		{ns: "labels.a", tag: "min", param: "2"},
+		{ns: "labels.bc", tag: TypeTag, param: "integer"},
+		{ns: "name", tag: "min", param: "2"},
+		{ns: "parent", tag: AnyOfTag},
+		{ns: "role", tag: "oneof", param: "admin user"},
+		{ns: "score", tag: MultipleOfTag, param: "0.5"},
+		{ns: "tags", tag: UniqueItemsTag},
+	})
+
+	err = sv.Validate(decode(t, `{"name": "jo", "email": "jo@example.com", "tags": ["a", 1]}`))
+	Equal(t, fieldErrs(t, err), []fieldErr{{ns: "tags[1]", tag: TypeTag, param: "string"}})
+
+	err = sv.Validate(decode(t, `[]`))
+	Equal(t, fieldErrs(t, err), []fieldErr{{ns: "", tag: TypeTag, param: "object"}})
+}
+
+func TestCompileStruct(t *testing.T) {
+
+	type Address struct {
+		Zip string `json:"zip"`
+	}
+
+	type User struct {
+		Name    string   `json:"name"`
+		Email   string   `json:"email"`
+		Age     int      `json:"age,omitempty"`
+		Address *Address `json:"address,omitempty"`
+	}
+
+	sv, err := Compile(validator.New(), []byte(userSchema))
+	Equal(t, err, nil)
+
+	err = sv.Validate(&User{Name: "joeybloggs", Email: "joey", Age: 12, Address: &Address{Zip: "1"}})
+	Equal(t, fieldErrs(t, err), []fieldErr{
+		{ns: "address.zip", tag: "min", param: "5"},
+		{ns: "age", tag: "min", param: "18"},
+		{ns: "email", tag: "email"},
+		{ns: "name", tag: "max", param: "8"},
+	})
+
+	err = sv.Validate(User{Name: "joey", Email: "joey@example.com"})
+	Equal(t, err, nil)
+
+	err = sv.Validate((*User)(nil))
+	Equal(t, fieldErrs(t, err), []fieldErr{{ns: "", tag: TypeTag, param: "object"}})
+}
+
+func TestCompileKeywords(t *testing.T) {
+
+	tests := []struct {
+		schema string
+		data   string
+		errs   []fieldErr
+	}{
+		{schema: `true`, data: `1`},
+		{schema: `false`, data: `1`, errs: []fieldErr{{tag: FalseTag}}},
+		{schema: `{"type": ["string", "null"]}`, data: `null`},
+		{schema: `{"type": ["string", "null"]}`, data: `1`, errs: []fieldErr{{tag: TypeTag, param: "string null"}}},
+		{schema: `{"type": "number"}`, data: `1`},
+		{schema: `{"type": "integer"}`, data: `1.0`},
+		{schema: `{"const": "a,b"}`, data: `"a,b"`},
+		{schema: `{"const": "a,b"}`, data: `"a"`, errs: []fieldErr{{tag: "eq", param: "a,b"}}},
+		{schema: `{"const": "a,b"}`, data: `1`, errs: []fieldErr{{tag: ConstTag, param: `"a,b"`}}},
+		{schema: `{"const": {"a": [1]}}`, data: `{"a": [1]}`},
+		{schema: `{"const": {"a": [1]}}`, data: `{"a": [2]}`, errs: []fieldErr{{tag: ConstTag, param: `{"a":[1]}`}}},
+		{schema: `{"const": null}`, data: `null`},
+		{schema: `{"const": 1}`, data: `null`, errs: []fieldErr{{tag: ConstTag, param: "1"}}},
+		{schema: `{"enum": [1, "a b", null]}`, data: `null`},
+		{schema: `{"enum": [1, "a b", null]}`, data: `"a b"`},
+		{schema: `{"enum": [1, "a b", null]}`, data: `2`, errs: []fieldErr{{tag: EnumTag, param: `[1,"a b",null]`}}},
+		{schema: `{"enum": ["a", "b"]}`, data: `true`, errs: []fieldErr{{tag: EnumTag, param: `["a","b"]`}}},
+		{schema: `{"minLength": 2}`, data: `5`},
+		{schema: `{"minProperties": 1}`, data: `{}`, errs: []fieldErr{{tag: "min", param: "1"}}},
+		{schema: `{"exclusiveMinimum": 1}`, data: `1`, errs: []fieldErr{{tag: "gt", param: "1"}}},
+		{schema: `{"maximum": 1.5}`, data: `1.6`, errs: []fieldErr{{tag: "max", param: "1.5"}}},
+		{schema: `{"allOf": [{"minimum": 1}, {"maximum": 3}]}`, data: `4`, errs: []fieldErr{{tag: "max", param: "3"}}},
+		{schema: `{"oneOf": [{"minimum": 1}, {"maximum": 3}]}`, data: `2`, errs: []fieldErr{{tag: OneOfTag}}},
+		{schema: `{"oneOf": [{"minimum": 1}, {"maximum": 3}]}`, data: `4`},
+		{schema: `{"not": {"type": "string"}}`, data: `"a"`, errs: []fieldErr{{tag: NotTag}}},
+		{schema: `{"not": {"type": "string"}}`, data: `1`},
+		{schema: `{"format": "uuid"}`, data: `"a"`, errs: []fieldErr{{tag: "uuid"}}},
+		{schema: `{"format": "unknown"}`, data: `"a"`},
+		{schema: `{"items": {"$ref": "#/$defs/positive"}, "$defs": {"positive": {"exclusiveMinimum": 0}}}`, data: `[1, 0]`, errs: []fieldErr{{ns: "[1]", tag: "gt", param: "0"}}},
+		{schema: `{"properties": {"a/b": {"type": "string"}, "c": {"$ref": "#/properties/a~1b"}}}`, data: `{"c": 1}`, errs: []fieldErr{{ns: "c", tag: TypeTag, param: "string"}}},
+		{schema: `{"properties": {"next": {"$ref": "#"}}, "required": ["id"]}`, data: `{"id": 1, "next": {"id": 2, "next": {}}}`, errs: []fieldErr{{ns: "next.next.id", tag: "required"}}},
+		{schema: `{"pattern": "0x2C"}`, data: `"0x2C"`},
+		{schema: `{"pattern": "0x2C"}`, data: `","`, errs: []fieldErr{{tag: PatternTag, param: "0x2C"}}},
+		{schema: `{"pattern": "^a,b|c$"}`, data: `"a,b"`},
+		{schema: `{"const": "0x2C"}`, data: `"0x2C"`},
+		{schema: `{"const": "0x2C"}`, data: `","`, errs: []fieldErr{{tag: ConstTag, param: `"0x2C"`}}},
+		{schema: `{"enum": ["0x7C", "a"]}`, data: `"0x7C"`},
+		{schema: `{"enum": ["0x7C", "a"]}`, data: `"|"`, errs: []fieldErr{{tag: EnumTag, param: `["0x7C","a"]`}}},
+	}
+
+	for i, tt := range tests {
+
+		sv, err := Compile(validator.New(), []byte(tt.schema))
+		if err != nil {
+			t.Fatalf("Index: %d Compile failed: %s", i, err)
+		}
+
+		err = sv.Validate(decode(t, tt.data))
+
+		if errs := fieldErrs(t, err); !equalErrs(errs, tt.errs) {
+			t.Fatalf("Index: %d expected %v got %v", i, tt.errs, errs)
+		}
+	}
+}
+
+func equalErrs(a, b []fieldErr) bool {
+
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestCompileErrors(t *testing.T) {
+
+	tests := []struct {
+		schema string
+		err    string
+	}{
+		{schema: `{"if": {}}`, err: "jsonschema: unsupported keyword 'if' at '#'"},
+		{schema: `{"properties": {"a": {"patternProperties": {}}}}`, err: "jsonschema: unsupported keyword 'patternProperties' at '#/properties/a'"},
+		{schema: `{"$ref": "other.json#/a"}`, err: "jsonschema: unsupported $ref 'other.json#/a', only those within the document are at '#'"},
+		{schema: `{"$ref": "#/$defs/missing"}`, err: "jsonschema: $ref '#/$defs/missing' not found at '#'"},
+		{schema: `{"type": "text"}`, err: "jsonschema: unknown type 'text' at '#/type'"},
+		{schema: `{"pattern": "(a"}`, err: "jsonschema: invalid pattern: error parsing regexp: missing closing ): `(a` at '#/pattern'"},
+		{schema: `{"minLength": -1}`, err: "jsonschema: minLength must be a non negative integer at '#/minLength'"},
+		{schema: `{"multipleOf": 0}`, err: "jsonschema: multipleOf must be greater than 0 at '#/multipleOf'"},
+		{schema: `{"exclusiveMinimum": true}`, err: "jsonschema: exclusiveMinimum must be a number at '#/exclusiveMinimum'"},
+		{schema: `{"items": [{}]}`, err: "jsonschema: unsupported items array, use prefixItems at '#/items'"},
+		{schema: `{"anyOf": []}`, err: "jsonschema: anyOf must be a non empty array at '#/anyOf'"},
+		{schema: `{"properties": {"a": 1}}`, err: "jsonschema: schema must be an object or boolean at '#/properties/a'"},
+		{schema: `{"$defs": {"A": {"$ref": "#/$defs/A"}}, "$ref": "#/$defs/A"}`, err: "jsonschema: $ref cycle applying the schema to the value it validates at '#/$defs/A'"},
+		{schema: `{"anyOf": [{"$ref": "#"}, {"type": "string"}]}`, err: "jsonschema: $ref cycle applying the schema to the value it validates at '#'"},
+		{schema: `{"properties": {"a": {"not": {"allOf": [{"$ref": "#/properties/a"}]}}}}`, err: "jsonschema: $ref cycle applying the schema to the value it validates at '#/properties/a'"},
+	}
+
+	for i, tt := range tests {
+
+		_, err := Compile(validator.New(), []byte(tt.schema))
+		if err == nil || err.Error() != tt.err {
+			t.Fatalf("Index: %d expected %s got %v", i, tt.err, err)
+		}
+	}
+
+	_, err := Compile(validator.New(), []byte(`{`))
+	NotEqual(t, err, nil)
+}
+
+func TestCompileParams(t *testing.T) {
+
+	validate := validator.New()
+
+	// the validations are registered on the shared validate, the params being of each Validator
+	a, err := Compile(validate, []byte(`{"pattern": "^a$", "not": {"const": "a"}}`))
+	Equal(t, err, nil)
+
+	b, err := Compile(validate, []byte(`{"pattern": "^b$"}`))
+	Equal(t, err, nil)
+
+	err = b.Validate("b")
+	Equal(t, err, nil)
+
+	err = a.Validate("b")
+	Equal(t, fieldErrs(t, err), []fieldErr{{tag: PatternTag, param: "^a$"}})
+
+	err = a.Validate("a")
+	Equal(t, fieldErrs(t, err), []fieldErr{{tag: NotTag}})
+
+	// the param is reported, rather than it's index, keeping the path
+	c, err := Compile(validate, []byte(`{"items": {"pattern": "^b$"}}`))
+	Equal(t, err, nil)
+
+	err = c.Validate([]interface{}{"b", "c"})
+	NotEqual(t, err, nil)
+
+	fe := err.(validator.ValidationErrors)[0]
+	Equal(t, fe.Param(), "^b$")
+	Equal(t, fe.Path().String(), "[1]")
+}
+
+func TestRegister(t *testing.T) {
+
+	validate := validator.New()
+
+	err := Register(validate)
+	Equal(t, err, nil)
+
+	// used outside of a Validator the param is the pattern, or JSON, itself
+	err = validate.Var("abc", PatternTag+"=^[a-z]+$")
+	Equal(t, err, nil)
+
+	err = validate.Var("ABC", PatternTag+"=^[a-z]+$")
+	Equal(t, fieldErrs(t, err), []fieldErr{{tag: PatternTag, param: "^[a-z]+$"}})
+
+	err = validate.Var(2.0, ConstTag+"=2")
+	Equal(t, err, nil)
+
+	err = validate.Var(true, EnumTag+"=[1 0x2C true]")
+	Equal(t, err, nil)
+
+	err = validate.Var("a", EnumTag+"=[1 0x2C true]")
+	Equal(t, fieldErrs(t, err), []fieldErr{{tag: EnumTag, param: "[1 , true]"}})
+
+	PanicMatches(t, func() { _ = validate.Var("a", PatternTag+"=[") }, "Bad param [ for jsonschema_pattern, error parsing regexp: missing closing ]: `[`")
+	PanicMatches(t, func() { _ = validate.Var("a", EnumTag+"=1") }, "Bad param 1 for jsonschema_enum, not an array")
+
+	// registered already, so not again
+	sv, err := Compile(validate, []byte(`{"pattern": "^a$"}`))
+	Equal(t, err, nil)
+
+	err = sv.Validate("b")
+	Equal(t, fieldErrs(t, err), []fieldErr{{tag: PatternTag, param: "^a$"}})
+}
+
+func TestCompileTranslations(t *testing.T) {
+
+	eng := english.New()
+	uni := ut.New(eng, eng)
+	trans, _ := uni.GetTranslator("en")
+
+	validate := validator.New()
+
+	err := en_translations.RegisterDefaultTranslations(validate, trans)
+	Equal(t, err, nil)
+
+	sv, err := Compile(validate, []byte(userSchema))
+	Equal(t, err, nil)
+
+	err = sv.Validate(decode(t, `{"name": "j", "email": "joey", "age": "old", "extra": 1}`))
+	NotEqual(t, err, nil)
+
+	Equal(t, err.(validator.ValidationErrors).Translate(trans), validator.ValidationErrorsTranslations{
+		"age":   "age must be of type integer",
+		"email": "email must be a valid email address",
+		"extra": "extra is not an allowed property",
+		"name":  "name must be at least 2 characters in length",
+	})
+}
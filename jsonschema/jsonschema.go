@@ -0,0 +1,653 @@
+/*
+Package jsonschema generates JSON Schema, draft 2020-12, documents from the validations of
+struct types; so the constraints enforced in Go can be shared with frontends and API docs.
+
+The validations are those parsed for validation, see Validate.StructFields, so include rules
+registered using RegisterStructRules and aliases. Those with an equivalent in JSON Schema are
+converted, depending on the kind of the field:
+
+	required                    the field is in the object's required array, strings, slices and
+	                            maps having a minLength, minItems or minProperties of 1
+	omitempty                   anyOf the constraints and the empty value, eg. "" or 0
+	min, max, len               minLength, maxLength for strings; minimum, maximum for numbers;
+	                            minItems, maxItems for slices and arrays; minProperties,
+	                            maxProperties for maps
+	gt, gte, lt, lte            as above, gt and lt being exclusiveMinimum and exclusiveMaximum for numbers
+	eq, ne                      const and not const
+	oneof                       enum
+	email, uri, url, uuid, ...  format
+	alpha, alphanum, numeric    pattern
+	unique                      uniqueItems
+	dive                        items for slices and arrays, additionalProperties for maps
+	keys                        propertyNames
+	a|b                         anyOf
+
+Any other validation, including custom validations, is ignored unless a TagFunc is registered
+for it, contributing the schema fragment of the validation; eg.
+
+	g := jsonschema.New(validate)
+	g.RegisterTag("is-awesome", func(s *jsonschema.Schema, param string, typ reflect.Type) {
+		s.Const = "awesome"
+	})
+
+	schema, err := g.Generate(reflect.TypeOf(User{}))
+
+Validations that can't be converted can instead be handled by a FallbackFunc, registered
+using RegisterFallback; eg. recording them in an extension keyword.
+
+Property names are those of the JSON encoding, the json tag's name taking precedence over the
+field's name, or those of the validator's TagNameFunc, see UseFieldNames; fields with a json tag
+of "-" are excluded and embedded structs flattened. Pointers, slices and maps, unless required, are
+anyOf their schema and null, as encoding/json encodes them when nil. Nested structs are defined once
+within $defs, and referenced using $ref, allowing recursive types; GenerateDefs returns the definitions
+alone, for documents such as OpenAPI's, which define them elsewhere.
+
+The reverse, a JSON Schema compiled into a Validator, validates maps, or any value decoded from JSON,
+and structs, by their JSON encoding; returning ValidationErrors, so rendered and translated the same as
+those of Struct. eg.
+
+	sv, err := jsonschema.Compile(validate, partnerSchema)
+
+	err = sv.Validate(data) // map[string]interface{}
+	err = sv.Validate(user) // struct
+
+Keywords with an equivalent baked in validation are reported using it's tag, eg. minLength as min,
+the rest using tags such as TypeTag.
+*/
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"package/validator"
+)
+
+// Draft is the JSON Schema dialect of the documents generated
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+const defaultRefPrefix = "#/$defs/"
+
+// Schema is a JSON Schema, or a subschema, of the keywords the validations convert to
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	ContentEncoding      string             `json:"contentEncoding,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Const                interface{}        `json:"const,omitempty"`
+	Not                  *Schema            `json:"not,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     *float64           `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     *float64           `json:"exclusiveMaximum,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	UniqueItems          bool               `json:"uniqueItems,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	MinProperties        *int               `json:"minProperties,omitempty"`
+	MaxProperties        *int               `json:"maxProperties,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	PropertyNames        *Schema            `json:"propertyNames,omitempty"`
+
+	// Extra holds any other keywords, eg. those added by a TagFunc, which are
+	// merged with the keywords above when marshaled
+	Extra map[string]interface{} `json:"-"`
+}
+
+// MarshalJSON marshals the Schema along with the Extra keywords
+func (s *Schema) MarshalJSON() ([]byte, error) {
+
+	// avoids recursing into MarshalJSON
+	type schema Schema
+
+	b, err := json.Marshal((*schema)(s))
+	if err != nil || len(s.Extra) == 0 {
+		return b, err
+	}
+
+	var doc map[string]interface{}
+
+	if err = json.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+
+	for k, v := range s.Extra {
+		if _, ok := doc[k]; !ok {
+			doc[k] = v
+		}
+	}
+
+	return json.Marshal(doc)
+}
+
+// FallbackFunc is called with the validations that can't be converted, having no TagFunc or one
+// that contributes nothing, of a value of type typ with the schema s; the alternatives of an or'ed
+// group being passed together when any can't be converted.
+type FallbackFunc func(s *Schema, vals []validator.Validation, typ reflect.Type)
+
+// TagFunc contributes the schema fragment of a validation to s, the schema of the value validated of type
+// typ, pointers having been dereferenced; param is the validation's param, if any.
+type TagFunc func(s *Schema, param string, typ reflect.Type)
+
+// Generator generates JSON Schemas from the validations of struct types
+type Generator struct {
+	v          *validator.Validate
+	tags       map[string]TagFunc
+	fallback   FallbackFunc
+	refPrefix  string
+	fieldNames bool
+	defs       map[string]*Schema
+	seen       map[reflect.Type]string
+}
+
+// New returns a Generator using the validations parsed by v
+func New(v *validator.Validate) *Generator {
+
+	g := &Generator{
+		v:         v,
+		tags:      make(map[string]TagFunc, len(bakedInTags)),
+		refPrefix: defaultRefPrefix,
+	}
+
+	for tag, fn := range bakedInTags {
+		g.tags[tag] = fn
+	}
+
+	return g
+}
+
+// RegisterTag registers the TagFunc converting the validation tag, eg. a custom validation
+// registered using RegisterValidation; replacing any previously registered, including those
+// baked in.
+func (g *Generator) RegisterTag(tag string, fn TagFunc) {
+	g.tags[tag] = fn
+}
+
+// RegisterFallback registers the FallbackFunc called with the validations that can't be converted,
+// which are otherwise ignored.
+func (g *Generator) RegisterFallback(fn FallbackFunc) {
+	g.fallback = fn
+}
+
+// SetRefPrefix sets the prefix of the $ref of struct types, "#/$defs/" by default; for documents
+// defining the structs elsewhere, such as OpenAPI's "#/components/schemas/", see GenerateDefs.
+func (g *Generator) SetRefPrefix(prefix string) {
+	g.refPrefix = prefix
+}
+
+// UseFieldNames names properties by the names of the fields within namespaces, as returned by the
+// validator's TagNameFunc, rather than by the json tag; fields named "-" are excluded.
+func (g *Generator) UseFieldNames() {
+	g.fieldNames = true
+}
+
+// Generate returns the JSON Schema of the struct type t, nested structs being within $defs.
+//
+// It returns validator.InvalidValidationError when t is not a struct and a *validator.InvalidTagError
+// when a tag is invalid.
+func (g *Generator) Generate(t reflect.Type) (s *Schema, err error) {
+
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct || t == timeType {
+		return nil, &validator.InvalidValidationError{Type: t}
+	}
+
+	g.defs = make(map[string]*Schema)
+	g.seen = make(map[reflect.Type]string)
+
+	defer func() {
+		g.defs = nil
+		g.seen = nil
+	}()
+
+	// the top level struct is defined inline, only referenced from $defs when recursive
+	name := g.defName(t)
+
+	if s, err = g.structSchema(t); err != nil {
+		return nil, err
+	}
+
+	if _, ok := g.defs[name]; ok {
+		g.defs[name] = s
+		s = &Schema{Ref: g.refPrefix + name}
+	}
+
+	s.Schema = Draft
+
+	if len(g.defs) > 0 {
+		s.Defs = g.defs
+	}
+
+	return s, nil
+}
+
+// GenerateDefs returns the schemas of the struct types, and those nested within them, keyed by
+// name; for placing within a document, the names being unique, and referenced using the prefix set
+// using SetRefPrefix.
+//
+// It returns validator.InvalidValidationError when a type is not a struct and a *validator.InvalidTagError
+// when a tag is invalid.
+func (g *Generator) GenerateDefs(types ...reflect.Type) (map[string]*Schema, error) {
+
+	g.defs = make(map[string]*Schema)
+	g.seen = make(map[reflect.Type]string)
+
+	defer func() {
+		g.defs = nil
+		g.seen = nil
+	}()
+
+	for _, t := range types {
+
+		for t != nil && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+
+		if t == nil || t.Kind() != reflect.Struct || t == timeType {
+			return nil, &validator.InvalidValidationError{Type: t}
+		}
+
+		if _, err := g.structRef(t); err != nil {
+			return nil, err
+		}
+	}
+
+	return g.defs, nil
+}
+
+// defName returns the name the struct type is defined as within $defs, unique within the document
+func (g *Generator) defName(t reflect.Type) string {
+
+	if name, ok := g.seen[t]; ok {
+		return name
+	}
+
+	name := t.Name()
+	if len(name) == 0 {
+		name = "Struct"
+	}
+
+	base := name
+
+	for i := 2; g.nameTaken(name); i++ {
+		name = base + strconv.Itoa(i)
+	}
+
+	g.seen[t] = name
+
+	return name
+}
+
+func (g *Generator) nameTaken(name string) bool {
+
+	for _, n := range g.seen {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// structRef returns the $ref of the struct type, defining it the first time it's seen
+func (g *Generator) structRef(t reflect.Type) (*Schema, error) {
+
+	_, seen := g.seen[t]
+	name := g.defName(t)
+
+	if !seen {
+
+		// placeholder so recursive references don't define it again
+		g.defs[name] = nil
+
+		s, err := g.structSchema(t)
+		if err != nil {
+			return nil, err
+		}
+
+		g.defs[name] = s
+	} else if _, ok := g.defs[name]; !ok {
+		// the top level struct being referenced recursively
+		g.defs[name] = nil
+	}
+
+	return &Schema{Ref: g.refPrefix + name}, nil
+}
+
+func (g *Generator) structSchema(t reflect.Type) (*Schema, error) {
+
+	fields, err := g.v.StructFields(t)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+
+	for _, f := range fields {
+
+		name, ok := jsonName(f.Field)
+		if !ok || (g.fieldNames && f.Name == "-") {
+			continue
+		}
+
+		ft := f.Field.Type
+
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+
+		// embedded structs are flattened by encoding/json
+		if f.Field.Anonymous && len(name) == 0 && ft.Kind() == reflect.Struct {
+
+			embedded, err := g.structSchema(ft)
+			if err != nil {
+				return nil, err
+			}
+
+			for k, v := range embedded.Properties {
+				if _, ok := s.Properties[k]; !ok {
+					s.Properties[k] = v
+				}
+			}
+
+			s.Required = append(s.Required, embedded.Required...)
+
+			continue
+		}
+
+		if g.fieldNames {
+			name = f.Name
+		} else if len(name) == 0 {
+			name = f.Field.Name
+		}
+
+		rules := f.Rules
+		if f.Skip {
+			rules = &validator.Rules{}
+		}
+
+		prop, required, err := g.valueSchema(f.Field.Type, rules)
+		if err != nil {
+			return nil, err
+		}
+
+		s.Properties[name] = prop
+
+		if required {
+			s.Required = append(s.Required, name)
+		}
+	}
+
+	return s, nil
+}
+
+// jsonName returns the name of the field within it's JSON encoding, blank when the json tag
+// doesn't name it, and false when the field isn't encoded
+func jsonName(fld reflect.StructField) (string, bool) {
+
+	tag, ok := fld.Tag.Lookup("json")
+	if !ok {
+		return "", true
+	}
+
+	name := strings.SplitN(tag, ",", 2)[0]
+
+	if name == "-" && !strings.HasPrefix(tag, "-,") {
+		return "", false
+	}
+
+	return name, true
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// valueSchema returns the schema of a value of type typ validated by rules, and whether it's required
+func (g *Generator) valueSchema(typ reflect.Type, rules *validator.Rules) (*Schema, bool, error) {
+
+	isPtr := typ.Kind() == reflect.Ptr
+
+	// encoded as null by encoding/json when nil
+	nilable := isPtr || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Map
+
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	s, err := g.typeSchema(typ, rules)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if rules == nil {
+
+		if nilable {
+			s = &Schema{AnyOf: []*Schema{s, {Type: "null"}}}
+		}
+
+		return s, false, nil
+	}
+
+	required := false
+
+	for _, or := range rules.Validations {
+
+		if len(or) == 1 {
+
+			if or[0].Tag == "required" {
+				required = true
+				continue
+			}
+
+			if !g.apply(s, or[0], typ) && g.fallback != nil {
+				g.fallback(s, or, typ)
+			}
+
+			continue
+		}
+
+		var anyOf []*Schema
+
+		for _, val := range or {
+
+			frag := &Schema{}
+
+			if g.apply(frag, val, typ) {
+				anyOf = append(anyOf, frag)
+			}
+		}
+
+		// an alternative that can't be converted may be the one that passes
+		if len(anyOf) == len(or) {
+			s.AnyOf = append(s.AnyOf, anyOf...)
+		} else if g.fallback != nil {
+			g.fallback(s, or, typ)
+		}
+	}
+
+	// a pointer to an empty value is present, but an empty string, slice or map isn't
+	if required && !isPtr {
+		requireNonEmpty(s, typ)
+	}
+
+	// the empty value, which omitempty skips the validations of, is valid regardless of them
+	var empty *Schema
+
+	if nilable {
+		if !required {
+			empty = &Schema{Type: "null"}
+		}
+	} else if rules.OmitEmpty && len(rules.Validations) > 0 {
+		empty = emptySchema(typ)
+	}
+
+	if empty != nil {
+		s = &Schema{AnyOf: []*Schema{s, empty}}
+	}
+
+	return s, required, nil
+}
+
+// requireNonEmpty sets the minimum length of the schema s, of a value of type typ, to 1 unless
+// already greater
+func requireNonEmpty(s *Schema, typ reflect.Type) {
+
+	var min **int
+
+	switch typ.Kind() {
+	case reflect.String:
+		min = &s.MinLength
+
+	case reflect.Slice:
+
+		// []byte being a base64 string
+		if s.Type == "string" {
+			min = &s.MinLength
+		} else {
+			min = &s.MinItems
+		}
+
+	case reflect.Map:
+		min = &s.MinProperties
+
+	default:
+		return
+	}
+
+	if *min == nil || **min < 1 {
+		one := 1
+		*min = &one
+	}
+}
+
+// emptySchema returns the schema of the empty value of typ, as encoded by encoding/json; nil
+// when omitempty never skips the validations of the type.
+func emptySchema(typ reflect.Type) *Schema {
+
+	if typ == timeType {
+		return &Schema{Const: time.Time{}.Format(time.RFC3339Nano)}
+	}
+
+	switch {
+	case typ.Kind() == reflect.String:
+		return &Schema{Const: ""}
+	case typ.Kind() == reflect.Bool:
+		return &Schema{Const: false}
+	case isNumber(typ):
+		return &Schema{Const: 0}
+	}
+
+	return nil
+}
+
+// typeSchema returns the schema of the type, with the element or struct schemas
+func (g *Generator) typeSchema(typ reflect.Type, rules *validator.Rules) (*Schema, error) {
+
+	var dive *validator.Rules
+	var keys *validator.Rules
+
+	if rules != nil {
+		dive = rules.Dive
+		keys = rules.Keys
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}, nil
+
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &Schema{Type: "integer"}, nil
+
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}, nil
+
+	case reflect.Struct:
+
+		if typ == timeType {
+			return &Schema{Type: "string", Format: "date-time"}, nil
+		}
+
+		return g.structRef(typ)
+
+	case reflect.Slice, reflect.Array:
+
+		// encoded as base64 by encoding/json
+		if typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", ContentEncoding: "base64"}, nil
+		}
+
+		items, _, err := g.valueSchema(typ.Elem(), dive)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Schema{Type: "array", Items: items}, nil
+
+	case reflect.Map:
+
+		values, _, err := g.valueSchema(typ.Elem(), dive)
+		if err != nil {
+			return nil, err
+		}
+
+		s := &Schema{Type: "object", AdditionalProperties: values}
+
+		if keys != nil {
+
+			if s.PropertyNames, _, err = g.valueSchema(typ.Key(), keys); err != nil {
+				return nil, err
+			}
+
+			// property names are always strings
+			s.PropertyNames.Type = ""
+		}
+
+		return s, nil
+	}
+
+	// interfaces and anything else can be any value
+	return &Schema{}, nil
+}
+
+// apply applies the validation to s, returning false when it can't be converted
+func (g *Generator) apply(s *Schema, val validator.Validation, typ reflect.Type) bool {
+
+	fn, ok := g.tags[val.Tag]
+	if !ok {
+		return false
+	}
+
+	before := *s
+
+	// Extra may be added to in place
+	if s.Extra != nil {
+
+		before.Extra = make(map[string]interface{}, len(s.Extra))
+
+		for k, v := range s.Extra {
+			before.Extra[k] = v
+		}
+	}
+
+	fn(s, val.Param, typ)
+
+	return !reflect.DeepEqual(before, *s)
+}
@@ -0,0 +1,235 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"package/validator"
+
+	. "gopkg.in/go-playground/assert.v1"
+)
+
+type Base struct {
+	ID string `json:"id" validate:"required,uuid4"`
+}
+
+type Address struct {
+	Street string `json:"street" validate:"required,max=64"`
+	Zip    string `json:"zip" validate:"len=5,numeric"`
+}
+
+type Node struct {
+	Name     string  `validate:"required"`
+	Children []*Node `validate:"dive"`
+}
+
+type User struct {
+	Base
+	Name      string            `json:"name" validate:"required,min=1,max=32"`
+	Email     string            `json:"email,omitempty" validate:"omitempty,email"`
+	Age       int               `json:"age" validate:"gte=18,lt=150"`
+	Score     float64           `json:"score" validate:"gt=0"`
+	Role      string            `json:"role" validate:"oneof=admin user"`
+	Level     uint8             `json:"level" validate:"oneof=1 2 3"`
+	Tags      []string          `json:"tags" validate:"min=1,unique,dive,alpha"`
+	Labels    map[string]string `json:"labels" validate:"max=4,dive,keys,min=2,endkeys,required"`
+	Address   *Address          `json:"address" validate:"required"`
+	Addresses []Address         `json:"addresses" validate:"dive"`
+	Website   string            `json:"website" validate:"url|email"`
+	Color     string            `json:"color" validate:"iscolor"`
+	Created   time.Time         `json:"created"`
+	Data      []byte            `json:"data"`
+	Any       interface{}       `json:"any"`
+	Secret    string            `json:"-" validate:"required"`
+	Custom    string            `json:"custom" validate:"awesome"`
+	Tree      Node              `json:"tree"`
+}
+
+var nodeSchema = &Schema{
+	Type: "object",
+	Properties: map[string]*Schema{
+		"Name": {Type: "string", MinLength: intp(1)},
+		"Children": {AnyOf: []*Schema{
+			{Type: "array", Items: &Schema{AnyOf: []*Schema{{Ref: "#/$defs/Node"}, {Type: "null"}}}},
+			{Type: "null"},
+		}},
+	},
+	Required: []string{"Name"},
+}
+
+func intp(i int) *int {
+	return &i
+}
+
+func floatp(f float64) *float64 {
+	return &f
+}
+
+func TestGenerate(t *testing.T) {
+
+	validate := validator.New()
+
+	err := validate.RegisterValidation("awesome", func(fl validator.FieldLevel) bool {
+		return fl.Field().String() == "awesome"
+	})
+	Equal(t, err, nil)
+
+	g := New(validate)
+	g.RegisterTag("awesome", func(s *Schema, param string, typ reflect.Type) {
+		s.Const = "awesome"
+		s.Extra = map[string]interface{}{"x-awesome": true}
+	})
+
+	s, err := g.Generate(reflect.TypeOf(&User{}))
+	Equal(t, err, nil)
+	Equal(t, s.Schema, Draft)
+	Equal(t, s.Type, "object")
+	Equal(t, s.Required, []string{"id", "name", "address"})
+
+	_, ok := s.Properties["Secret"]
+	Equal(t, ok, false)
+
+	Equal(t, s.Properties["id"], &Schema{Type: "string", Format: "uuid", MinLength: intp(1)})
+	Equal(t, s.Properties["name"], &Schema{Type: "string", MinLength: intp(1), MaxLength: intp(32)})
+	Equal(t, s.Properties["email"], &Schema{AnyOf: []*Schema{{Type: "string", Format: "email"}, {Const: ""}}})
+	Equal(t, s.Properties["age"], &Schema{Type: "integer", Minimum: floatp(18), ExclusiveMaximum: floatp(150)})
+	Equal(t, s.Properties["score"], &Schema{Type: "number", ExclusiveMinimum: floatp(0)})
+	Equal(t, s.Properties["role"], &Schema{Type: "string", Enum: []interface{}{"admin", "user"}})
+	Equal(t, s.Properties["level"], &Schema{Type: "integer", Enum: []interface{}{uint64(1), uint64(2), uint64(3)}})
+	Equal(t, s.Properties["tags"], &Schema{AnyOf: []*Schema{
+		{
+			Type:        "array",
+			MinItems:    intp(1),
+			UniqueItems: true,
+			Items:       &Schema{Type: "string", Pattern: alphaPattern},
+		},
+		{Type: "null"},
+	}})
+	Equal(t, s.Properties["labels"], &Schema{AnyOf: []*Schema{
+		{
+			Type:                 "object",
+			MaxProperties:        intp(4),
+			AdditionalProperties: &Schema{Type: "string", MinLength: intp(1)},
+			PropertyNames:        &Schema{MinLength: intp(2)},
+		},
+		{Type: "null"},
+	}})
+	Equal(t, s.Properties["address"], &Schema{Ref: "#/$defs/Address"})
+	Equal(t, s.Properties["addresses"], &Schema{AnyOf: []*Schema{{Type: "array", Items: &Schema{Ref: "#/$defs/Address"}}, {Type: "null"}}})
+	Equal(t, s.Properties["website"], &Schema{Type: "string", AnyOf: []*Schema{{Format: "uri"}, {Format: "email"}}})
+	Equal(t, s.Properties["color"], &Schema{Type: "string"})
+	Equal(t, s.Properties["created"], &Schema{Type: "string", Format: "date-time"})
+	Equal(t, s.Properties["data"], &Schema{AnyOf: []*Schema{{Type: "string", ContentEncoding: "base64"}, {Type: "null"}}})
+	Equal(t, s.Properties["any"], &Schema{})
+	Equal(t, s.Properties["tree"], &Schema{Ref: "#/$defs/Node"})
+
+	Equal(t, len(s.Defs), 2)
+	Equal(t, s.Defs["Address"], &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"street": {Type: "string", MinLength: intp(1), MaxLength: intp(64)},
+			"zip":    {Type: "string", MinLength: intp(5), MaxLength: intp(5), Pattern: numericPattern},
+		},
+		Required: []string{"street"},
+	})
+	Equal(t, s.Defs["Node"], nodeSchema)
+
+	b, err := json.Marshal(s.Properties["custom"])
+	Equal(t, err, nil)
+	Equal(t, string(b), `{"const":"awesome","type":"string","x-awesome":true}`)
+
+	b, err = json.Marshal(s.Defs["Address"])
+	Equal(t, err, nil)
+	Equal(t, string(b), `{"type":"object","properties":{"street":{"type":"string","minLength":1,"maxLength":64},"zip":{"type":"string","pattern":"^[-+]?[0-9]+(?:\\.[0-9]+)?$","minLength":5,"maxLength":5}},"required":["street"]}`)
+}
+
+func TestGenerateRecursive(t *testing.T) {
+
+	s, err := New(validator.New()).Generate(reflect.TypeOf(Node{}))
+	Equal(t, err, nil)
+	Equal(t, s, &Schema{
+		Schema: Draft,
+		Ref:    "#/$defs/Node",
+		Defs:   map[string]*Schema{"Node": nodeSchema},
+	})
+}
+
+func TestGenerateEmpty(t *testing.T) {
+
+	type Test struct {
+		Name     *string        `json:"name" validate:"omitempty,min=2"`
+		Nickname *string        `json:"nickname" validate:"required"`
+		Age      int            `json:"age" validate:"omitempty,gte=18"`
+		Ratio    float64        `json:"ratio" validate:"omitempty,lt=1"`
+		Admin    bool           `json:"admin" validate:"omitempty,eq=true"`
+		Expires  time.Time      `json:"expires" validate:"omitempty,gt"`
+		Note     string         `json:"note" validate:"omitempty"`
+		Tags     []string       `json:"tags" validate:"required,max=4"`
+		Labels   map[string]int `json:"labels" validate:"required"`
+	}
+
+	s, err := New(validator.New()).Generate(reflect.TypeOf(Test{}))
+	Equal(t, err, nil)
+	Equal(t, s.Required, []string{"nickname", "tags", "labels"})
+
+	// the empty value of a pointer is null, a pointer to an empty value being required
+	Equal(t, s.Properties["name"], &Schema{AnyOf: []*Schema{{Type: "string", MinLength: intp(2)}, {Type: "null"}}})
+	Equal(t, s.Properties["nickname"], &Schema{Type: "string"})
+
+	Equal(t, s.Properties["age"], &Schema{AnyOf: []*Schema{{Type: "integer", Minimum: floatp(18)}, {Const: 0}}})
+	Equal(t, s.Properties["ratio"], &Schema{AnyOf: []*Schema{{Type: "number", ExclusiveMaximum: floatp(1)}, {Const: 0}}})
+	Equal(t, s.Properties["admin"], &Schema{AnyOf: []*Schema{{Type: "boolean", Const: true}, {Const: false}}})
+	Equal(t, s.Properties["expires"], &Schema{AnyOf: []*Schema{{Type: "string", Format: "date-time"}, {Const: "0001-01-01T00:00:00Z"}}})
+	Equal(t, s.Properties["note"], &Schema{Type: "string"})
+	Equal(t, s.Properties["tags"], &Schema{Type: "array", MinItems: intp(1), MaxItems: intp(4), Items: &Schema{Type: "string"}})
+	Equal(t, s.Properties["labels"], &Schema{Type: "object", MinProperties: intp(1), AdditionalProperties: &Schema{Type: "integer"}})
+
+	b, err := json.Marshal(s.Properties["age"])
+	Equal(t, err, nil)
+	Equal(t, string(b), `{"anyOf":[{"type":"integer","minimum":18},{"const":0}]}`)
+}
+
+func TestGenerateErrors(t *testing.T) {
+
+	g := New(validator.New())
+
+	_, err := g.Generate(reflect.TypeOf(""))
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "validator: (nil string)")
+
+	type Bad struct {
+		Name string `validate:"bad"`
+	}
+
+	_, err = g.Generate(reflect.TypeOf(Bad{}))
+	NotEqual(t, err, nil)
+
+	tagErr, ok := err.(*validator.InvalidTagError)
+	Equal(t, ok, true)
+	Equal(t, tagErr.Tag, "bad")
+}
+
+func TestGenerateFallback(t *testing.T) {
+
+	type Test struct {
+		Name  string `json:"name" validate:"max=8,eqfield=Other"`
+		Other string `json:"other" validate:"email|contains=@"`
+	}
+
+	var unconverted []string
+
+	g := New(validator.New())
+	g.RegisterFallback(func(s *Schema, vals []validator.Validation, typ reflect.Type) {
+		for _, val := range vals {
+			unconverted = append(unconverted, val.Tag)
+		}
+	})
+
+	s, err := g.Generate(reflect.TypeOf(Test{}))
+	Equal(t, err, nil)
+	Equal(t, s.Properties["name"], &Schema{Type: "string", MaxLength: intp(8)})
+	Equal(t, s.Properties["other"], &Schema{Type: "string"})
+	Equal(t, unconverted, []string{"eqfield", "email", "contains"})
+}
@@ -0,0 +1,220 @@
+package jsonschema
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// patterns of the validations matching a regular expression, as used by the validator
+const (
+	alphaPattern        = "^[a-zA-Z]+$"
+	alphaNumericPattern = "^[a-zA-Z0-9]+$"
+	numericPattern      = "^[-+]?[0-9]+(?:\\.[0-9]+)?$"
+	hexadecimalPattern  = "^[0-9a-fA-F]+$"
+)
+
+var bakedInTags = map[string]TagFunc{
+	"min":              bound(false, false),
+	"gte":              bound(false, false),
+	"gt":               bound(false, true),
+	"max":              bound(true, false),
+	"lte":              bound(true, false),
+	"lt":               bound(true, true),
+	"len":              length,
+	"eq":               equals,
+	"ne":               notEquals,
+	"oneof":            oneOf,
+	"unique":           unique,
+	"email":            format("email"),
+	"url":              format("uri"),
+	"uri":              format("uri"),
+	"uuid":             format("uuid"),
+	"uuid3":            format("uuid"),
+	"uuid4":            format("uuid"),
+	"uuid5":            format("uuid"),
+	"uuid_rfc4122":     format("uuid"),
+	"uuid3_rfc4122":    format("uuid"),
+	"uuid4_rfc4122":    format("uuid"),
+	"uuid5_rfc4122":    format("uuid"),
+	"ipv4":             format("ipv4"),
+	"ipv6":             format("ipv6"),
+	"hostname":         format("hostname"),
+	"hostname_rfc1123": format("hostname"),
+	"alpha":            pattern(alphaPattern),
+	"alphanum":         pattern(alphaNumericPattern),
+	"numeric":          pattern(numericPattern),
+	"hexadecimal":      pattern(hexadecimalPattern),
+}
+
+func isNumber(typ reflect.Type) bool {
+
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
+
+// bound returns the TagFunc of a lower or upper bound; the length of strings, slices,
+// arrays and maps, or the value of numbers. Params that aren't a number, such as those
+// of durations, can't be converted.
+func bound(upper bool, exclusive bool) TagFunc {
+
+	return func(s *Schema, param string, typ reflect.Type) {
+
+		if isNumber(typ) {
+
+			n, err := strconv.ParseFloat(param, 64)
+			if err != nil {
+				return
+			}
+
+			switch {
+			case upper && exclusive:
+				s.ExclusiveMaximum = &n
+			case upper:
+				s.Maximum = &n
+			case exclusive:
+				s.ExclusiveMinimum = &n
+			default:
+				s.Minimum = &n
+			}
+
+			return
+		}
+
+		n, err := strconv.Atoi(param)
+		if err != nil {
+			return
+		}
+
+		if exclusive {
+			if upper {
+				n--
+			} else {
+				n++
+			}
+		}
+
+		var min, max **int
+
+		switch typ.Kind() {
+		case reflect.String:
+			min, max = &s.MinLength, &s.MaxLength
+		case reflect.Slice, reflect.Array:
+			min, max = &s.MinItems, &s.MaxItems
+		case reflect.Map:
+			min, max = &s.MinProperties, &s.MaxProperties
+		default:
+			return
+		}
+
+		if upper {
+			*max = &n
+		} else {
+			*min = &n
+		}
+	}
+}
+
+func length(s *Schema, param string, typ reflect.Type) {
+	bound(false, false)(s, param, typ)
+	bound(true, false)(s, param, typ)
+}
+
+// value returns the param as a value of the kind of typ, and false when it can't be
+func value(param string, typ reflect.Type) (interface{}, bool) {
+
+	switch typ.Kind() {
+	case reflect.String:
+		return param, true
+
+	case reflect.Bool:
+		b, err := strconv.ParseBool(param)
+		return b, err == nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(param, 0, 64)
+		return n, err == nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(param, 0, 64)
+		return n, err == nil
+
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(param, 64)
+		return n, err == nil
+	}
+
+	return nil, false
+}
+
+func equals(s *Schema, param string, typ reflect.Type) {
+
+	switch typ.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		length(s, param, typ)
+		return
+	}
+
+	if val, ok := value(param, typ); ok {
+		s.Const = val
+	}
+}
+
+func notEquals(s *Schema, param string, typ reflect.Type) {
+
+	if val, ok := value(param, typ); ok {
+		s.Not = &Schema{Const: val}
+	}
+}
+
+func oneOf(s *Schema, param string, typ reflect.Type) {
+
+	vals := strings.Fields(param)
+	enum := make([]interface{}, 0, len(vals))
+
+	for _, v := range vals {
+
+		val, ok := value(v, typ)
+		if !ok {
+			return
+		}
+
+		enum = append(enum, val)
+	}
+
+	s.Enum = enum
+}
+
+func unique(s *Schema, param string, typ reflect.Type) {
+
+	switch typ.Kind() {
+	case reflect.Slice, reflect.Array:
+		s.UniqueItems = true
+	}
+}
+
+// format returns the TagFunc of a validation of strings equivalent to the format
+func format(f string) TagFunc {
+
+	return func(s *Schema, param string, typ reflect.Type) {
+		if typ.Kind() == reflect.String {
+			s.Format = f
+		}
+	}
+}
+
+// pattern returns the TagFunc of a validation of strings matching the regular expression
+func pattern(p string) TagFunc {
+
+	return func(s *Schema, param string, typ reflect.Type) {
+		if typ.Kind() == reflect.String {
+			s.Pattern = p
+		}
+	}
+}
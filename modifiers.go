@@ -0,0 +1,344 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	defaultModTagName = "mod"
+	defaultModifier   = "default"
+	undefinedModifier = "Undefined modifier '%s' on field '%s'"
+	invalidModifier   = "Invalid modifier tag on field '%s'"
+	badModifierKind   = "modifier '%s' can't be applied to kind %s"
+)
+
+// ModFunc modifies a field's value in place, before it is validated; field is always settable
+// and param is the modifier's param, if any. eg. for `mod:"trim=xy"` param is "xy".
+//
+// Pointers are dereferenced before calling a ModFunc, nil pointers being left alone.
+type ModFunc func(ctx context.Context, field reflect.Value, param string)
+
+// internalModifierWrapper holds a registered modifier along with the kinds it supports.
+type internalModifierWrapper struct {
+	fn          ModFunc
+	stringsOnly bool // only applies to strings, the kind is checked before calling fn
+	allocates   bool // allocates nil pointers, only the default modifier does
+}
+
+// cMod is a parsed modifier, modifiers are chained in the order they are applied.
+type cMod struct {
+	tag   string
+	param string
+	dive  bool // the following modifiers apply to the elements
+	internalModifierWrapper
+	next *cMod
+}
+
+var bakedInModifiers = map[string]internalModifierWrapper{
+	"trim":          {fn: trimMod, stringsOnly: true},
+	"ltrim":         {fn: ltrimMod, stringsOnly: true},
+	"rtrim":         {fn: rtrimMod, stringsOnly: true},
+	"lcase":         {fn: lcaseMod, stringsOnly: true},
+	"ucase":         {fn: ucaseMod, stringsOnly: true},
+	"title":         {fn: titleMod, stringsOnly: true},
+	"snake":         {fn: snakeMod, stringsOnly: true},
+	"camel":         {fn: camelMod, stringsOnly: true},
+	"nfc":           {fn: nfcMod, stringsOnly: true},
+	defaultModifier: {fn: defaultMod, allocates: true},
+}
+
+// parseModTag parses the modifiers of a field's mod tag
+func (v *Validate) parseModTag(tag string, fieldName string) (first *cMod, err *InvalidTagError) {
+
+	var current *cMod
+
+	for _, t := range strings.Split(tag, tagSeparator) {
+
+		mod := new(cMod)
+
+		if t == diveTag {
+			mod.tag = t
+			mod.dive = true
+		} else {
+
+			vals := strings.SplitN(t, tagKeySeparator, 2)
+
+			mod.tag = vals[0]
+			if len(mod.tag) == 0 {
+				return nil, &InvalidTagError{Tag: tag, Reason: fmt.Sprintf(invalidModifier, fieldName)}
+			}
+
+			wrapper, ok := v.modifiers[mod.tag]
+			if !ok {
+				return nil, &InvalidTagError{Tag: mod.tag, Reason: fmt.Sprintf(undefinedModifier, mod.tag, fieldName)}
+			}
+
+			mod.internalModifierWrapper = wrapper
+
+			if len(vals) > 1 {
+				mod.param = strings.Replace(strings.Replace(vals[1], utf8HexComma, ",", -1), utf8Pipe, "|", -1)
+			}
+		}
+
+		if current == nil {
+			first = mod
+		} else {
+			current.next = mod
+		}
+
+		current = mod
+	}
+
+	return
+}
+
+// modify applies the modifiers to the field, when it is settable
+func (v *validate) modify(ctx context.Context, field reflect.Value, ns []byte, cf *cField, mod *cMod) {
+
+	for ; mod != nil; mod = mod.next {
+
+		if v.defaultsOnly && !mod.dive && mod.tag != defaultModifier {
+			continue
+		}
+
+		current, ok := modifiable(field, mod.allocates)
+		if !ok {
+			continue
+		}
+
+		if mod.dive {
+
+			field = current
+
+			switch field.Kind() {
+			case reflect.Slice, reflect.Array:
+
+				for i := 0; i < field.Len(); i++ {
+					v.modify(ctx, field.Index(i), ns, cf, mod.next)
+				}
+
+			case reflect.Map:
+
+				elem := reflect.New(field.Type().Elem()).Elem()
+
+				for _, key := range field.MapKeys() {
+					elem.Set(field.MapIndex(key))
+					v.modify(ctx, elem, ns, cf, mod.next)
+					field.SetMapIndex(key, elem)
+				}
+
+			default:
+				v.invalidTag(&InvalidTagError{
+					Namespace: string(append(ns, cf.altName...)),
+					Tag:       mod.tag,
+					Reason:    "dive error! can't dive on a non slice or map",
+				})
+			}
+
+			return
+		}
+
+		if mod.stringsOnly && current.Kind() != reflect.String {
+			v.invalidTag(&InvalidTagError{
+				Namespace: string(append(ns, cf.altName...)),
+				Tag:       mod.tag,
+				Reason:    fmt.Sprintf(badModifierKind, mod.tag, current.Kind()),
+			})
+		}
+
+		if mod.tag == defaultModifier {
+			if err := setDefault(current, mod.param); err != nil {
+				v.invalidTag(&InvalidTagError{
+					Namespace: string(append(ns, cf.altName...)),
+					Tag:       mod.tag + tagKeySeparator + mod.param,
+					Reason:    err.Error(),
+				})
+			}
+			continue
+		}
+
+		mod.fn(ctx, current, mod.param)
+	}
+}
+
+// modifiable returns the settable value the field's pointers, if any, point to; nil pointers
+// are only allocated when alloc is true and interfaces are never modified.
+func modifiable(field reflect.Value, alloc bool) (reflect.Value, bool) {
+
+	if !field.CanSet() {
+		return field, false
+	}
+
+	for field.Kind() == reflect.Ptr {
+
+		if field.IsNil() {
+
+			if !alloc {
+				return field, false
+			}
+
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+
+		field = field.Elem()
+	}
+
+	return field, field.Kind() != reflect.Interface
+}
+
+func trimMod(ctx context.Context, field reflect.Value, param string) {
+
+	if len(param) == 0 {
+		field.SetString(strings.TrimSpace(field.String()))
+		return
+	}
+
+	field.SetString(strings.Trim(field.String(), param))
+}
+
+func ltrimMod(ctx context.Context, field reflect.Value, param string) {
+
+	if len(param) == 0 {
+		field.SetString(strings.TrimLeftFunc(field.String(), unicode.IsSpace))
+		return
+	}
+
+	field.SetString(strings.TrimLeft(field.String(), param))
+}
+
+func rtrimMod(ctx context.Context, field reflect.Value, param string) {
+
+	if len(param) == 0 {
+		field.SetString(strings.TrimRightFunc(field.String(), unicode.IsSpace))
+		return
+	}
+
+	field.SetString(strings.TrimRight(field.String(), param))
+}
+
+func lcaseMod(ctx context.Context, field reflect.Value, param string) {
+	field.SetString(strings.ToLower(field.String()))
+}
+
+func ucaseMod(ctx context.Context, field reflect.Value, param string) {
+	field.SetString(strings.ToUpper(field.String()))
+}
+
+// titleMod upper cases the first letter of each word, lower casing the rest
+func titleMod(ctx context.Context, field reflect.Value, param string) {
+
+	s := field.String()
+	b := strings.Builder{}
+	b.Grow(len(s))
+
+	start := true
+
+	for _, r := range s {
+
+		if unicode.IsSpace(r) {
+			start = true
+			b.WriteRune(r)
+			continue
+		}
+
+		if start {
+			b.WriteRune(unicode.ToTitle(r))
+			start = false
+			continue
+		}
+
+		b.WriteRune(unicode.ToLower(r))
+	}
+
+	field.SetString(b.String())
+}
+
+// snakeMod converts to snake case, eg. "HTTPServer name" becomes "http_server_name"
+func snakeMod(ctx context.Context, field reflect.Value, param string) {
+
+	words := splitWords(field.String())
+
+	for i := range words {
+		words[i] = strings.ToLower(words[i])
+	}
+
+	field.SetString(strings.Join(words, "_"))
+}
+
+// camelMod converts to camel case, eg. "http_server name" becomes "httpServerName"
+func camelMod(ctx context.Context, field reflect.Value, param string) {
+
+	words := splitWords(field.String())
+
+	for i := range words {
+
+		words[i] = strings.ToLower(words[i])
+
+		if i > 0 {
+			r, size := utf8.DecodeRuneInString(words[i])
+			words[i] = string(unicode.ToUpper(r)) + words[i][size:]
+		}
+	}
+
+	field.SetString(strings.Join(words, ""))
+}
+
+// nfcMod normalizes to Unicode normalization form C, eg. "e\u0301" becomes "\u00e9"
+func nfcMod(ctx context.Context, field reflect.Value, param string) {
+	field.SetString(norm.NFC.String(field.String()))
+}
+
+// defaultMod sets the field to param when it has it's zero value; it's applied by modify,
+// which reports a param that can't be parsed, but is registered so the tag is known.
+func defaultMod(ctx context.Context, field reflect.Value, param string) {
+	_ = setDefault(field, param)
+}
+
+// splitWords splits s into words, on anything other than letters and digits and where
+// the case changes, keeping acronyms together; eg. "HTTPServer-name" is "HTTP", "Server", "name".
+func splitWords(s string) []string {
+
+	var words []string
+	var word []rune
+
+	runes := []rune(s)
+
+	for i, r := range runes {
+
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+
+			if len(word) > 0 {
+				words = append(words, string(word))
+				word = word[0:0]
+			}
+
+			continue
+		}
+
+		if len(word) > 0 && unicode.IsUpper(r) {
+
+			prev := word[len(word)-1]
+
+			// "aB" or the "S" of "HTTPServer"
+			if !unicode.IsUpper(prev) || (i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				words = append(words, string(word))
+				word = word[0:0]
+			}
+		}
+
+		word = append(word, r)
+	}
+
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+
+	return words
+}
@@ -0,0 +1,162 @@
+/*
+Package openapi generates the components/schemas of OpenAPI 3.1 documents from the validations
+of struct types; so published specs keep in sync with the constraints enforced in Go.
+
+OpenAPI 3.1 schemas being JSON Schemas, the validations are converted as they are by the jsonschema
+package; those without an equivalent, such as cross field and custom validations, being emitted
+as a tag in the x-validate extension of the schema they apply to. eg.
+
+	type User struct {
+		Name     string `json:"name" validate:"required,max=64"`
+		Password string `json:"password" validate:"required,min=8"`
+		Confirm  string `json:"confirm" validate:"eqfield=Password"`
+	}
+
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		return strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+	})
+
+	components, err := openapi.New(validate).Generate(reflect.TypeOf(User{}))
+
+generates
+
+	{
+		"schemas": {
+			"User": {
+				"type": "object",
+				"properties": {
+					"confirm": {"type": "string", "x-validate": "eqfield=Password"},
+					"name": {"type": "string", "minLength": 1, "maxLength": 64},
+					"password": {"type": "string", "minLength": 8}
+				},
+				"required": ["name", "password"]
+			}
+		}
+	}
+
+Property names are those of the validator's TagNameFunc, so the names of the fields when none is
+registered. As with the jsonschema package the schemas of fields with omitempty are anyOf their
+constraints and the empty value, and those of pointers, slices and maps, unless required, anyOf
+their schema and null. Each struct type, including those nested, is a schema of the components, referenced using
+$ref.
+
+See the validator-openapi command for generating the components of a package's types.
+*/
+package openapi
+
+import (
+	"reflect"
+	"strings"
+
+	"package/validator"
+	"package/validator/jsonschema"
+)
+
+const (
+	// RefPrefix is the prefix of the $ref of the component schemas
+	RefPrefix = "#/components/schemas/"
+
+	// Extension is the extension keyword holding the validations without an OpenAPI equivalent
+	Extension = "x-validate"
+)
+
+// Components is the components object of an OpenAPI document
+type Components struct {
+	Schemas map[string]*jsonschema.Schema `json:"schemas"`
+}
+
+// Generator generates OpenAPI components from the validations of struct types
+type Generator struct {
+	g *jsonschema.Generator
+}
+
+// New returns a Generator using the validations parsed, and property names, of v
+func New(v *validator.Validate) *Generator {
+
+	g := jsonschema.New(v)
+	g.SetRefPrefix(RefPrefix)
+	g.UseFieldNames()
+	g.RegisterFallback(extension)
+
+	return &Generator{g: g}
+}
+
+// RegisterTag registers the TagFunc converting the validation tag, eg. a custom validation
+// registered using RegisterValidation, in place of it being emitted in x-validate.
+func (g *Generator) RegisterTag(tag string, fn jsonschema.TagFunc) {
+	g.g.RegisterTag(tag, fn)
+}
+
+// Generate returns the components having the schemas of the struct types, and of those nested within them.
+//
+// It returns validator.InvalidValidationError when a type is not a struct and a *validator.InvalidTagError
+// when a tag is invalid.
+func (g *Generator) Generate(types ...reflect.Type) (*Components, error) {
+
+	schemas, err := g.g.GenerateDefs(types...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Components{Schemas: schemas}, nil
+}
+
+// extension adds the validations to the x-validate extension of s, as they would be tagged
+func extension(s *jsonschema.Schema, vals []validator.Validation, typ reflect.Type) {
+
+	tag := validationTag(vals)
+
+	if s.Extra == nil {
+		s.Extra = make(map[string]interface{})
+	}
+
+	if prev, ok := s.Extra[Extension].(string); ok {
+
+		// the validations of an alias that can't be converted are emitted once
+		if prev == tag || strings.HasSuffix(prev, ","+tag) {
+			return
+		}
+
+		tag = prev + "," + tag
+	}
+
+	s.Extra[Extension] = tag
+}
+
+// validationTag returns the tag of the validations, or'ed together; the alias when they're all of the same
+func validationTag(vals []validator.Validation) string {
+
+	alias := vals[0].Alias
+
+	for _, val := range vals[1:] {
+		if val.Alias != alias {
+			alias = ""
+			break
+		}
+	}
+
+	if len(alias) > 0 {
+		return alias
+	}
+
+	tags := make([]string, len(vals))
+
+	for i, val := range vals {
+
+		tags[i] = val.Tag
+
+		if len(val.Param) > 0 {
+			tags[i] += "=" + escapeParam(val.Param)
+		}
+	}
+
+	return strings.Join(tags, "|")
+}
+
+var paramReplacer = strings.NewReplacer(",", "0x2C", "|", "0x7C")
+
+// escapeParam escapes the separators within the param, as they are within tags
+func escapeParam(param string) string {
+	return paramReplacer.Replace(param)
+}
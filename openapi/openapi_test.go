@@ -0,0 +1,139 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"package/validator"
+	"package/validator/jsonschema"
+
+	. "gopkg.in/go-playground/assert.v1"
+)
+
+type Address struct {
+	Street string `json:"street" validate:"required,max=64"`
+	Zip    string `json:"zip" validate:"required_with=Street,numeric"`
+}
+
+type User struct {
+	Name      string            `json:"name" validate:"required,max=64"`
+	Password  string            `json:"password" validate:"required,min=8"`
+	Confirm   string            `json:"confirm" validate:"eqfield=Password"`
+	Color     string            `json:"color" validate:"omitempty,iscolor"`
+	Website   string            `json:"website" validate:"url|contains=a0x2Cb"`
+	Age       int               `json:"age" validate:"gte=18,isdefault|gt=20"`
+	Address   Address           `json:"address"`
+	Addresses []*Address        `json:"addresses" validate:"dive"`
+	Labels    map[string]string `json:"labels" validate:"dive,keys,contains=x-,endkeys,awesome"`
+	Ignored   string            `json:"-" validate:"required"`
+	Untagged  string
+}
+
+func newValidate() *validator.Validate {
+
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		return strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+	})
+
+	return validate
+}
+
+func TestGenerate(t *testing.T) {
+
+	validate := newValidate()
+
+	err := validate.RegisterValidation("awesome", func(fl validator.FieldLevel) bool {
+		return fl.Field().String() == "awesome"
+	})
+	Equal(t, err, nil)
+
+	c, err := New(validate).Generate(reflect.TypeOf(User{}))
+	Equal(t, err, nil)
+	Equal(t, len(c.Schemas), 2)
+
+	user := c.Schemas["User"]
+	Equal(t, user.Schema, "")
+	Equal(t, user.Required, []string{"name", "password"})
+	Equal(t, len(user.Properties), 10)
+
+	_, ok := user.Properties["Ignored"]
+	Equal(t, ok, false)
+
+	Equal(t, user.Properties["Untagged"], &jsonschema.Schema{Type: "string"})
+	Equal(t, user.Properties["confirm"].Extra, map[string]interface{}{Extension: "eqfield=Password"})
+	Equal(t, user.Properties["color"].AnyOf[0].Extra, map[string]interface{}{Extension: "iscolor"})
+	Equal(t, user.Properties["website"].Extra, map[string]interface{}{Extension: "url|contains=a0x2Cb"})
+	Equal(t, user.Properties["age"].Extra, map[string]interface{}{Extension: "isdefault|gt=20"})
+	Equal(t, *user.Properties["age"].Minimum, float64(18))
+	Equal(t, user.Properties["address"], &jsonschema.Schema{Ref: "#/components/schemas/Address"})
+	Equal(t, user.Properties["addresses"].AnyOf[0].Items.AnyOf[0], &jsonschema.Schema{Ref: "#/components/schemas/Address"})
+	Equal(t, user.Properties["labels"].AnyOf[0].PropertyNames.Extra, map[string]interface{}{Extension: "contains=x-"})
+	Equal(t, user.Properties["labels"].AnyOf[0].AdditionalProperties.Extra, map[string]interface{}{Extension: "awesome"})
+
+	b, err := json.Marshal(c.Schemas["Address"])
+	Equal(t, err, nil)
+	Equal(t, string(b), `{"type":"object","properties":{"street":{"type":"string","minLength":1,"maxLength":64},"zip":{"pattern":"^[-+]?[0-9]+(?:\\.[0-9]+)?$","type":"string","x-validate":"required_with=Street"}},"required":["street"]}`)
+}
+
+func TestGenerateEmpty(t *testing.T) {
+
+	type Profile struct {
+		Bio      string   `json:"bio" validate:"omitempty,max=8,eqcsfield=Nickname"`
+		Nickname *string  `json:"nickname" validate:"omitempty,min=2"`
+		Manager  *Address `json:"manager"`
+		Owner    *Address `json:"owner" validate:"required"`
+	}
+
+	c, err := New(newValidate()).Generate(reflect.TypeOf(Profile{}))
+	Equal(t, err, nil)
+
+	profile := c.Schemas["Profile"]
+	Equal(t, profile.Required, []string{"owner"})
+
+	b, err := json.Marshal(profile.Properties["bio"])
+	Equal(t, err, nil)
+	Equal(t, string(b), `{"anyOf":[{"maxLength":8,"type":"string","x-validate":"eqcsfield=Nickname"},{"const":""}]}`)
+
+	b, err = json.Marshal(profile.Properties["nickname"])
+	Equal(t, err, nil)
+	Equal(t, string(b), `{"anyOf":[{"type":"string","minLength":2},{"type":"null"}]}`)
+
+	b, err = json.Marshal(profile.Properties["manager"])
+	Equal(t, err, nil)
+	Equal(t, string(b), `{"anyOf":[{"$ref":"#/components/schemas/Address"},{"type":"null"}]}`)
+
+	Equal(t, profile.Properties["owner"], &jsonschema.Schema{Ref: "#/components/schemas/Address"})
+}
+
+func TestGenerateRegisterTag(t *testing.T) {
+
+	validate := newValidate()
+
+	err := validate.RegisterValidation("awesome", func(fl validator.FieldLevel) bool {
+		return fl.Field().String() == "awesome"
+	})
+	Equal(t, err, nil)
+
+	type Test struct {
+		Name string `json:"name" validate:"awesome"`
+	}
+
+	g := New(validate)
+	g.RegisterTag("awesome", func(s *jsonschema.Schema, param string, typ reflect.Type) {
+		s.Const = "awesome"
+	})
+
+	c, err := g.Generate(reflect.TypeOf(Test{}))
+	Equal(t, err, nil)
+	Equal(t, c.Schemas["Test"].Properties["name"], &jsonschema.Schema{Type: "string", Const: "awesome"})
+}
+
+func TestGenerateErrors(t *testing.T) {
+
+	_, err := New(newValidate()).Generate(reflect.TypeOf(Address{}), reflect.TypeOf(1))
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "validator: (nil int)")
+}
@@ -0,0 +1,260 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// PathSegmentKind is the kind of a PathSegment
+type PathSegmentKind uint8
+
+// PathSegment kinds
+const (
+	FieldSegment PathSegmentKind = iota // a struct field
+	IndexSegment                        // an element of a slice or array
+	KeySegment                          // an element, or key, of a map
+)
+
+// PathSegment is a single step of a Path
+type PathSegment struct {
+	Kind       PathSegmentKind
+	Name       string        // FieldSegment only, the field's name with the tag name taking precedence
+	StructName string        // FieldSegment only, the field's actual name
+	Index      int           // IndexSegment only
+	Key        reflect.Value // KeySegment only, the map key
+}
+
+// Path is the path of a field within the value validated, from the top level
+// struct's fields, or the value itself when validating a variable, down to the field.
+//
+// Unlike a namespace the top level struct's name is not part of the Path; and the
+// segments are not ambiguous, eg. when a map key contains a '.' or ']'.
+type Path []PathSegment
+
+// String returns the Path in the dotted form used by namespaces, without the
+// top level struct's name.
+//
+// eg. "Addresses[0].Tags[foo]"
+func (p Path) String() string {
+
+	var b strings.Builder
+
+	for i, seg := range p {
+
+		switch seg.Kind {
+		case FieldSegment:
+
+			if i > 0 {
+				b.WriteByte('.')
+			}
+
+			b.WriteString(seg.Name)
+
+		case IndexSegment:
+			b.WriteByte('[')
+			b.WriteString(strconv.Itoa(seg.Index))
+			b.WriteByte(']')
+
+		case KeySegment:
+			b.WriteByte('[')
+			b.WriteString(seg.keyString())
+			b.WriteByte(']')
+		}
+	}
+
+	return b.String()
+}
+
+// JSONPointer returns the Path as an RFC 6901 JSON Pointer, an empty Path being
+// the whole document.
+//
+// eg. "/Addresses/0/Tags/foo"
+func (p Path) JSONPointer() string {
+
+	var b strings.Builder
+
+	for _, seg := range p {
+
+		b.WriteByte('/')
+
+		switch seg.Kind {
+		case FieldSegment:
+			b.WriteString(jsonPointerEscaper.Replace(seg.Name))
+
+		case IndexSegment:
+			b.WriteString(strconv.Itoa(seg.Index))
+
+		case KeySegment:
+			b.WriteString(jsonPointerEscaper.Replace(seg.keyString()))
+		}
+	}
+
+	return b.String()
+}
+
+// JSONPath returns the Path as a JSONPath expression, using the dot notation for
+// field names that allow it and the bracket notation otherwise.
+//
+// eg. "$.Addresses[0].Tags['foo']"
+func (p Path) JSONPath() string {
+
+	b := strings.Builder{}
+	b.WriteByte('$')
+
+	for _, seg := range p {
+
+		switch seg.Kind {
+		case FieldSegment:
+
+			if isJSONPathName(seg.Name) {
+				b.WriteByte('.')
+				b.WriteString(seg.Name)
+			} else {
+				b.WriteString("['")
+				b.WriteString(jsonPathEscaper.Replace(seg.Name))
+				b.WriteString("']")
+			}
+
+		case IndexSegment:
+			b.WriteByte('[')
+			b.WriteString(strconv.Itoa(seg.Index))
+			b.WriteByte(']')
+
+		case KeySegment:
+			b.WriteString("['")
+			b.WriteString(jsonPathEscaper.Replace(seg.keyString()))
+			b.WriteString("']")
+		}
+	}
+
+	return b.String()
+}
+
+// keyString returns the map key as formatted within namespaces
+func (seg PathSegment) keyString() string {
+
+	if !seg.Key.IsValid() || !seg.Key.CanInterface() {
+		return ""
+	}
+
+	return fmt.Sprintf("%v", seg.Key.Interface())
+}
+
+var (
+	jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+	jsonPathEscaper    = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+)
+
+// isJSONPathName returns if name can be used with the dot notation
+func isJSONPathName(name string) bool {
+
+	if len(name) == 0 {
+		return false
+	}
+
+	for i, r := range name {
+
+		switch {
+		case r == '_' || r == '$' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r > 0x7F:
+		case r >= '0' && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// parsePath returns the Path of the namespaces, as best it can, for FieldError's that were
+// not created while traversing the value; eg. those created by NewFieldError or unmarshaled.
+// Bracketed elements are IndexSegment's when a valid index, and KeySegment's holding
+// the string key otherwise.
+//
+// When skipTop is true the first element, the top level struct's name, is dropped.
+func parsePath(ns, structNs string, skipTop bool) Path {
+
+	names := splitNamespace(ns, skipTop)
+	structNames := splitNamespace(structNs, skipTop)
+
+	if len(structNames) != len(names) {
+		structNames = names
+	}
+
+	path := make(Path, len(names))
+
+	for i, name := range names {
+
+		if !name.bracketed {
+			path[i] = PathSegment{Kind: FieldSegment, Name: name.s, StructName: structNames[i].s}
+			continue
+		}
+
+		if idx, err := strconv.Atoi(name.s); err == nil && idx >= 0 && strconv.Itoa(idx) == name.s {
+			path[i] = PathSegment{Kind: IndexSegment, Index: idx}
+			continue
+		}
+
+		path[i] = PathSegment{Kind: KeySegment, Key: reflect.ValueOf(name.s)}
+	}
+
+	return path
+}
+
+// namespaceElem is a single element of a namespace
+type namespaceElem struct {
+	s         string
+	bracketed bool
+}
+
+// splitNamespace splits a namespace into it's field names and bracketed indexes or keys
+func splitNamespace(ns string, skipTop bool) []namespaceElem {
+
+	if skipTop {
+
+		idx := strings.IndexAny(ns, ".[")
+		if idx == -1 {
+			return nil
+		}
+
+		if ns[idx] == '.' {
+			idx++
+		}
+
+		ns = ns[idx:]
+	}
+
+	var elems []namespaceElem
+
+	for len(ns) > 0 {
+
+		if ns[0] == '[' {
+
+			end := strings.IndexByte(ns, ']')
+			if end == -1 {
+				elems = append(elems, namespaceElem{s: ns[1:], bracketed: true})
+				break
+			}
+
+			elems = append(elems, namespaceElem{s: ns[1:end], bracketed: true})
+			ns = ns[end+1:]
+
+		} else {
+
+			end := strings.IndexAny(ns, ".[")
+			if end == -1 {
+				end = len(ns)
+			}
+
+			elems = append(elems, namespaceElem{s: ns[:end]})
+			ns = ns[end:]
+		}
+
+		if len(ns) > 0 && ns[0] == '.' {
+			ns = ns[1:]
+		}
+	}
+
+	return elems
+}
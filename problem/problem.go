@@ -0,0 +1,137 @@
+/*
+Package problem renders ValidationErrors as RFC 7807 problem details documents,
+served with the application/problem+json content type.
+
+Each FieldError becomes an entry of the invalid-params extension, named by the
+JSON Pointer, RFC 6901, of the field within the validated value; so if the field
+names are those of the JSON, see RegisterTagNameFunc, the pointer references the
+offending field within the request body.
+
+	if err := validate.Struct(user); err != nil {
+
+		if errs, ok := err.(validator.ValidationErrors); ok {
+			problem.New(errs, trans).ServeHTTP(w, r)
+			return
+		}
+
+		...
+	}
+
+which writes:
+
+	HTTP/1.1 422 Unprocessable Entity
+	Content-Type: application/problem+json
+
+	{
+		"type": "about:blank",
+		"title": "Unprocessable Entity",
+		"status": 422,
+		"detail": "1 field failed validation",
+		"invalid-params": [
+			{
+				"name": "/addresses/0/zip",
+				"reason": "zip is a required field",
+				"tag": "required"
+			}
+		]
+	}
+*/
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	ut "package/universal-translator"
+	"package/validator"
+)
+
+// ContentType is the media type of problem details documents
+const ContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details document
+type Problem struct {
+	Type          string         `json:"type,omitempty"`
+	Title         string         `json:"title,omitempty"`
+	Status        int            `json:"status,omitempty"`
+	Detail        string         `json:"detail,omitempty"`
+	Instance      string         `json:"instance,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+}
+
+// InvalidParam describes a single field that failed validation
+type InvalidParam struct {
+	Name   string `json:"name"`            // JSON Pointer of the field
+	Reason string `json:"reason"`          // translated error, or the FieldError's Error() when not translated
+	Tag    string `json:"tag"`             // validation tag that failed
+	Param  string `json:"param,omitempty"` // param of the validation tag
+}
+
+// New returns a Problem, with a 422 Unprocessable Entity status, describing the ValidationErrors;
+// the reasons are translated using trans, when not nil.
+//
+// Type, Title, Detail and Instance can be changed as desired before writing the Problem.
+func New(errs validator.ValidationErrors, trans ut.Translator) *Problem {
+
+	p := &Problem{
+		Type:          "about:blank",
+		Title:         http.StatusText(http.StatusUnprocessableEntity),
+		Status:        http.StatusUnprocessableEntity,
+		InvalidParams: make([]InvalidParam, len(errs)),
+	}
+
+	if len(errs) == 1 {
+		p.Detail = "1 field failed validation"
+	} else {
+		p.Detail = strconv.Itoa(len(errs)) + " fields failed validation"
+	}
+
+	for i, fe := range errs {
+
+		param := InvalidParam{
+			Name:  Pointer(fe),
+			Tag:   fe.Tag(),
+			Param: fe.Param(),
+		}
+
+		if trans != nil {
+			param.Reason = fe.Translate(trans)
+		} else {
+			param.Reason = fe.(error).Error()
+		}
+
+		p.InvalidParams[i] = param
+	}
+
+	return p
+}
+
+// ServeHTTP writes the Problem as application/problem+json with the Problem's
+// status, or 422 Unprocessable Entity when not set.
+func (p *Problem) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	status := p.Status
+	if status == 0 {
+		status = http.StatusUnprocessableEntity
+	}
+
+	b, err := json.Marshal(p)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(status)
+	w.Write(b)
+}
+
+// Pointer returns the JSON Pointer of the FieldError's field within the validated
+// value, see FieldError.Path; struct fields, slice indexes and map keys each
+// become a reference token.
+//
+// eg. "User.addresses[0].zip" becomes "/addresses/0/zip"
+func Pointer(fe validator.FieldError) string {
+	return fe.Path().JSONPointer()
+}
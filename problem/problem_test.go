@@ -0,0 +1,160 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	english "package/locales/en"
+	ut "package/universal-translator"
+	"package/validator"
+	en_translations "package/validator/translations/en"
+
+	. "gopkg.in/go-playground/assert.v1"
+)
+
+type Address struct {
+	Zip string `json:"zip" validate:"required"`
+}
+
+type User struct {
+	Name      string            `json:"name" validate:"required"`
+	Age       int               `json:"age" validate:"gte=18"`
+	Addresses []Address         `json:"addresses" validate:"dive"`
+	Labels    map[string]string `json:"labels" validate:"dive,max=2"`
+}
+
+func newValidate() *validator.Validate {
+
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		return strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+	})
+
+	return validate
+}
+
+func TestNew(t *testing.T) {
+
+	validate := newValidate()
+
+	err := validate.Struct(User{Age: 17, Addresses: []Address{{Zip: "1"}, {}}, Labels: map[string]string{"a/b~c": "abc"}})
+	NotEqual(t, err, nil)
+
+	p := New(err.(validator.ValidationErrors), nil)
+	Equal(t, p.Type, "about:blank")
+	Equal(t, p.Title, "Unprocessable Entity")
+	Equal(t, p.Status, http.StatusUnprocessableEntity)
+	Equal(t, p.Detail, "4 fields failed validation")
+	Equal(t, len(p.InvalidParams), 4)
+
+	Equal(t, p.InvalidParams[0], InvalidParam{
+		Name:   "/name",
+		Reason: "Key: 'User.name' Error:Field validation for 'name' failed on the 'required' tag",
+		Tag:    "required",
+	})
+	Equal(t, p.InvalidParams[1].Name, "/age")
+	Equal(t, p.InvalidParams[1].Param, "18")
+	Equal(t, p.InvalidParams[2].Name, "/addresses/1/zip")
+	Equal(t, p.InvalidParams[3].Name, "/labels/a~1b~0c")
+
+	err = validate.Struct(User{Age: 18})
+	NotEqual(t, err, nil)
+
+	p = New(err.(validator.ValidationErrors), nil)
+	Equal(t, p.Detail, "1 field failed validation")
+
+	eng := english.New()
+	uni := ut.New(eng, eng)
+	trans, _ := uni.GetTranslator("en")
+
+	err = en_translations.RegisterDefaultTranslations(validate, trans)
+	Equal(t, err, nil)
+
+	err = validate.Struct(User{Age: 17})
+	NotEqual(t, err, nil)
+
+	p = New(err.(validator.ValidationErrors), trans)
+	Equal(t, p.InvalidParams[0].Reason, "name is a required field")
+	Equal(t, p.InvalidParams[1].Reason, "age must be 18 or greater")
+}
+
+func TestPointer(t *testing.T) {
+
+	validate := validator.New()
+
+	tests := []struct {
+		ns       string
+		expected string
+	}{
+		{ns: "", expected: ""},
+		{ns: "User", expected: ""},
+		{ns: "User.Name", expected: "/Name"},
+		{ns: "User.Addresses[0].Zip", expected: "/Addresses/0/Zip"},
+		{ns: "User.Matrix[1][2]", expected: "/Matrix/1/2"},
+		{ns: "User.Map[a.b][c]", expected: "/Map/a.b/c"},
+		{ns: "User.Map[~/]", expected: "/Map/~0~1"},
+		{ns: "[3]", expected: "/3"},
+		{ns: "[3].Name", expected: "/3/Name"},
+		{ns: "User.Map[unterminated", expected: "/Map/unterminated"},
+	}
+
+	for _, tt := range tests {
+		fe := validate.NewFieldError(validator.FieldErrorOptions{Namespace: tt.ns, Tag: "required"})
+		Equal(t, Pointer(fe), tt.expected)
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+
+	err := newValidate().Struct(User{Age: 18, Addresses: []Address{{}}})
+	NotEqual(t, err, nil)
+
+	p := New(err.(validator.ValidationErrors), nil)
+	p.Instance = "/users"
+
+	w := httptest.NewRecorder()
+	p.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/users", nil))
+
+	Equal(t, w.Code, http.StatusUnprocessableEntity)
+	Equal(t, w.Header().Get("Content-Type"), "application/problem+json")
+
+	var doc map[string]interface{}
+
+	err = json.Unmarshal(w.Body.Bytes(), &doc)
+	Equal(t, err, nil)
+	Equal(t, doc, map[string]interface{}{
+		"type":     "about:blank",
+		"title":    "Unprocessable Entity",
+		"status":   float64(422),
+		"detail":   "2 fields failed validation",
+		"instance": "/users",
+		"invalid-params": []interface{}{
+			map[string]interface{}{
+				"name":   "/name",
+				"reason": "Key: 'User.name' Error:Field validation for 'name' failed on the 'required' tag",
+				"tag":    "required",
+			},
+			map[string]interface{}{
+				"name":   "/addresses/0/zip",
+				"reason": "Key: 'User.addresses[0].zip' Error:Field validation for 'zip' failed on the 'required' tag",
+				"tag":    "required",
+			},
+		},
+	})
+
+	// status defaults to 422
+	w = httptest.NewRecorder()
+	(&Problem{Title: "Bad"}).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	Equal(t, w.Code, http.StatusUnprocessableEntity)
+	Equal(t, w.Body.String(), `{"title":"Bad"}`)
+
+	w = httptest.NewRecorder()
+	(&Problem{Status: http.StatusBadRequest}).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	Equal(t, w.Code, http.StatusBadRequest)
+}
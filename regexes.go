@@ -85,3 +85,45 @@ var (
 	hTMLEncodedRegex           = regexp.MustCompile(hTMLEncodedRegexString)
 	hTMLRegex                  = regexp.MustCompile(hTMLRegexString)
 )
+
+// regexTags are the baked in validations that match a regex against the field, see Limits.MaxRegexInput
+var regexTags = map[string]struct{}{
+	"alpha":            {},
+	"alphanum":         {},
+	"alphaunicode":     {},
+	"alphanumunicode":  {},
+	"numeric":          {},
+	"number":           {},
+	"hexadecimal":      {},
+	"hexcolor":         {},
+	"rgb":              {},
+	"rgba":             {},
+	"hsl":              {},
+	"hsla":             {},
+	"email":            {},
+	"base64":           {},
+	"base64url":        {},
+	"isbn":             {},
+	"isbn10":           {},
+	"isbn13":           {},
+	"eth_addr":         {},
+	"btc_addr":         {},
+	"btc_addr_bech32":  {},
+	"uuid":             {},
+	"uuid3":            {},
+	"uuid4":            {},
+	"uuid5":            {},
+	"ascii":            {},
+	"printascii":       {},
+	"multibyte":        {},
+	"datauri":          {},
+	"latitude":         {},
+	"longitude":        {},
+	"ssn":              {},
+	"hostname":         {},
+	"hostname_rfc1123": {},
+	"fqdn":             {},
+	"html":             {},
+	"html_encoded":     {},
+	"url_encoded":      {},
+}
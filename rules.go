@@ -0,0 +1,169 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+const (
+	unknownRuleField = "validator: %s has no exported field '%s'"
+	unknownRuleType  = "validator: rules for unknown type '%s'"
+	ambiguousRules   = "validator: rules for type '%s' match both %s and %s"
+)
+
+// RegisterStructRules registers the validation tags of the fields of a struct type, keyed by the
+// fields' names, in place of their struct tags; for types that can't have tags added, such as those
+// generated or of a third party. Fields without a rule keep the validation tag they have, if any,
+// a rule of "-" skipping the field.
+//
+//	err := validate.RegisterStructRules(pb.User{}, map[string]string{
+//		"Name":  "required,max=64",
+//		"Items": "dive,required",
+//	})
+//
+// It returns InvalidValidationError when t is not a struct, an error when a field doesn't exist and an
+// *InvalidTagError when a rule is invalid.
+//
+// NOTES:
+//   - registering rules for a type again replaces the rules previously registered, including
+//     when the type has already been validated.
+//   - this method is not thread-safe it is intended that these all be registered prior to any validation
+func (v *Validate) RegisterStructRules(t interface{}, rules map[string]string) error {
+
+	typ := reflect.TypeOf(t)
+
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ == nil || typ.Kind() != reflect.Struct || typ == timeType {
+		return &InvalidValidationError{Type: reflect.TypeOf(t)}
+	}
+
+	fieldRules := make(map[string]string, len(rules))
+
+	for name, rule := range rules {
+
+		fld, ok := typ.FieldByName(name)
+		if !ok || len(fld.Index) > 1 || (!fld.Anonymous && len(fld.PkgPath) > 0) {
+			return fmt.Errorf(unknownRuleField, typ, name)
+		}
+
+		if len(rule) > 0 && rule != skipValidationTag {
+			if _, _, err := v.parseFieldTagsRecursive(rule, name, "", false); err != nil {
+				err.Namespace = typ.Name() + namespaceSeparator + name
+				return err
+			}
+		}
+
+		fieldRules[name] = rule
+	}
+
+	if v.structRules == nil {
+		v.structRules = make(map[reflect.Type]map[string]string)
+	}
+
+	v.structRules[typ] = fieldRules
+
+	// the type is parsed again, with the rules, the next time it's validated
+	v.structCache.lock.Lock()
+	v.structCache.Delete(typ)
+	v.structCache.lock.Unlock()
+
+	return nil
+}
+
+// LoadStructRules registers the rules, see RegisterStructRules, of the types from data; a document
+// keyed by type name, either the type's name or qualified by it's package name, of the rules keyed
+// by field name:
+//
+//	{
+//		"User": {
+//			"Name": "required,max=64",
+//			"Items": "dive,required"
+//		}
+//	}
+//
+// data is decoded using unmarshal, json.Unmarshal when nil; so YAML can be loaded using a YAML library's
+// Unmarshal function, eg. validate.LoadStructRules(data, yaml.Unmarshal, User{}, Item{}). types are those
+// the rules can be for, rules for any other type returning an error.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation
+func (v *Validate) LoadStructRules(data []byte, unmarshal func([]byte, interface{}) error, types ...interface{}) error {
+
+	if unmarshal == nil {
+		unmarshal = json.Unmarshal
+	}
+
+	var doc map[string]map[string]string
+
+	if err := unmarshal(data, &doc); err != nil {
+		return err
+	}
+
+	byName := make(map[string]reflect.Type, len(types)*2)
+	ambiguous := make(map[string]string)
+
+	for _, t := range types {
+
+		typ := reflect.TypeOf(t)
+
+		for typ != nil && typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+
+		if typ == nil {
+			return &InvalidValidationError{}
+		}
+
+		for _, name := range []string{typ.Name(), typ.String()} {
+
+			if other, ok := byName[name]; ok && other != typ {
+				ambiguous[name] = fmt.Sprintf(ambiguousRules, name, other, typ)
+			}
+
+			byName[name] = typ
+		}
+	}
+
+	names := make([]string, 0, len(doc))
+
+	for name := range doc {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+
+		if msg, ok := ambiguous[name]; ok {
+			return errors.New(msg)
+		}
+
+		typ, ok := byName[name]
+		if !ok {
+			return fmt.Errorf(unknownRuleType, name)
+		}
+
+		if err := v.RegisterStructRules(reflect.Zero(typ).Interface(), doc[name]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fieldTag returns the field's validation tag, rules registered using RegisterStructRules taking precedence
+func (v *Validate) fieldTag(typ reflect.Type, fld reflect.StructField) string {
+
+	if rules, ok := v.structRules[typ]; ok {
+		if rule, ok := rules[fld.Name]; ok {
+			return rule
+		}
+	}
+
+	return fld.Tag.Get(v.tagName)
+}
@@ -19,6 +19,110 @@ func wrapStructLevelFunc(fn StructLevelFunc) StructLevelFuncCtx {
 	}
 }
 
+// Validatable is implemented by structs that validate themselves, it is called with the same
+// StructLevel a StructLevelFunc registered with RegisterStructValidation would be, after it.
+//
+// NOTE: a ValidateStruct shadowing that of an embedded struct, with the same receiver, isn't called;
+// see the package documentation on Struct Level Validation.
+type Validatable interface {
+	ValidateStruct(sl StructLevel)
+}
+
+// ValidatableCtx is the same as Validatable but also allows passing of contextual validation
+// information via context.Context; it takes precedence when a struct implements both.
+type ValidatableCtx interface {
+	ValidateStructCtx(ctx context.Context, sl StructLevel)
+}
+
+var (
+	validatableType    = reflect.TypeOf((*Validatable)(nil)).Elem()
+	validatableCtxType = reflect.TypeOf((*ValidatableCtx)(nil)).Elem()
+)
+
+// selfValidationFunc returns a StructLevelFuncCtx calling the ValidateStructCtx or ValidateStruct
+// method of typ, if it has either, otherwise nil.
+//
+// Methods promoted from an embedded struct are ignored, the embedded struct being validated, and
+// calling them, itself; unless it's of an unexported type, see inheritsMethod.
+func selfValidationFunc(typ reflect.Type) StructLevelFuncCtx {
+
+	ptrTyp := reflect.PtrTo(typ)
+
+	withCtx := ptrTyp.Implements(validatableCtxType) && !inheritsMethod(typ, "ValidateStructCtx")
+
+	if !withCtx && !(ptrTyp.Implements(validatableType) && !inheritsMethod(typ, "ValidateStruct")) {
+		return nil
+	}
+
+	return func(ctx context.Context, sl StructLevel) {
+
+		current := sl.Current()
+
+		// an embedded struct of an unexported type can't be passed to it's methods, they're
+		// called by the struct embedding it instead
+		if !current.CanInterface() {
+			return
+		}
+
+		// the method may have a pointer receiver, for which an addressable value is needed
+		if current.CanAddr() {
+			current = current.Addr()
+		} else {
+			ptr := reflect.New(typ)
+			ptr.Elem().Set(current)
+			current = ptr
+		}
+
+		if withCtx {
+			current.Interface().(ValidatableCtx).ValidateStructCtx(ctx, sl)
+		} else {
+			current.Interface().(Validatable).ValidateStruct(sl)
+		}
+	}
+}
+
+// inheritsMethod returns whether the method name of typ, or of a pointer to it, is promoted from an
+// embedded field of an exported type; such a field is validated, calling the method, itself. The
+// method of an embedded field of an unexported type can't be called that way, so isn't inherited.
+//
+// A method is told to be promoted by the method sets of the embedded fields having it, typ's own method
+// set only having it when one of the fields', as embedded, does. So a method typ declares shadowing an
+// embedded field's is only told apart when their receivers differ, eg. typ declaring it on a value and
+// the field on a pointer; otherwise it's taken to be inherited, and not called.
+func inheritsMethod(typ reflect.Type, name string) bool {
+
+	var fld reflect.StructField
+	var promoted, promotedToValue bool
+
+	for i := 0; i < typ.NumField(); i++ {
+
+		fld = typ.Field(i)
+
+		if !fld.Anonymous || len(fld.PkgPath) > 0 {
+			continue
+		}
+
+		if _, ok := fld.Type.MethodByName(name); ok {
+			promoted, promotedToValue = true, true
+			continue
+		}
+
+		if fld.Type.Kind() == reflect.Struct {
+			if _, ok := reflect.PtrTo(fld.Type).MethodByName(name); ok {
+				promoted = true
+			}
+		}
+	}
+
+	if !promoted {
+		return false
+	}
+
+	_, onValue := typ.MethodByName(name)
+
+	return onValue == promotedToValue
+}
+
 // StructLevel contains all the information and helper functions
 // to validate a struct
 type StructLevel interface {
@@ -108,6 +212,10 @@ func (v *validate) ExtractType(field reflect.Value) (reflect.Value, reflect.Kind
 // ReportError reports an error just by passing the field and tag information
 func (v *validate) ReportError(field interface{}, fieldName, structFieldName, tag, param string) {
 
+	if v.stop() {
+		return
+	}
+
 	fv, kind, _ := v.extractTypeInternal(reflect.ValueOf(field), false)
 
 	if len(structFieldName) == 0 {
@@ -122,6 +230,8 @@ func (v *validate) ReportError(field interface{}, fieldName, structFieldName, ta
 		v.str2 = v.str1
 	}
 
+	path := append(v.fieldPath(), parsePath(fieldName, structFieldName, false)...)
+
 	if kind == reflect.Invalid {
 
 		v.errs = append(v.errs,
@@ -133,6 +243,7 @@ func (v *validate) ReportError(field interface{}, fieldName, structFieldName, ta
 				structNs:       v.str2,
 				fieldLen:       uint8(len(fieldName)),
 				structfieldLen: uint8(len(structFieldName)),
+				path:           path,
 				param:          param,
 				kind:           kind,
 			},
@@ -149,6 +260,7 @@ func (v *validate) ReportError(field interface{}, fieldName, structFieldName, ta
 			structNs:       v.str2,
 			fieldLen:       uint8(len(fieldName)),
 			structfieldLen: uint8(len(structFieldName)),
+			path:           path,
 			value:          fv.Interface(),
 			param:          param,
 			kind:           kind,
@@ -166,9 +278,14 @@ func (v *validate) ReportValidationErrors(relativeNamespace, relativeStructNames
 
 	for i := 0; i < len(errs); i++ {
 
+		if v.stop() {
+			return
+		}
+
 		err = errs[i].(*fieldError)
 		err.ns = string(append(append(v.ns, relativeNamespace...), err.ns...))
 		err.structNs = string(append(append(v.actualNs, relativeStructNamespace...), err.structNs...))
+		err.path = nil // parsed from the new namespaces when requested
 
 		v.errs = append(v.errs, err)
 	}
@@ -29,6 +29,66 @@ func RegisterDefaultTranslations(v *validator.Validate, trans ut.Translator) (er
 			translation: "{0} is a required field",
 			override:    false,
 		},
+		{
+			tag:         "required_if",
+			translation: "{0} is a required field",
+			override:    false,
+		},
+		{
+			tag:         "required_unless",
+			translation: "{0} is a required field",
+			override:    false,
+		},
+		{
+			tag:         "required_with",
+			translation: "{0} is a required field",
+			override:    false,
+		},
+		{
+			tag:         "required_with_all",
+			translation: "{0} is a required field",
+			override:    false,
+		},
+		{
+			tag:         "required_without",
+			translation: "{0} is a required field",
+			override:    false,
+		},
+		{
+			tag:         "required_without_all",
+			translation: "{0} is a required field",
+			override:    false,
+		},
+		{
+			tag:         "excluded_if",
+			translation: "{0} must be empty",
+			override:    false,
+		},
+		{
+			tag:         "excluded_unless",
+			translation: "{0} must be empty",
+			override:    false,
+		},
+		{
+			tag:         "excluded_with",
+			translation: "{0} must be empty",
+			override:    false,
+		},
+		{
+			tag:         "excluded_with_all",
+			translation: "{0} must be empty",
+			override:    false,
+		},
+		{
+			tag:         "excluded_without",
+			translation: "{0} must be empty",
+			override:    false,
+		},
+		{
+			tag:         "excluded_without_all",
+			translation: "{0} must be empty",
+			override:    false,
+		},
 		{
 			tag: "len",
 			customRegisFunc: func(ut ut.Translator) (err error) {
@@ -993,6 +1053,21 @@ func RegisterDefaultTranslations(v *validator.Validate, trans ut.Translator) (er
 				return t
 			},
 		},
+		{
+			tag:         "expr",
+			translation: "{0} must satisfy {1}",
+			override:    false,
+			customTransFunc: func(ut ut.Translator, fe validator.FieldError) string {
+
+				t, err := ut.T(fe.Tag(), fe.Field(), fe.Param())
+				if err != nil {
+					log.Printf("warning: error translating FieldError: %#v", fe)
+					return fe.(error).Error()
+				}
+
+				return t
+			},
+		},
 		{
 			tag:         "alpha",
 			translation: "{0} can only contain alphabetic characters",
@@ -1311,6 +1386,114 @@ func RegisterDefaultTranslations(v *validator.Validate, trans ut.Translator) (er
 				return s
 			},
 		},
+		{
+			tag:         "jsonschema_type",
+			translation: "{0} must be of type {1}",
+			override:    false,
+			customTransFunc: func(ut ut.Translator, fe validator.FieldError) string {
+				s, err := ut.T(fe.Tag(), fe.Field(), fe.Param())
+				if err != nil {
+					log.Printf("warning: error translating FieldError: %#v", fe)
+					return fe.(error).Error()
+				}
+				return s
+			},
+		},
+		{
+			tag:         "jsonschema_pattern",
+			translation: "{0} must match the pattern {1}",
+			override:    false,
+			customTransFunc: func(ut ut.Translator, fe validator.FieldError) string {
+				s, err := ut.T(fe.Tag(), fe.Field(), fe.Param())
+				if err != nil {
+					log.Printf("warning: error translating FieldError: %#v", fe)
+					return fe.(error).Error()
+				}
+				return s
+			},
+		},
+		{
+			tag:         "jsonschema_format",
+			translation: "{0} must be a valid {1}",
+			override:    false,
+			customTransFunc: func(ut ut.Translator, fe validator.FieldError) string {
+				s, err := ut.T(fe.Tag(), fe.Field(), fe.Param())
+				if err != nil {
+					log.Printf("warning: error translating FieldError: %#v", fe)
+					return fe.(error).Error()
+				}
+				return s
+			},
+		},
+		{
+			tag:         "jsonschema_multipleof",
+			translation: "{0} must be a multiple of {1}",
+			override:    false,
+			customTransFunc: func(ut ut.Translator, fe validator.FieldError) string {
+				s, err := ut.T(fe.Tag(), fe.Field(), fe.Param())
+				if err != nil {
+					log.Printf("warning: error translating FieldError: %#v", fe)
+					return fe.(error).Error()
+				}
+				return s
+			},
+		},
+		{
+			tag:         "jsonschema_const",
+			translation: "{0} must be {1}",
+			override:    false,
+			customTransFunc: func(ut ut.Translator, fe validator.FieldError) string {
+				s, err := ut.T(fe.Tag(), fe.Field(), fe.Param())
+				if err != nil {
+					log.Printf("warning: error translating FieldError: %#v", fe)
+					return fe.(error).Error()
+				}
+				return s
+			},
+		},
+		{
+			tag:         "jsonschema_enum",
+			translation: "{0} must be one of {1}",
+			override:    false,
+			customTransFunc: func(ut ut.Translator, fe validator.FieldError) string {
+				s, err := ut.T(fe.Tag(), fe.Field(), fe.Param())
+				if err != nil {
+					log.Printf("warning: error translating FieldError: %#v", fe)
+					return fe.(error).Error()
+				}
+				return s
+			},
+		},
+		{
+			tag:         "jsonschema_uniqueitems",
+			translation: "{0} must contain unique items",
+			override:    false,
+		},
+		{
+			tag:         "jsonschema_additionalproperties",
+			translation: "{0} is not an allowed property",
+			override:    false,
+		},
+		{
+			tag:         "jsonschema_anyof",
+			translation: "{0} must match at least one of the allowed schemas",
+			override:    false,
+		},
+		{
+			tag:         "jsonschema_oneof",
+			translation: "{0} must match exactly one of the allowed schemas",
+			override:    false,
+		},
+		{
+			tag:         "jsonschema_not",
+			translation: "{0} must not match the disallowed schema",
+			override:    false,
+		},
+		{
+			tag:         "jsonschema_false",
+			translation: "{0} is not allowed",
+			override:    false,
+		},
 	}
 
 	for _, t := range translations {
@@ -35,6 +35,16 @@ func TestTranslations(t *testing.T) {
 		RequiredString    string    `validate:"required"`
 		RequiredNumber    int       `validate:"required"`
 		RequiredMultiple  []string  `validate:"required"`
+		RequiredIf        string    `validate:"required_if=MaxString 1234"`
+		RequiredUnless    string    `validate:"required_unless=MaxString 4321"`
+		RequiredWith      string    `validate:"required_with=MaxString"`
+		RequiredWithAll   string    `validate:"required_with_all=MaxString LtString"`
+		RequiredWithout   string    `validate:"required_without=EqString"`
+		ExcludedIf        string    `validate:"excluded_if=MaxString 1234"`
+		ExcludedUnless    string    `validate:"excluded_unless=MaxString 4321"`
+		ExcludedWith      string    `validate:"excluded_with=MaxString"`
+		ExcludedWithAll   string    `validate:"excluded_with_all=MaxString LtString"`
+		ExcludedWithout   string    `validate:"excluded_without=EqString"`
 		LenString         string    `validate:"len=1"`
 		LenNumber         float64   `validate:"len=1113.00"`
 		LenMultiple       []string  `validate:"len=7"`
@@ -78,6 +88,7 @@ func TestTranslations(t *testing.T) {
 		GteFieldString    string    `validate:"gtefield=MaxString"`
 		LtFieldString     string    `validate:"ltfield=MaxString"`
 		LteFieldString    string    `validate:"ltefield=MaxString"`
+		ExprString        string    `validate:"expr=len(ExprString) > 3"`
 		AlphaString       string    `validate:"alpha"`
 		AlphanumString    string    `validate:"alphanum"`
 		NumericString     string    `validate:"numeric"`
@@ -180,6 +191,12 @@ func TestTranslations(t *testing.T) {
 
 	test.MultiByte = "1234feerf"
 
+	test.ExcludedIf = "set"
+	test.ExcludedUnless = "set"
+	test.ExcludedWith = "set"
+	test.ExcludedWithAll = "set"
+	test.ExcludedWithout = "set"
+
 	s := "toolong"
 	test.StrPtrMaxLen = &s
 	test.StrPtrLen = &s
@@ -198,6 +215,46 @@ func TestTranslations(t *testing.T) {
 			ns:       "Test.IsColor",
 			expected: "IsColor must be a valid color",
 		},
+		{
+			ns:       "Test.ExcludedIf",
+			expected: "ExcludedIf must be empty",
+		},
+		{
+			ns:       "Test.ExcludedUnless",
+			expected: "ExcludedUnless must be empty",
+		},
+		{
+			ns:       "Test.ExcludedWith",
+			expected: "ExcludedWith must be empty",
+		},
+		{
+			ns:       "Test.ExcludedWithAll",
+			expected: "ExcludedWithAll must be empty",
+		},
+		{
+			ns:       "Test.ExcludedWithout",
+			expected: "ExcludedWithout must be empty",
+		},
+		{
+			ns:       "Test.RequiredIf",
+			expected: "RequiredIf is a required field",
+		},
+		{
+			ns:       "Test.RequiredUnless",
+			expected: "RequiredUnless is a required field",
+		},
+		{
+			ns:       "Test.RequiredWith",
+			expected: "RequiredWith is a required field",
+		},
+		{
+			ns:       "Test.RequiredWithAll",
+			expected: "RequiredWithAll is a required field",
+		},
+		{
+			ns:       "Test.RequiredWithout",
+			expected: "RequiredWithout is a required field",
+		},
 		{
 			ns:       "Test.MAC",
 			expected: "MAC must contain a valid MAC address",
@@ -402,6 +459,10 @@ func TestTranslations(t *testing.T) {
 			ns:       "Test.LteFieldString",
 			expected: "LteFieldString must be less than or equal to MaxString",
 		},
+		{
+			ns:       "Test.ExprString",
+			expected: "ExprString must satisfy len(ExprString) > 3",
+		},
 		{
 			ns:       "Test.GtFieldString",
 			expected: "GtFieldString must be greater than MaxString",
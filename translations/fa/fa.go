@@ -37,6 +37,78 @@ func RegisterDefaultTranslations(v *validator.Validate, trans ut.Translator) (er
 				return t
 			},
 		},
+		{
+			tag:             "required_if",
+			translation:     "{0} نمیتونه خالی باشه",
+			override:        false,
+			customTransFunc: translateFieldFunc,
+		},
+		{
+			tag:             "required_unless",
+			translation:     "{0} نمیتونه خالی باشه",
+			override:        false,
+			customTransFunc: translateFieldFunc,
+		},
+		{
+			tag:             "required_with",
+			translation:     "{0} نمیتونه خالی باشه",
+			override:        false,
+			customTransFunc: translateFieldFunc,
+		},
+		{
+			tag:             "required_with_all",
+			translation:     "{0} نمیتونه خالی باشه",
+			override:        false,
+			customTransFunc: translateFieldFunc,
+		},
+		{
+			tag:             "required_without",
+			translation:     "{0} نمیتونه خالی باشه",
+			override:        false,
+			customTransFunc: translateFieldFunc,
+		},
+		{
+			tag:             "required_without_all",
+			translation:     "{0} نمیتونه خالی باشه",
+			override:        false,
+			customTransFunc: translateFieldFunc,
+		},
+		{
+			tag:             "excluded_if",
+			translation:     "{0} باید خالی باشه",
+			override:        false,
+			customTransFunc: translateFieldFunc,
+		},
+		{
+			tag:             "excluded_unless",
+			translation:     "{0} باید خالی باشه",
+			override:        false,
+			customTransFunc: translateFieldFunc,
+		},
+		{
+			tag:             "excluded_with",
+			translation:     "{0} باید خالی باشه",
+			override:        false,
+			customTransFunc: translateFieldFunc,
+		},
+		{
+			tag:             "excluded_with_all",
+			translation:     "{0} باید خالی باشه",
+			override:        false,
+			customTransFunc: translateFieldFunc,
+		},
+		{
+			tag:             "excluded_without",
+			translation:     "{0} باید خالی باشه",
+			override:        false,
+			customTransFunc: translateFieldFunc,
+		},
+		{
+			tag:             "excluded_without_all",
+			translation:     "{0} باید خالی باشه",
+			override:        false,
+			customTransFunc: translateFieldFunc,
+		},
 		{
 			tag: "len",
 			customRegisFunc: func(ut ut.Translator) (err error) {
@@ -1099,6 +1171,24 @@ func RegisterDefaultTranslations(v *validator.Validate, trans ut.Translator) (er
 				return t
 			},
 		},
+		{
+			tag:         "expr",
+			translation: "{0} باید شرط {1} رو برآورده کنه",
+			override:    false,
+			customTransFunc: func(ut ut.Translator, fe validator.FieldError) string {
+				f1, _ := ut.T(fe.Field())
+				if f1 == "" {
+					f1 = fe.Field()
+				}
+				t, err := ut.T(fe.Tag(), f1, fe.Param())
+				if err != nil {
+					fmt.Printf("warning: error translating FieldError: %#v", fe)
+					return fe.(error).Error()
+				}
+
+				return t
+			},
+		},
 		{
 			tag:         "alpha",
 			translation: "{0} فقط میتونه شامل حروف باشه",
@@ -2033,6 +2123,114 @@ func RegisterDefaultTranslations(v *validator.Validate, trans ut.Translator) (er
 				return t
 			},
 		},
+		{
+			tag:         "jsonschema_type",
+			translation: "{0} باید از نوع {1} باشه",
+			override:    false,
+			customTransFunc: func(ut ut.Translator, fe validator.FieldError) string {
+				s, err := ut.T(fe.Tag(), fe.Field(), fe.Param())
+				if err != nil {
+					log.Printf("warning: error translating FieldError: %#v", fe)
+					return fe.(error).Error()
+				}
+				return s
+			},
+		},
+		{
+			tag:         "jsonschema_pattern",
+			translation: "{0} باید با الگوی {1} مطابقت داشته باشه",
+			override:    false,
+			customTransFunc: func(ut ut.Translator, fe validator.FieldError) string {
+				s, err := ut.T(fe.Tag(), fe.Field(), fe.Param())
+				if err != nil {
+					log.Printf("warning: error translating FieldError: %#v", fe)
+					return fe.(error).Error()
+				}
+				return s
+			},
+		},
+		{
+			tag:         "jsonschema_format",
+			translation: "{0} باید یک {1} معتبر باشه",
+			override:    false,
+			customTransFunc: func(ut ut.Translator, fe validator.FieldError) string {
+				s, err := ut.T(fe.Tag(), fe.Field(), fe.Param())
+				if err != nil {
+					log.Printf("warning: error translating FieldError: %#v", fe)
+					return fe.(error).Error()
+				}
+				return s
+			},
+		},
+		{
+			tag:         "jsonschema_multipleof",
+			translation: "{0} باید مضربی از {1} باشه",
+			override:    false,
+			customTransFunc: func(ut ut.Translator, fe validator.FieldError) string {
+				s, err := ut.T(fe.Tag(), fe.Field(), fe.Param())
+				if err != nil {
+					log.Printf("warning: error translating FieldError: %#v", fe)
+					return fe.(error).Error()
+				}
+				return s
+			},
+		},
+		{
+			tag:         "jsonschema_const",
+			translation: "{0} باید برابر {1} باشه",
+			override:    false,
+			customTransFunc: func(ut ut.Translator, fe validator.FieldError) string {
+				s, err := ut.T(fe.Tag(), fe.Field(), fe.Param())
+				if err != nil {
+					log.Printf("warning: error translating FieldError: %#v", fe)
+					return fe.(error).Error()
+				}
+				return s
+			},
+		},
+		{
+			tag:         "jsonschema_enum",
+			translation: "{0} باید یکی از {1} باشه",
+			override:    false,
+			customTransFunc: func(ut ut.Translator, fe validator.FieldError) string {
+				s, err := ut.T(fe.Tag(), fe.Field(), fe.Param())
+				if err != nil {
+					log.Printf("warning: error translating FieldError: %#v", fe)
+					return fe.(error).Error()
+				}
+				return s
+			},
+		},
+		{
+			tag:         "jsonschema_uniqueitems",
+			translation: "{0} باید آیتم‌های یکتا داشته باشه",
+			override:    false,
+		},
+		{
+			tag:         "jsonschema_additionalproperties",
+			translation: "{0} یک ویژگی مجاز نیست",
+			override:    false,
+		},
+		{
+			tag:         "jsonschema_anyof",
+			translation: "{0} باید حداقل با یکی از اسکیماهای مجاز مطابقت داشته باشه",
+			override:    false,
+		},
+		{
+			tag:         "jsonschema_oneof",
+			translation: "{0} باید دقیقا با یکی از اسکیماهای مجاز مطابقت داشته باشه",
+			override:    false,
+		},
+		{
+			tag:         "jsonschema_not",
+			translation: "{0} نباید با اسکیمای غیرمجاز مطابقت داشته باشه",
+			override:    false,
+		},
+		{
+			tag:         "jsonschema_false",
+			translation: "{0} مجاز نیست",
+			override:    false,
+		},
 	}
 
 	for _, t := range translations {
@@ -2075,6 +2273,24 @@ func registrationFunc(tag string, translation string, override bool) validator.R
 
 }
 
+// translateFieldFunc translates the FieldError the same as translateFunc, but with it's field name
+// translated when a translation of it has been added.
+func translateFieldFunc(ut ut.Translator, fe validator.FieldError) string {
+
+	fld, _ := ut.T(fe.Field())
+	if fld == "" {
+		fld = fe.Field()
+	}
+
+	t, err := ut.T(fe.Tag(), fld)
+	if err != nil {
+		log.Printf("warning: error translating FieldError: %#v", fe)
+		return fe.(error).Error()
+	}
+
+	return t
+}
+
 func translateFunc(ut ut.Translator, fe validator.FieldError) string {
 
 	t, err := ut.T(fe.Tag(), fe.Field())
@@ -0,0 +1,131 @@
+package fa
+
+import (
+	"testing"
+
+	persian "package/locales/fa"
+	ut "package/universal-translator"
+	"package/validator"
+
+	. "gopkg.in/go-playground/assert.v1"
+)
+
+func TestRequiredExcludedTranslations(t *testing.T) {
+
+	fa := persian.New()
+	uni := ut.New(fa, fa)
+	trans, _ := uni.GetTranslator("fa")
+
+	validate := validator.New()
+
+	err := RegisterDefaultTranslations(validate, trans)
+	Equal(t, err, nil)
+
+	type Test struct {
+		Trigger            string
+		Empty              string
+		Required           string `validate:"required"`
+		RequiredIf         string `validate:"required_if=Trigger set"`
+		RequiredUnless     string `validate:"required_unless=Trigger unset"`
+		RequiredWith       string `validate:"required_with=Trigger"`
+		RequiredWithAll    string `validate:"required_with_all=Trigger"`
+		RequiredWithout    string `validate:"required_without=Empty"`
+		RequiredWithoutAll string `validate:"required_without_all=Empty"`
+		ExcludedIf         string `validate:"excluded_if=Trigger set"`
+		ExcludedUnless     string `validate:"excluded_unless=Trigger unset"`
+		ExcludedWith       string `validate:"excluded_with=Trigger"`
+		ExcludedWithAll    string `validate:"excluded_with_all=Trigger"`
+		ExcludedWithout    string `validate:"excluded_without=Empty"`
+		ExcludedWithoutAll string `validate:"excluded_without_all=Empty"`
+	}
+
+	test := Test{
+		Trigger:            "set",
+		ExcludedIf:         "x",
+		ExcludedUnless:     "x",
+		ExcludedWith:       "x",
+		ExcludedWithAll:    "x",
+		ExcludedWithout:    "x",
+		ExcludedWithoutAll: "x",
+	}
+
+	err = validate.Struct(test)
+	NotEqual(t, err, nil)
+
+	errs, ok := err.(validator.ValidationErrors)
+	Equal(t, ok, true)
+
+	tests := []struct {
+		ns       string
+		expected string
+	}{
+		{ns: "Test.RequiredIf", expected: "RequiredIf نمیتونه خالی باشه"},
+		{ns: "Test.RequiredUnless", expected: "RequiredUnless نمیتونه خالی باشه"},
+		{ns: "Test.RequiredWith", expected: "RequiredWith نمیتونه خالی باشه"},
+		{ns: "Test.RequiredWithAll", expected: "RequiredWithAll نمیتونه خالی باشه"},
+		{ns: "Test.RequiredWithout", expected: "RequiredWithout نمیتونه خالی باشه"},
+		{ns: "Test.RequiredWithoutAll", expected: "RequiredWithoutAll نمیتونه خالی باشه"},
+		{ns: "Test.ExcludedIf", expected: "ExcludedIf باید خالی باشه"},
+		{ns: "Test.ExcludedUnless", expected: "ExcludedUnless باید خالی باشه"},
+		{ns: "Test.ExcludedWith", expected: "ExcludedWith باید خالی باشه"},
+		{ns: "Test.ExcludedWithAll", expected: "ExcludedWithAll باید خالی باشه"},
+		{ns: "Test.ExcludedWithout", expected: "ExcludedWithout باید خالی باشه"},
+		{ns: "Test.ExcludedWithoutAll", expected: "ExcludedWithoutAll باید خالی باشه"},
+	}
+
+	// and Required, having no translated field name, see below
+	Equal(t, len(errs), len(tests)+1)
+
+	for _, tt := range tests {
+
+		var fe validator.FieldError
+
+		for _, e := range errs {
+			if tt.ns == e.Namespace() {
+				fe = e
+				break
+			}
+		}
+
+		NotEqual(t, fe, nil)
+		Equal(t, tt.expected, fe.Translate(trans))
+	}
+
+	// a translated field name is used when added
+	err = trans.Add("Required", "نام", false)
+	Equal(t, err, nil)
+
+	err = validate.Struct(Test{})
+	NotEqual(t, err, nil)
+
+	for _, fe := range err.(validator.ValidationErrors) {
+		if fe.Field() == "Required" {
+			Equal(t, fe.Translate(trans), "نام نمیتونه خالی باشه")
+		}
+	}
+}
+
+func TestExprTranslation(t *testing.T) {
+
+	fa := persian.New()
+	uni := ut.New(fa, fa)
+	trans, _ := uni.GetTranslator("fa")
+
+	validate := validator.New()
+
+	err := RegisterDefaultTranslations(validate, trans)
+	Equal(t, err, nil)
+
+	type Test struct {
+		Start int
+		End   int `validate:"expr=End > Start"`
+	}
+
+	err = validate.Struct(Test{Start: 2, End: 1})
+	NotEqual(t, err, nil)
+
+	errs, ok := err.(validator.ValidationErrors)
+	Equal(t, ok, true)
+	Equal(t, len(errs), 1)
+	Equal(t, errs[0].Translate(trans), "End باید شرط End > Start رو برآورده کنه")
+}
@@ -2,6 +2,7 @@ package validator
 
 import (
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -53,14 +54,15 @@ BEGIN:
 }
 
 // getStructFieldOKInternal traverses a struct to retrieve a specific field denoted by the provided namespace and
-// returns the field, field kind and whether is was successful in retrieving the field at all.
+// returns the field, field kind, whether the field was reached through a pointer or interface and whether is was
+// successful in retrieving the field at all.
 //
 // NOTE: when not successful ok will be false, this can happen when a nested struct is nil and so the field
 // could not be retrieved because it didn't exist.
-func (v *validate) getStructFieldOKInternal(val reflect.Value, namespace string) (current reflect.Value, kind reflect.Kind, found bool) {
+func (v *validate) getStructFieldOKInternal(val reflect.Value, namespace string) (current reflect.Value, kind reflect.Kind, nullable bool, found bool) {
 
 BEGIN:
-	current, kind, _ = v.ExtractType(val)
+	current, kind, nullable = v.ExtractType(val)
 
 	if kind == reflect.Invalid {
 		return
@@ -112,7 +114,7 @@ BEGIN:
 		arrIdx, _ := strconv.Atoi(namespace[idx+1 : idx2])
 
 		if arrIdx >= current.Len() {
-			return current, kind, false
+			return current, kind, nullable, false
 		}
 
 		startIdx := idx2 + 1
@@ -128,6 +130,28 @@ BEGIN:
 		goto BEGIN
 
 	case reflect.Map:
+
+		// a string keyed map's key may also be named as a struct's field is, eg. the sibling keys of ValidateMap
+		if !strings.HasPrefix(namespace, leftBracket) && current.Type().Key().Kind() == reflect.String {
+
+			key := namespace
+			namespace = ""
+
+			if idx := strings.IndexAny(key, namespaceSeparator+leftBracket); idx != -1 {
+				namespace = strings.TrimPrefix(key[idx:], namespaceSeparator)
+				key = key[:idx]
+			}
+
+			val = current.MapIndex(reflect.ValueOf(key).Convert(current.Type().Key()))
+
+			// the value, and not the interface holding it, as a value of ValidateMap is validated
+			if val.Kind() == reflect.Interface && !val.IsNil() {
+				val = val.Elem()
+			}
+
+			goto BEGIN
+		}
+
 		idx := strings.Index(namespace, leftBracket) + 1
 		idx2 := strings.Index(namespace, rightBracket)
 
@@ -240,6 +264,16 @@ func asUint(param string) uint64 {
 	return i
 }
 
+// asBool returns the parameter as a bool
+// or panics if it can't convert
+func asBool(param string) bool {
+
+	i, err := strconv.ParseBool(param)
+	panicIf(err)
+
+	return i
+}
+
 // asFloat returns the parameter as a float64
 // or panics if it can't convert
 func asFloat(param string) float64 {
@@ -255,3 +289,81 @@ func panicIf(err error) {
 		panic(err.Error())
 	}
 }
+
+// extractGroupTags returns the values of the struct tags named name.<group>, eg. validate.create,
+// keyed by group; parsing the tag the same way reflect.StructTag.Lookup does.
+func extractGroupTags(tag reflect.StructTag, name string) (groups map[string]string) {
+
+	prefix := name + groupSeparator
+
+	for tag != "" {
+
+		i := 0
+		for i < len(tag) && tag[i] == ' ' {
+			i++
+		}
+
+		tag = tag[i:]
+		if tag == "" {
+			break
+		}
+
+		i = 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			break
+		}
+
+		key := string(tag[:i])
+		tag = tag[i+1:]
+
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+
+		if i >= len(tag) {
+			break
+		}
+
+		qvalue := string(tag[:i+1])
+		tag = tag[i+1:]
+
+		if !strings.HasPrefix(key, prefix) || len(key) == len(prefix) {
+			continue
+		}
+
+		value, err := strconv.Unquote(qvalue)
+		if err != nil {
+			break
+		}
+
+		if groups == nil {
+			groups = make(map[string]string)
+		}
+
+		groups[key[len(prefix):]] = value
+	}
+
+	return
+}
+
+// sortedKeys returns the keys of m in order.
+func sortedKeys(m map[string]string) []string {
+
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
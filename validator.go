@@ -5,8 +5,19 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
+	"time"
 )
 
+// checkInterval is the number of fields visited between checks of the context and deadline
+const checkInterval = 64
+
+// visitKey identifies a struct being validated
+type visitKey struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
 // per validate contruct
 type validate struct {
 	v              *Validate
@@ -16,15 +27,26 @@ type validate struct {
 	errs           ValidationErrors
 	includeExclude map[string]struct{} // reset only if StructPartial or StructExcept are called, no need otherwise
 	ffn            FilterFunc
-	slflParent     reflect.Value // StructLevel & FieldLevel
-	slCurrent      reflect.Value // StructLevel & FieldLevel
-	flField        reflect.Value // StructLevel & FieldLevel
-	cf             *cField       // StructLevel & FieldLevel
-	ct             *cTag         // StructLevel & FieldLevel
-	misc           []byte        // misc reusable
-	str1           string        // misc reusable
-	str2           string        // misc reusable
-	fldIsPointer   bool          // StructLevel & FieldLevel
+	slflParent     reflect.Value         // StructLevel & FieldLevel
+	slCurrent      reflect.Value         // StructLevel & FieldLevel
+	flField        reflect.Value         // StructLevel & FieldLevel
+	flNs           []byte                // FieldLevel, namespace the current field's name is appended to
+	cf             *cField               // StructLevel & FieldLevel
+	ct             *cTag                 // StructLevel & FieldLevel
+	misc           []byte                // misc reusable
+	str1           string                // misc reusable
+	str2           string                // misc reusable
+	groups         []string              // groups being validated, only set by StructGroups
+	path           Path                  // path of the field being traversed, segments are pushed and popped while traversing
+	maxErrs        int                   // maximum number of errors of the current validation, 0 using the Validate instance's
+	maxDepth       int                   // maximum depth of the current validation, 0 using the Validate instance's
+	depth          int                   // number of structs being validated, the current one and it's ancestors
+	visiting       map[visitKey]struct{} // structs being validated that may contain themselves, to detect cycles
+	abort          error                 // stops validation and is returned instead of the errors
+	fieldCount     int                   // number of fields visited, including elements dived into, see Limits
+	deadline       time.Time             // zero when there's no deadline, see Limits
+	fldIsPointer   bool                  // StructLevel & FieldLevel
+	defaultsOnly   bool                  // only the default modifier is applied, see SetDefaults
 	isPartial      bool
 	hasExcludes    bool
 }
@@ -32,10 +54,7 @@ type validate struct {
 // parent and current will be the same the first run of validateStruct
 func (v *validate) validateStruct(ctx context.Context, parent reflect.Value, current reflect.Value, typ reflect.Type, ns []byte, structNs []byte, ct *cTag) {
 
-	cs, ok := v.v.structCache.Get(typ)
-	if !ok {
-		cs = v.v.extractStructCache(current, typ.Name())
-	}
+	cs := v.cachedStruct(current, typ, ns)
 
 	if len(ns) == 0 && len(cs.name) != 0 {
 
@@ -46,43 +65,98 @@ func (v *validate) validateStruct(ctx context.Context, parent reflect.Value, cur
 		structNs = append(structNs, '.')
 	}
 
+	if max := v.maxDepthAllowed(); max > 0 && v.depth >= max {
+		v.abort = &MaxDepthError{Namespace: strings.TrimSuffix(string(ns), "."), MaxDepth: max}
+		return
+	}
+
+	// a struct that may contain itself, and is already being validated further up, is part of a cycle
+	var key visitKey
+
+	if cs.mayCycle && current.CanAddr() {
+
+		key = visitKey{ptr: current.UnsafeAddr(), typ: typ}
+
+		if _, ok := v.visiting[key]; ok {
+			return
+		}
+
+		if v.visiting == nil {
+			v.visiting = make(map[visitKey]struct{})
+		}
+
+		v.visiting[key] = struct{}{}
+	}
+
+	v.depth++
+
 	// ct is nil on top level struct, and structs as fields that have no tag info
 	// so if nil or if not nil and the structonly tag isn't present
 	if ct == nil || ct.typeof != typeStructOnly {
 
 		var f *cField
+		var fct *cTag
+
+		// fields are modified before any is validated, so cross field validations see the modified values
+		if cs.hasMods {
+
+			// not validating a field, so a panic within a modifier must not be reported as an invalid tag
+			v.ct = nil
+
+			for i := 0; i < len(cs.fields); i++ {
+
+				f = cs.fields[i]
+
+				if f.mods != nil && !v.excludedByPartial(structNs, f) {
+					v.modify(ctx, current.Field(f.idx), ns, f, f.mods)
+				}
+			}
+		}
 
 		for i := 0; i < len(cs.fields); i++ {
 
+			if v.stop() {
+				break
+			}
+
 			f = cs.fields[i]
+			fct = f.cTags
 
-			if v.isPartial {
+			if len(v.groups) > 0 && f.groups != nil {
 
-				if v.ffn != nil {
-					// used with StructFiltered
-					if v.ffn(append(structNs, f.name...)) {
+				if gct, found := f.groupCTags(v.groups); found {
+
+					if gct == nil {
 						continue
 					}
 
-				} else {
-					// used with StructPartial & StructExcept
-					_, ok = v.includeExclude[string(append(structNs, f.name...))]
+					fct = gct
 
-					if (ok && v.hasExcludes) || (!ok && !v.hasExcludes) {
-						continue
-					}
+				} else if f.skip {
+					continue
 				}
+
+			} else if f.skip {
+				continue
 			}
 
-			v.traverseField(ctx, parent, current.Field(f.idx), ns, structNs, f, f.cTags)
+			if v.excludedByPartial(structNs, f) {
+				continue
+			}
+
+			v.path = append(v.path, PathSegment{Kind: FieldSegment, Name: f.altName, StructName: f.name})
+			v.traverseField(ctx, parent, current.Field(f.idx), ns, structNs, f, fct)
+			v.path = v.path[:len(v.path)-1]
 		}
 	}
 
 	// check if any struct level validations, after all field validations already checked.
 	// first iteration will have no info about nostructlevel tag, and is checked prior to
 	// calling the next iteration of validateStruct called from traverseField.
-	if cs.fn != nil {
+	if cs.fn != nil && !v.stop() {
 
+		// not validating a field, so a panic within the struct level func must not be reported as an invalid tag
+		v.ct = nil
 		v.slflParent = parent
 		v.slCurrent = current
 		v.ns = ns
@@ -90,6 +164,50 @@ func (v *validate) validateStruct(ctx context.Context, parent reflect.Value, cur
 
 		cs.fn(ctx, v)
 	}
+
+	v.depth--
+
+	if key.typ != nil {
+		delete(v.visiting, key)
+	}
+}
+
+// cachedStruct returns the struct's cache, extracting it the first time the type is seen
+func (v *validate) cachedStruct(current reflect.Value, typ reflect.Type, ns []byte) *cStruct {
+
+	cs, ok := v.v.structCache.Get(typ)
+	if !ok {
+		var err error
+		if cs, err = v.v.extractStructCache(current, typ.Name()); err != nil {
+			tagErr := err.(*InvalidTagError)
+			if len(ns) == 0 && len(typ.Name()) != 0 {
+				tagErr.Namespace = typ.Name() + namespaceSeparator + tagErr.Namespace
+			} else {
+				tagErr.Namespace = string(ns) + tagErr.Namespace
+			}
+			v.invalidTag(tagErr)
+		}
+	}
+
+	return cs
+}
+
+// excludedByPartial returns if the field is excluded by StructFiltered, StructPartial or StructExcept
+func (v *validate) excludedByPartial(structNs []byte, f *cField) bool {
+
+	if !v.isPartial {
+		return false
+	}
+
+	if v.ffn != nil {
+		// used with StructFiltered
+		return v.ffn(append(structNs, f.name...))
+	}
+
+	// used with StructPartial & StructExcept
+	_, ok := v.includeExclude[string(append(structNs, f.name...))]
+
+	return (ok && v.hasExcludes) || (!ok && !v.hasExcludes)
 }
 
 // traverseField validates any field, be it a struct or single field, ensures it's validity and passes it along to be validated via it's tag options
@@ -98,6 +216,19 @@ func (v *validate) traverseField(ctx context.Context, parent reflect.Value, curr
 	var typ reflect.Type
 	var kind reflect.Kind
 
+	v.fieldCount++
+
+	// not validating yet, so a panic within a custom type func must not be reported as an invalid tag
+	v.ct = nil
+
+	if v.v.hasLimits && v.exceedsLimits(ns, cf) {
+		return
+	}
+
+	if v.fieldCount%checkInterval == 1 && v.contextDone(ctx, ns, cf) {
+		return
+	}
+
 	current, kind, v.fldIsPointer = v.extractTypeInternal(current, false)
 
 	switch kind {
@@ -131,6 +262,7 @@ func (v *validate) traverseField(ctx context.Context, parent reflect.Value, curr
 						structNs:       v.str2,
 						fieldLen:       uint8(len(cf.altName)),
 						structfieldLen: uint8(len(cf.name)),
+						path:           v.fieldPath(),
 						param:          ct.param,
 						kind:           kind,
 					},
@@ -139,23 +271,26 @@ func (v *validate) traverseField(ctx context.Context, parent reflect.Value, curr
 				return
 			}
 
-			v.errs = append(v.errs,
-				&fieldError{
-					v:              v.v,
-					tag:            ct.aliasTag,
-					actualTag:      ct.tag,
-					ns:             v.str1,
-					structNs:       v.str2,
-					fieldLen:       uint8(len(cf.altName)),
-					structfieldLen: uint8(len(cf.name)),
-					value:          current.Interface(),
-					param:          ct.param,
-					kind:           kind,
-					typ:            current.Type(),
-				},
-			)
+			if !ct.runValidationWhenNil {
+				v.errs = append(v.errs,
+					&fieldError{
+						v:              v.v,
+						tag:            ct.aliasTag,
+						actualTag:      ct.tag,
+						ns:             v.str1,
+						structNs:       v.str2,
+						fieldLen:       uint8(len(cf.altName)),
+						structfieldLen: uint8(len(cf.name)),
+						path:           v.fieldPath(),
+						value:          current.Interface(),
+						param:          ct.param,
+						kind:           kind,
+						typ:            current.Type(),
+					},
+				)
 
-			return
+				return
+			}
 		}
 
 	case reflect.Struct:
@@ -172,6 +307,7 @@ func (v *validate) traverseField(ctx context.Context, parent reflect.Value, curr
 					// set Field Level fields
 					v.slflParent = parent
 					v.flField = current
+					v.flNs = ns
 					v.cf = cf
 					v.ct = ct
 
@@ -193,6 +329,7 @@ func (v *validate) traverseField(ctx context.Context, parent reflect.Value, curr
 								structNs:       v.str2,
 								fieldLen:       uint8(len(cf.altName)),
 								structfieldLen: uint8(len(cf.name)),
+								path:           v.fieldPath(),
 								value:          current.Interface(),
 								param:          ct.param,
 								kind:           kind,
@@ -231,12 +368,26 @@ func (v *validate) traverseField(ctx context.Context, parent reflect.Value, curr
 
 	typ = current.Type()
 
+	// set once a conditional tag, eg. required_with, passes on an empty value, see conditionalTags
+	var notRequired bool
+
 OUTER:
 	for {
 		if ct == nil {
 			return
 		}
 
+		if notRequired && !ct.runValidationWhenNil && ct.tag != requiredTag {
+
+			// the tags after a dive are those of the elements
+			if ct.typeof == typeDive {
+				return
+			}
+
+			ct = ct.next
+			continue
+		}
+
 		switch ct.typeof {
 
 		case typeOmitEmpty:
@@ -244,10 +395,13 @@ OUTER:
 			// set Field Level fields
 			v.slflParent = parent
 			v.flField = current
+			v.flNs = ns
 			v.cf = cf
 			v.ct = ct
 
-			if !v.fldIsPointer && !hasValue(v) {
+			// a nil pointer or interface only gets this far when a preceding
+			// validation, such as required_with, runs even when the value is nil
+			if kind == reflect.Ptr || kind == reflect.Interface || (!v.fldIsPointer && !hasValue(v)) {
 				return
 			}
 
@@ -259,10 +413,16 @@ OUTER:
 
 		case typeDive:
 
+			diveCt := ct
 			ct = ct.next
 
 			// traverse slice or map here
 			// or panic ;)
+			if max := v.v.limits.MaxDiveElements; max > 0 && (kind == reflect.Slice || kind == reflect.Array || kind == reflect.Map) && current.Len() > max {
+				v.abort = &LimitError{Limit: LimitDiveElements, Namespace: string(append(ns, cf.altName...))}
+				return
+			}
+
 			switch kind {
 			case reflect.Slice, reflect.Array:
 
@@ -271,6 +431,10 @@ OUTER:
 
 				for i := 0; i < current.Len(); i++ {
 
+					if v.stop() {
+						return
+					}
+
 					i64 = int64(i)
 
 					v.misc = append(v.misc[0:0], cf.name...)
@@ -291,7 +455,9 @@ OUTER:
 
 						reusableCF.altName = string(v.misc)
 					}
+					v.path = append(v.path, PathSegment{Kind: IndexSegment, Index: i})
 					v.traverseField(ctx, parent, current.Index(i), ns, structNs, reusableCF, ct)
+					v.path = v.path[:len(v.path)-1]
 				}
 
 			case reflect.Map:
@@ -301,6 +467,10 @@ OUTER:
 
 				for _, key := range current.MapKeys() {
 
+					if v.stop() {
+						return
+					}
+
 					pv = fmt.Sprintf("%v", key.Interface())
 
 					v.misc = append(v.misc[0:0], cf.name...)
@@ -321,21 +491,29 @@ OUTER:
 						reusableCF.altName = string(v.misc)
 					}
 
+					v.path = append(v.path, PathSegment{Kind: KeySegment, Key: key})
+
 					if ct != nil && ct.typeof == typeKeys && ct.keys != nil {
 						v.traverseField(ctx, parent, key, ns, structNs, reusableCF, ct.keys)
 						// can be nil when just keys being validated
-						if ct.next != nil {
+						if ct.next != nil && !v.stop() {
 							v.traverseField(ctx, parent, current.MapIndex(key), ns, structNs, reusableCF, ct.next)
 						}
 					} else {
 						v.traverseField(ctx, parent, current.MapIndex(key), ns, structNs, reusableCF, ct)
 					}
+
+					v.path = v.path[:len(v.path)-1]
 				}
 
 			default:
 				// throw error, if not a slice or map then should not have gotten here
 				// bad dive tag
-				panic("dive error! can't dive on a non slice or map")
+				v.invalidTag(&InvalidTagError{
+					Namespace: string(append(ns, cf.altName...)),
+					Tag:       diveCt.aliasTag,
+					Reason:    "dive error! can't dive on a non slice or map",
+				})
 			}
 
 			return
@@ -346,9 +524,14 @@ OUTER:
 
 			for {
 
+				if ct.usesRegex && v.exceedsRegexInput(current, ns, cf) {
+					return
+				}
+
 				// set Field Level fields
 				v.slflParent = parent
 				v.flField = current
+				v.flNs = ns
 				v.cf = cf
 				v.ct = ct
 
@@ -398,6 +581,7 @@ OUTER:
 								structNs:       v.str2,
 								fieldLen:       uint8(len(cf.altName)),
 								structfieldLen: uint8(len(cf.name)),
+								path:           v.fieldPath(),
 								value:          current.Interface(),
 								param:          ct.param,
 								kind:           kind,
@@ -418,6 +602,7 @@ OUTER:
 								structNs:       v.str2,
 								fieldLen:       uint8(len(cf.altName)),
 								structfieldLen: uint8(len(cf.name)),
+								path:           v.fieldPath(),
 								value:          current.Interface(),
 								param:          ct.param,
 								kind:           kind,
@@ -434,13 +619,20 @@ OUTER:
 
 		default:
 
+			if ct.usesRegex && v.exceedsRegexInput(current, ns, cf) {
+				return
+			}
+
 			// set Field Level fields
 			v.slflParent = parent
 			v.flField = current
+			v.flNs = ns
 			v.cf = cf
 			v.ct = ct
 
-			if !ct.fn(ctx, v) {
+			// a nil pointer or interface only gets here after a tag running on nil, eg. expr, so fails
+			// the tags that don't as it would had they been first
+			if ((kind == reflect.Ptr || kind == reflect.Interface) && !ct.runValidationWhenNil) || !ct.fn(ctx, v) {
 
 				v.str1 = string(append(ns, cf.altName...))
 
@@ -459,6 +651,7 @@ OUTER:
 						structNs:       v.str2,
 						fieldLen:       uint8(len(cf.altName)),
 						structfieldLen: uint8(len(cf.name)),
+						path:           v.fieldPath(),
 						value:          current.Interface(),
 						param:          ct.param,
 						kind:           kind,
@@ -468,8 +661,174 @@ OUTER:
 
 				return
 			}
+
+			if ct.isConditional && !notRequired {
+				notRequired = !hasValue(v)
+			}
+
 			ct = ct.next
 		}
 	}
 
 }
+
+// setOptions sets the options of the current validation
+func (v *validate) setOptions(opts ValidateOptions) {
+
+	v.maxErrs = opts.MaxErrors
+	v.maxDepth = opts.MaxDepth
+
+	if opts.FailFast {
+		v.maxErrs = 1
+	}
+}
+
+// startLimits resets the count of fields visited, and sets the deadline, of the Limits for a new validation
+func (v *validate) startLimits(ctx context.Context) {
+
+	v.fieldCount = 0
+	v.deadline = time.Time{}
+
+	if !v.v.hasLimits {
+		return
+	}
+
+	if v.v.limits.Timeout > 0 {
+		v.deadline = time.Now().Add(v.v.limits.Timeout)
+	}
+
+	if d, ok := ctx.Deadline(); ok && (v.deadline.IsZero() || d.Before(v.deadline)) {
+		v.deadline = d
+	}
+}
+
+// exceedsLimits returns if visiting another field exceeds the Limits, aborting validation if so; the deadline
+// is only checked every so many fields, as getting the time isn't free.
+func (v *validate) exceedsLimits(ns []byte, cf *cField) bool {
+
+	if max := v.v.limits.MaxFields; max > 0 && v.fieldCount > max {
+		v.abort = &LimitError{Limit: LimitFields, Namespace: string(append(ns, cf.altName...))}
+		return true
+	}
+
+	if !v.deadline.IsZero() && v.fieldCount%checkInterval == 1 && time.Now().After(v.deadline) {
+		v.abort = &LimitError{Limit: LimitDeadline, Namespace: string(append(ns, cf.altName...))}
+		return true
+	}
+
+	return false
+}
+
+// contextDone returns if the context is done, eg. the client disconnected, aborting validation if so.
+func (v *validate) contextDone(ctx context.Context, ns []byte, cf *cField) bool {
+
+	if ctx == nil {
+		return false
+	}
+
+	if err := ctx.Err(); err != nil {
+		v.abort = &ContextError{Namespace: string(append(ns, cf.altName...)), Err: err}
+		return true
+	}
+
+	return false
+}
+
+// exceedsRegexInput returns if the field is a string too long to match a regex against, aborting validation if so.
+func (v *validate) exceedsRegexInput(current reflect.Value, ns []byte, cf *cField) bool {
+
+	if max := v.v.limits.MaxRegexInput; max > 0 && current.Kind() == reflect.String && current.Len() > max {
+		v.abort = &LimitError{Limit: LimitRegexInput, Namespace: string(append(ns, cf.altName...))}
+		return true
+	}
+
+	return false
+}
+
+// maxDepthAllowed returns the maximum depth of the current validation, 0 being unlimited
+func (v *validate) maxDepthAllowed() int {
+
+	if v.maxDepth != 0 {
+		return v.maxDepth
+	}
+
+	return v.v.maxDepth
+}
+
+// stop returns if validation must stop, because it was aborted or the maximum number of errors has been reached;
+// in the latter case the errors are marked as truncated as the rest of the value is not validated.
+func (v *validate) stop() bool {
+
+	if v.abort != nil {
+		return true
+	}
+
+	max := v.maxErrs
+	if max == 0 {
+		max = v.v.maxErrors
+	}
+
+	if max <= 0 || len(v.errs) < max {
+		return false
+	}
+
+	v.errs[len(v.errs)-1].(*fieldError).truncated = true
+
+	return true
+}
+
+// fieldPath returns a copy of the path of the field being traversed
+func (v *validate) fieldPath() Path {
+
+	path := make(Path, len(v.path))
+	copy(path, v.path)
+
+	return path
+}
+
+// invalidTag aborts the current validation because of a malformed tag or a validation applied to a field
+// of a kind it does not support; it panics with the reason when configured to, otherwise with the error
+// itself so that recoverInvalidTag can return it from the validation entry point.
+func (v *validate) invalidTag(err *InvalidTagError) {
+
+	if v.v.panicOnInvalidTag {
+		panic(err.Reason)
+	}
+
+	panic(err)
+}
+
+// recoverInvalidTag recovers the *InvalidTagError panicked with by invalidTag and stores it in err.
+// Any other panic, eg. of a custom validation, is re-raised.
+//
+// NOTE: must be deferred directly by the validation entry point and only when not panicking on invalid tags.
+func (v *validate) recoverInvalidTag(err *error) {
+
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	tagErr, ok := r.(*InvalidTagError)
+	if !ok {
+
+		// the string panic of a baked in validation, misused by applying it to a field of
+		// the wrong kind or giving it a bad param, is an invalid tag of the field validated
+		reason, isString := r.(string)
+		if !isString || v.ct == nil || !v.ct.isBakedIn {
+			panic(r)
+		}
+
+		tagErr = &InvalidTagError{Tag: v.ct.aliasTag, Reason: reason}
+
+		if v.cf != nil {
+			tagErr.Namespace = string(append(v.flNs, v.cf.altName...))
+		}
+	}
+
+	*err = tagErr
+
+	// the validate instance is in an unknown state after the panic, let it be garbage
+	// collected instead of returning it to the pool.
+	v.errs = nil
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -13,27 +15,42 @@ import (
 )
 
 const (
-	defaultTagName     = "validate"
-	utf8HexComma       = "0x2C"
-	utf8Pipe           = "0x7C"
-	tagSeparator       = ","
-	orSeparator        = "|"
-	tagKeySeparator    = "="
-	structOnlyTag      = "structonly"
-	noStructLevelTag   = "nostructlevel"
-	omitempty          = "omitempty"
-	isdefault          = "isdefault"
-	skipValidationTag  = "-"
-	diveTag            = "dive"
-	keysTag            = "keys"
-	endKeysTag         = "endkeys"
-	requiredTag        = "required"
-	namespaceSeparator = "."
-	leftBracket        = "["
-	rightBracket       = "]"
-	restrictedTagChars = ".[],|=+()`~!@#$%^&*\\\"/?<>{}"
-	restrictedAliasErr = "Alias '%s' either contains restricted characters or is the same as a restricted tag needed for normal operation"
-	restrictedTagErr   = "Tag '%s' either contains restricted characters or is the same as a restricted tag needed for normal operation"
+	defaultTagName        = "validate"
+	utf8HexComma          = "0x2C"
+	utf8Pipe              = "0x7C"
+	tagSeparator          = ","
+	orSeparator           = "|"
+	tagKeySeparator       = "="
+	structOnlyTag         = "structonly"
+	noStructLevelTag      = "nostructlevel"
+	omitempty             = "omitempty"
+	isdefault             = "isdefault"
+	skipValidationTag     = "-"
+	diveTag               = "dive"
+	keysTag               = "keys"
+	endKeysTag            = "endkeys"
+	requiredTag           = "required"
+	requiredIfTag         = "required_if"
+	requiredUnlessTag     = "required_unless"
+	requiredWithTag       = "required_with"
+	requiredWithAllTag    = "required_with_all"
+	requiredWithoutTag    = "required_without"
+	requiredWithoutAllTag = "required_without_all"
+	excludedIfTag         = "excluded_if"
+	excludedUnlessTag     = "excluded_unless"
+	excludedWithTag       = "excluded_with"
+	excludedWithAllTag    = "excluded_with_all"
+	excludedWithoutTag    = "excluded_without"
+	excludedWithoutAllTag = "excluded_without_all"
+	namespaceSeparator    = "."
+	groupSeparator        = "."
+	leftBracket           = "["
+	rightBracket          = "]"
+	restrictedTagChars    = ".[],|=+()`~!@#$%^&*\\\"/?<>{}"
+	restrictedAliasErr    = "Alias '%s' either contains restricted characters or is the same as a restricted tag needed for normal operation"
+	restrictedTagErr      = "Tag '%s' either contains restricted characters or is the same as a restricted tag needed for normal operation"
+	invalidMapRule        = "Invalid rule for key '%s', must be a tag or a map of rules"
+	notAMapToDive         = "validator: '%s' is not a map to dive"
 )
 
 var (
@@ -55,20 +72,44 @@ type CustomTypeFunc func(field reflect.Value) interface{}
 // TagNameFunc allows for adding of a custom tag name parser
 type TagNameFunc func(field reflect.StructField) string
 
+// Limits are guardrails for validating untrusted input, a zero value meaning unlimited; validation stops
+// when one is exceeded, returning a *LimitError instead of any ValidationErrors. See SetLimits.
+type Limits struct {
+	MaxDiveElements int           // maximum number of elements of a single slice, array or map dived into
+	MaxFields       int           // maximum number of fields, including the elements dived into, visited per validation
+	MaxRegexInput   int           // maximum length of a string validated by a baked in validation using a regex, eg. email
+	Timeout         time.Duration // maximum duration of a validation, the context's deadline also being honored when earlier
+}
+
+// ValidateOptions are the options of a single validation, see StructWithOptions and VarWithOptions
+type ValidateOptions struct {
+	FailFast  bool // stop at the first error, the same as a MaxErrors of 1
+	MaxErrors int  // stop once this many errors are found, 0 uses the Validate instance's, see SetMaxErrors, and -1 is unlimited
+	MaxDepth  int  // maximum nesting of structs, 0 uses the Validate instance's, see SetMaxDepth, and -1 is unlimited
+}
+
 // Validate contains the validator settings and cache
 type Validate struct {
-	tagName          string
-	pool             *sync.Pool
-	hasCustomFuncs   bool
-	hasTagNameFunc   bool
-	tagNameFunc      TagNameFunc
-	structLevelFuncs map[reflect.Type]StructLevelFuncCtx
-	customFuncs      map[reflect.Type]CustomTypeFunc
-	aliases          map[string]string
-	validations      map[string]FuncCtx
-	transTagFunc     map[ut.Translator]map[string]TranslationFunc // map[<locale>]map[<tag>]TranslationFunc
-	tagCache         *tagCache
-	structCache      *structCache
+	tagName           string
+	modTagName        string
+	pool              *sync.Pool
+	hasCustomFuncs    bool
+	hasTagNameFunc    bool
+	panicOnInvalidTag bool
+	maxErrors         int
+	maxDepth          int
+	hasLimits         bool
+	limits            Limits
+	tagNameFunc       TagNameFunc
+	structLevelFuncs  map[reflect.Type]StructLevelFuncCtx
+	structRules       map[reflect.Type]map[string]string // validation tags by field name, see RegisterStructRules
+	customFuncs       map[reflect.Type]CustomTypeFunc
+	aliases           map[string]string
+	validations       map[string]internalValidationFuncWrapper
+	modifiers         map[string]internalModifierWrapper
+	transTagFunc      map[ut.Translator]map[string]TranslationFunc // map[<locale>]map[<tag>]TranslationFunc
+	tagCache          *tagCache
+	structCache       *structCache
 }
 
 // New returns a new instance of 'validate' with sane defaults.
@@ -81,11 +122,14 @@ func New() *Validate {
 	sc.m.Store(make(map[reflect.Type]*cStruct))
 
 	v := &Validate{
-		tagName:     defaultTagName,
-		aliases:     make(map[string]string, len(bakedInAliases)),
-		validations: make(map[string]FuncCtx, len(bakedInValidators)),
-		tagCache:    tc,
-		structCache: sc,
+		tagName:           defaultTagName,
+		modTagName:        defaultModTagName,
+		panicOnInvalidTag: true,
+		aliases:           make(map[string]string, len(bakedInAliases)),
+		validations:       make(map[string]internalValidationFuncWrapper, len(bakedInValidators)),
+		modifiers:         make(map[string]internalModifierWrapper, len(bakedInModifiers)),
+		tagCache:          tc,
+		structCache:       sc,
 	}
 
 	// must copy alias validators for separate validations to be used in each validator instance
@@ -96,8 +140,21 @@ func New() *Validate {
 	// must copy validators for separate validations to be used in each instance
 	for k, val := range bakedInValidators {
 
-		// no need to error check here, baked in will always be valid
-		_ = v.registerValidation(k, wrapFunc(val), true)
+		_, conditional := conditionalTags[k]
+
+		switch {
+		// these require that even if the value is nil that the validation should run, omitempty still overrides this behaviour
+		case conditional || k == exprTag:
+			_ = v.registerValidation(k, wrapFunc(val), true, true)
+		default:
+			// no need to error check here, baked in will always be valid
+			_ = v.registerValidation(k, wrapFunc(val), true, false)
+		}
+	}
+
+	// must copy modifiers for separate modifiers to be used in each instance
+	for k, val := range bakedInModifiers {
+		v.modifiers[k] = val
 	}
 
 	v.pool = &sync.Pool{
@@ -119,6 +176,49 @@ func (v *Validate) SetTagName(name string) {
 	v.tagName = name
 }
 
+// SetModTagName allows for changing of the default modifier tag name of 'mod'
+func (v *Validate) SetModTagName(name string) {
+	v.modTagName = name
+}
+
+// SetPanicOnInvalidTag sets whether validation panics, the default, when encountering a malformed
+// validation tag or a validation applied to a field of a kind it does not support.
+//
+// When set to false these problems are instead returned as an *InvalidTagError from Struct, Var
+// and friends; this allows a typo in a single struct tag to be reported rather than crashing a
+// long running service. Panics of custom validations are never recovered.
+func (v *Validate) SetPanicOnInvalidTag(panicOnInvalidTag bool) {
+	v.panicOnInvalidTag = panicOnInvalidTag
+}
+
+// SetMaxErrors sets the maximum number of errors a validation returns, 0, the default, being unlimited
+// and 1 failing fast on the first error.
+//
+// Once reached the rest of the fields, including the remaining elements being dived into, are not
+// validated; the ValidationErrors returned then report they are incomplete, see ValidationErrors.Truncated.
+// This can also be set for a single validation using StructWithOptions or VarWithOptions.
+func (v *Validate) SetMaxErrors(max int) {
+	v.maxErrors = max
+}
+
+// SetLimits sets the Limits guarding against untrusted input, eg. a payload with millions of
+// elements, each validation being stopped with a *LimitError once one is exceeded.
+func (v *Validate) SetLimits(limits Limits) {
+	v.limits = limits
+	v.hasLimits = limits != Limits{}
+}
+
+// SetMaxDepth sets the maximum nesting of structs validated, 0, the default, being unlimited; exceeding it
+// stops validation and returns a *MaxDepthError instead of any ValidationErrors. It is a safety limit for
+// deeply nested untrusted input, such as long linked lists, and can also be set for a single validation
+// using StructWithOptions or VarWithOptions.
+//
+// NOTE: cycles, such as a parent and child pointing to each other, are detected regardless and not
+// followed; a struct that is already being validated further up the same path is skipped.
+func (v *Validate) SetMaxDepth(max int) {
+	v.maxDepth = max
+}
+
 // RegisterTagNameFunc registers a function to get alternate names for StructFields.
 //
 // eg. to use the names which have been specified for JSON representations of structs, rather than normal Go field names:
@@ -147,10 +247,10 @@ func (v *Validate) RegisterValidation(tag string, fn Func) error {
 // RegisterValidationCtx does the same as RegisterValidation on accepts a FuncCtx validation
 // allowing context.Context validation support.
 func (v *Validate) RegisterValidationCtx(tag string, fn FuncCtx) error {
-	return v.registerValidation(tag, fn, false)
+	return v.registerValidation(tag, fn, false, false)
 }
 
-func (v *Validate) registerValidation(tag string, fn FuncCtx, bakedIn bool) error {
+func (v *Validate) registerValidation(tag string, fn FuncCtx, bakedIn bool, nilCheckable bool) error {
 
 	if len(tag) == 0 {
 		return errors.New("Function Key cannot be empty")
@@ -166,7 +266,34 @@ func (v *Validate) registerValidation(tag string, fn FuncCtx, bakedIn bool) erro
 		panic(fmt.Sprintf(restrictedTagErr, tag))
 	}
 
-	v.validations[tag] = fn
+	_, usesRegex := regexTags[tag]
+
+	v.validations[tag] = internalValidationFuncWrapper{fn: fn, runValidationOnNil: nilCheckable, usesRegex: bakedIn && usesRegex, bakedIn: bakedIn}
+
+	return nil
+}
+
+// RegisterModifier adds a modifier with the given name, applied to fields having it in their
+// mod tag before they are validated; see the Modifiers section of the package documentation.
+//
+// NOTES:
+// - if the name already exists, the previous modifier will be replaced.
+// - this method is not thread-safe it is intended that these all be registered prior to any validation
+func (v *Validate) RegisterModifier(name string, fn ModFunc) error {
+
+	if len(name) == 0 {
+		return errors.New("Modifier name cannot be empty")
+	}
+
+	if fn == nil {
+		return errors.New("Function cannot be empty")
+	}
+
+	if name == diveTag || strings.ContainsAny(name, restrictedTagChars) {
+		panic(fmt.Sprintf(restrictedTagErr, name))
+	}
+
+	v.modifiers[name] = internalModifierWrapper{fn: fn}
 
 	return nil
 }
@@ -232,6 +359,68 @@ func (v *Validate) RegisterCustomTypeFunc(fn CustomTypeFunc, types ...interface{
 	v.hasCustomFuncs = true
 }
 
+// RegisterStruct parses and caches the validation tags of the provided types, and every
+// struct type reachable from them, ahead of time; see Compile.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation
+func (v *Validate) RegisterStruct(types ...interface{}) error {
+
+	typs := make([]reflect.Type, len(types))
+
+	for i, t := range types {
+		typs[i] = reflect.TypeOf(t)
+	}
+
+	return v.compile(typs...)
+}
+
+// Compile walks the struct type t, along with every struct type reachable from it via
+// fields, pointers, slices, arrays and maps, parsing and caching the validation tags
+// found; allowing tags to be checked at startup or within a unit test instead of the
+// first time a type is validated.
+//
+// It returns InvalidValidationError when t is not a struct, nil when all tags are valid
+// and InvalidTagErrors, containing every invalid tag found, otherwise.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation
+func (v *Validate) Compile(t reflect.Type) error {
+	return v.compile(t)
+}
+
+// SetDefaults sets the zero valued fields of the struct s points to, and of the structs nested within it,
+// from the default modifier of their mod tag; eg. `mod:"default=8080"`. Other modifiers are not applied
+// and nothing is validated, Struct and friends applying the default modifiers along with the others.
+//
+// Nested structs are reached through struct fields, non nil pointers and interfaces, and the elements
+// of slices and arrays; the values of maps can't be set in place so their structs are skipped.
+//
+// It returns InvalidValidationError when s is not a non nil pointer to a struct, and an *InvalidTagError
+// when a mod tag is invalid or a default can't be parsed for the field's kind, if SetPanicOnInvalidTag
+// is false, panicking otherwise.
+func (v *Validate) SetDefaults(s interface{}) (err error) {
+
+	val := reflect.ValueOf(s)
+
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct || val.Elem().Type() == timeType {
+		return &InvalidValidationError{Type: reflect.TypeOf(s)}
+	}
+
+	vd := v.pool.Get().(*validate)
+
+	if !v.panicOnInvalidTag {
+		vd.ct = nil
+		defer vd.recoverInvalidTag(&err)
+	}
+
+	vd.defaultsOnly = true
+	vd.setDefaults(context.Background(), val.Elem(), vd.ns[0:0])
+	vd.defaultsOnly = false
+
+	v.pool.Put(vd)
+
+	return
+}
+
 // RegisterTranslation registers translations against the provided tag.
 func (v *Validate) RegisterTranslation(tag string, trans ut.Translator, registerFn RegisterTranslationsFunc, translationFn TranslationFunc) (err error) {
 
@@ -282,13 +471,24 @@ func (v *Validate) StructCtx(ctx context.Context, s interface{}) (err error) {
 
 	// good to validate
 	vd := v.pool.Get().(*validate)
+
+	if !v.panicOnInvalidTag {
+		vd.ct = nil
+		defer vd.recoverInvalidTag(&err)
+	}
+
 	vd.top = top
+	vd.startLimits(ctx)
 	vd.isPartial = false
 	// vd.hasExcludes = false // only need to reset in StructPartial and StructExcept
 
 	vd.validateStruct(ctx, top, val, val.Type(), vd.ns[0:0], vd.actualNs[0:0], nil)
 
-	if len(vd.errs) > 0 {
+	if vd.abort != nil {
+		err = vd.abort
+		vd.abort = nil
+		vd.errs = nil
+	} else if len(vd.errs) > 0 {
 		err = vd.errs
 		vd.errs = nil
 	}
@@ -298,6 +498,108 @@ func (v *Validate) StructCtx(ctx context.Context, s interface{}) (err error) {
 	return
 }
 
+// StructGroups validates a structs exposed fields, and automatically validates nested structs, the same as StructCtx
+// but using the tags of the groups provided where a field has them.
+//
+// Group tags are named after the tag name and group, eg. `validate:"required" validate.create:"isdefault"`; for each
+// field the tag of the first of groups that it has one for is used instead of its tag, a group tag of "-" skipping the
+// field within that group.
+//
+// It returns InvalidValidationError for bad values passed in and nil or ValidationErrors as error otherwise.
+// You will need to assert the error if it's not nil eg. err.(validator.ValidationErrors) to access the array of errors.
+func (v *Validate) StructGroups(ctx context.Context, s interface{}, groups ...string) (err error) {
+
+	val := reflect.ValueOf(s)
+	top := val
+
+	if val.Kind() == reflect.Ptr && !val.IsNil() {
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Struct || val.Type() == timeType {
+		return &InvalidValidationError{Type: reflect.TypeOf(s)}
+	}
+
+	// good to validate
+	vd := v.pool.Get().(*validate)
+
+	if !v.panicOnInvalidTag {
+		vd.ct = nil
+		defer vd.recoverInvalidTag(&err)
+	}
+
+	vd.top = top
+	vd.startLimits(ctx)
+	vd.isPartial = false
+	vd.groups = groups
+
+	vd.validateStruct(ctx, top, val, val.Type(), vd.ns[0:0], vd.actualNs[0:0], nil)
+
+	if vd.abort != nil {
+		err = vd.abort
+		vd.abort = nil
+		vd.errs = nil
+	} else if len(vd.errs) > 0 {
+		err = vd.errs
+		vd.errs = nil
+	}
+
+	vd.groups = nil
+
+	v.pool.Put(vd)
+
+	return
+}
+
+// StructWithOptions validates a structs exposed fields, and automatically validates nested structs, the same as
+// StructCtx but using the options provided; eg. to stop at the first error when only the validity matters.
+//
+// It returns InvalidValidationError for bad values passed in and nil or ValidationErrors as error otherwise.
+// You will need to assert the error if it's not nil eg. err.(validator.ValidationErrors) to access the array of errors.
+func (v *Validate) StructWithOptions(ctx context.Context, s interface{}, opts ValidateOptions) (err error) {
+
+	val := reflect.ValueOf(s)
+	top := val
+
+	if val.Kind() == reflect.Ptr && !val.IsNil() {
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Struct || val.Type() == timeType {
+		return &InvalidValidationError{Type: reflect.TypeOf(s)}
+	}
+
+	// good to validate
+	vd := v.pool.Get().(*validate)
+
+	if !v.panicOnInvalidTag {
+		vd.ct = nil
+		defer vd.recoverInvalidTag(&err)
+	}
+
+	vd.top = top
+	vd.startLimits(ctx)
+	vd.isPartial = false
+	vd.setOptions(opts)
+
+	vd.validateStruct(ctx, top, val, val.Type(), vd.ns[0:0], vd.actualNs[0:0], nil)
+
+	if vd.abort != nil {
+		err = vd.abort
+		vd.abort = nil
+		vd.errs = nil
+	} else if len(vd.errs) > 0 {
+		err = vd.errs
+		vd.errs = nil
+	}
+
+	vd.setOptions(ValidateOptions{})
+
+	v.pool.Put(vd)
+
+	return
+}
+
 // StructFiltered validates a structs exposed fields, that pass the FilterFunc check and automatically validates
 // nested structs, unless otherwise specified.
 //
@@ -327,14 +629,25 @@ func (v *Validate) StructFilteredCtx(ctx context.Context, s interface{}, fn Filt
 
 	// good to validate
 	vd := v.pool.Get().(*validate)
+
+	if !v.panicOnInvalidTag {
+		vd.ct = nil
+		defer vd.recoverInvalidTag(&err)
+	}
+
 	vd.top = top
+	vd.startLimits(ctx)
 	vd.isPartial = true
 	vd.ffn = fn
 	// vd.hasExcludes = false // only need to reset in StructPartial and StructExcept
 
 	vd.validateStruct(ctx, top, val, val.Type(), vd.ns[0:0], vd.actualNs[0:0], nil)
 
-	if len(vd.errs) > 0 {
+	if vd.abort != nil {
+		err = vd.abort
+		vd.abort = nil
+		vd.errs = nil
+	} else if len(vd.errs) > 0 {
 		err = vd.errs
 		vd.errs = nil
 	}
@@ -375,7 +688,14 @@ func (v *Validate) StructPartialCtx(ctx context.Context, s interface{}, fields .
 
 	// good to validate
 	vd := v.pool.Get().(*validate)
+
+	if !v.panicOnInvalidTag {
+		vd.ct = nil
+		defer vd.recoverInvalidTag(&err)
+	}
+
 	vd.top = top
+	vd.startLimits(ctx)
 	vd.isPartial = true
 	vd.ffn = nil
 	vd.hasExcludes = false
@@ -421,7 +741,11 @@ func (v *Validate) StructPartialCtx(ctx context.Context, s interface{}, fields .
 
 	vd.validateStruct(ctx, top, val, typ, vd.ns[0:0], vd.actualNs[0:0], nil)
 
-	if len(vd.errs) > 0 {
+	if vd.abort != nil {
+		err = vd.abort
+		vd.abort = nil
+		vd.errs = nil
+	} else if len(vd.errs) > 0 {
 		err = vd.errs
 		vd.errs = nil
 	}
@@ -462,7 +786,14 @@ func (v *Validate) StructExceptCtx(ctx context.Context, s interface{}, fields ..
 
 	// good to validate
 	vd := v.pool.Get().(*validate)
+
+	if !v.panicOnInvalidTag {
+		vd.ct = nil
+		defer vd.recoverInvalidTag(&err)
+	}
+
 	vd.top = top
+	vd.startLimits(ctx)
 	vd.isPartial = true
 	vd.ffn = nil
 	vd.hasExcludes = true
@@ -486,7 +817,11 @@ func (v *Validate) StructExceptCtx(ctx context.Context, s interface{}, fields ..
 
 	vd.validateStruct(ctx, top, val, typ, vd.ns[0:0], vd.actualNs[0:0], nil)
 
-	if len(vd.errs) > 0 {
+	if vd.abort != nil {
+		err = vd.abort
+		vd.abort = nil
+		vd.errs = nil
+	} else if len(vd.errs) > 0 {
 		err = vd.errs
 		vd.errs = nil
 	}
@@ -532,21 +867,391 @@ func (v *Validate) VarCtx(ctx context.Context, field interface{}, tag string) (e
 		return nil
 	}
 
-	ctag := v.fetchCacheTag(tag)
+	vd := v.pool.Get().(*validate)
+
+	if !v.panicOnInvalidTag {
+		vd.ct = nil
+		defer vd.recoverInvalidTag(&err)
+	}
+
+	ctag, tagErr := v.fetchCacheTag(tag)
+	if tagErr != nil {
+		vd.invalidTag(tagErr.(*InvalidTagError))
+	}
+
 	val := reflect.ValueOf(field)
+	vd.top = val
+	vd.startLimits(ctx)
+	vd.isPartial = false
+	vd.traverseField(ctx, val, val, vd.ns[0:0], vd.actualNs[0:0], defaultCField, ctag)
+
+	if vd.abort != nil {
+		err = vd.abort
+		vd.abort = nil
+		vd.errs = nil
+	} else if len(vd.errs) > 0 {
+		err = vd.errs
+		vd.errs = nil
+	}
+	v.pool.Put(vd)
+	return
+}
+
+// VarWithOptions validates a single variable using tag style validation, the same as VarCtx but using the
+// options provided; eg. to stop at the first error when diving into a large slice and only the validity matters.
+//
+// It returns InvalidValidationError for bad values passed in and nil or ValidationErrors as error otherwise.
+// You will need to assert the error if it's not nil eg. err.(validator.ValidationErrors) to access the array of errors.
+func (v *Validate) VarWithOptions(ctx context.Context, field interface{}, tag string, opts ValidateOptions) (err error) {
+	if len(tag) == 0 || tag == skipValidationTag {
+		return nil
+	}
+
 	vd := v.pool.Get().(*validate)
+
+	if !v.panicOnInvalidTag {
+		vd.ct = nil
+		defer vd.recoverInvalidTag(&err)
+	}
+
+	ctag, tagErr := v.fetchCacheTag(tag)
+	if tagErr != nil {
+		vd.invalidTag(tagErr.(*InvalidTagError))
+	}
+
+	val := reflect.ValueOf(field)
 	vd.top = val
+	vd.startLimits(ctx)
 	vd.isPartial = false
+	vd.setOptions(opts)
 	vd.traverseField(ctx, val, val, vd.ns[0:0], vd.actualNs[0:0], defaultCField, ctag)
 
-	if len(vd.errs) > 0 {
+	if vd.abort != nil {
+		err = vd.abort
+		vd.abort = nil
+		vd.errs = nil
+	} else if len(vd.errs) > 0 {
 		err = vd.errs
 		vd.errs = nil
 	}
+	vd.setOptions(ValidateOptions{})
 	v.pool.Put(vd)
 	return
 }
 
+// ValidateMap validates the data, such as a JSON body decoded into a map[string]interface{}, against
+// the rules; each rule being either the tag to validate the value of the same key with, or the nested
+// rules of a map, or of each map in a slice, within the data.
+//
+//	rules := map[string]interface{}{
+//		"email": "required,email",
+//		"tags":  "dive,alpha",
+//		"addresses": map[string]interface{}{
+//			"zip": "required,numeric",
+//		},
+//	}
+//
+// The errors returned mirror the rules, keyed by the same keys: the error of a tag, usually ValidationErrors,
+// or a map of the errors of nested rules; the errors of a slice of maps are keyed by the index. The FieldError's
+// namespace, and Path, is that of the value within the data, eg. "addresses[1].zip", the data having no
+// top level name. A key without any errors is omitted, an empty map meaning the data is valid.
+//
+// A key missing from the data is validated as nil, use omitempty for optional keys, and a missing nested
+// map as an empty map.
+//
+// The keys are validated in order with their map as the parent, so cross field and conditional tags name
+// the sibling keys, eg. "eqfield=password" or "required_with=phone"; and the Limits, and the maximum number
+// of errors, apply to the whole validation, the key being validated when one is exceeded having the
+// LimitError as its error.
+func (v *Validate) ValidateMap(data map[string]interface{}, rules map[string]interface{}) map[string]interface{} {
+	return v.ValidateMapCtx(context.Background(), data, rules)
+}
+
+// ValidateMapCtx validates the data against the rules, the same as ValidateMap, and allows passing of
+// contextual validation information via context.Context.
+func (v *Validate) ValidateMapCtx(ctx context.Context, data map[string]interface{}, rules map[string]interface{}) map[string]interface{} {
+
+	vd := v.pool.Get().(*validate)
+
+	vd.top = reflect.ValueOf(data)
+	vd.startLimits(ctx)
+	vd.isPartial = false
+
+	errs := vd.validateMap(ctx, data, rules, "", nil)
+
+	vd.abort = nil
+	vd.errs = nil
+	v.pool.Put(vd)
+
+	return errs
+}
+
+// validateMap validates the data against the rules, the keys being validated in order with the data as their
+// parent, so that cross field and conditional tags see the sibling keys, and sharing the Limits and the
+// maximum number of errors of the validation.
+func (v *validate) validateMap(ctx context.Context, data map[string]interface{}, rules map[string]interface{}, ns string, path Path) map[string]interface{} {
+
+	errs := make(map[string]interface{})
+	parent := reflect.ValueOf(data)
+
+	fields := make([]string, 0, len(rules))
+	for field := range rules {
+		fields = append(fields, field)
+	}
+
+	sort.Strings(fields)
+
+	for _, field := range fields {
+
+		if v.stop() {
+			break
+		}
+
+		fieldNs := field
+		if len(ns) > 0 {
+			fieldNs = ns + namespaceSeparator + field
+		}
+
+		fieldPath := append(path[:len(path):len(path)], PathSegment{Kind: FieldSegment, Name: field, StructName: field})
+
+		switch r := rules[field].(type) {
+		case string:
+
+			if err := v.validateMapField(ctx, parent, field, r, ns, fieldPath); err != nil {
+				errs[field] = err
+			}
+
+		case map[string]interface{}:
+
+			if nested := v.validateNestedMap(ctx, data[field], r, fieldNs, fieldPath); nested != nil {
+				errs[field] = nested
+			}
+
+		default:
+
+			err := &InvalidTagError{Namespace: fieldNs, Tag: fmt.Sprint(rules[field]), Reason: fmt.Sprintf(invalidMapRule, field)}
+
+			if v.v.panicOnInvalidTag {
+				panic(err.Reason)
+			}
+
+			errs[field] = err
+		}
+	}
+
+	return errs
+}
+
+// validateNestedMap validates the value, a map or a slice of maps, against the nested rules; returning nil when valid
+func (v *validate) validateNestedMap(ctx context.Context, value interface{}, rules map[string]interface{}, ns string, path Path) interface{} {
+
+	var maps []interface{}
+
+	switch d := value.(type) {
+	case nil:
+		// validated as an empty map, so that required and friends apply
+		return v.validateNestedMap(ctx, map[string]interface{}(nil), rules, ns, path)
+
+	case map[string]interface{}:
+
+		if errs := v.validateMap(ctx, d, rules, ns, path); len(errs) > 0 {
+			return errs
+		}
+
+		return nil
+
+	case []map[string]interface{}:
+
+		maps = make([]interface{}, len(d))
+
+		for i := range d {
+			maps[i] = d[i]
+		}
+
+	case []interface{}:
+		maps = d
+
+	default:
+		return fmt.Errorf(notAMapToDive, ns)
+	}
+
+	errs := make(map[string]interface{})
+
+	for i, m := range maps {
+
+		if v.stop() {
+			break
+		}
+
+		idx := strconv.Itoa(i)
+		idxPath := append(path[:len(path):len(path)], PathSegment{Kind: IndexSegment, Index: i})
+
+		if nested := v.validateNestedMap(ctx, m, rules, ns+leftBracket+idx+rightBracket, idxPath); nested != nil {
+			errs[idx] = nested
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// validateMapField validates the value of the name key of the parent map, a missing key being a nil value,
+// returning the errors of the key or what aborted the validation.
+//
+// When not panicking on invalid tags, an invalid tag is returned as the error of the key and the state of
+// the validation is restored, so that the rest of the keys are still validated.
+func (v *validate) validateMapField(ctx context.Context, parent reflect.Value, name string, tag string, ns string, path Path) (err error) {
+	if len(tag) == 0 || tag == skipValidationTag {
+		return nil
+	}
+
+	errs := v.errs
+	before := len(v.errs)
+
+	if !v.v.panicOnInvalidTag {
+		v.ct = nil
+
+		defer func() {
+			if _, ok := err.(*InvalidTagError); ok {
+				v.errs = errs
+				v.depth = 0
+
+				for key := range v.visiting {
+					delete(v.visiting, key)
+				}
+			}
+		}()
+
+		defer v.recoverInvalidTag(&err)
+	}
+
+	ctag, tagErr := v.v.fetchCacheTag(tag)
+	if tagErr != nil {
+		tagErr.(*InvalidTagError).Namespace = name
+		if len(ns) > 0 {
+			tagErr.(*InvalidTagError).Namespace = ns + namespaceSeparator + name
+		}
+		v.invalidTag(tagErr.(*InvalidTagError))
+	}
+
+	v.ns = v.ns[0:0]
+	v.actualNs = v.actualNs[0:0]
+
+	if len(ns) > 0 {
+		v.ns = append(append(v.ns, ns...), '.')
+		v.actualNs = append(append(v.actualNs, ns...), '.')
+	}
+
+	// a missing key is a nil interface, as a nil value is, so the tags running on nil, eg. required_with, apply
+	current := parent.MapIndex(reflect.ValueOf(name))
+	if !current.IsValid() {
+		current = reflect.Zero(parent.Type().Elem())
+	} else if !current.IsNil() {
+		current = current.Elem()
+	}
+
+	v.path = append(v.path[0:0], path...)
+	v.traverseField(ctx, parent, current, v.ns, v.actualNs, &cField{name: name, altName: name, namesEqual: true}, ctag)
+	v.path = v.path[0:0]
+
+	if v.abort != nil {
+		err = v.abort
+	} else if len(v.errs) > before {
+		err = v.errs[before:len(v.errs):len(v.errs)]
+	}
+
+	return
+}
+
+// varField validates a single value, the same as VarCtx, but named and at the path of the value
+// within a larger document, see VarPath.
+func (v *Validate) varField(ctx context.Context, field interface{}, tag string, ns string, name string, path Path) (err error) {
+	if len(tag) == 0 || tag == skipValidationTag {
+		return nil
+	}
+
+	vd := v.pool.Get().(*validate)
+
+	if !v.panicOnInvalidTag {
+		vd.ct = nil
+		defer vd.recoverInvalidTag(&err)
+	}
+
+	ctag, tagErr := v.fetchCacheTag(tag)
+	if tagErr != nil {
+		tagErr.(*InvalidTagError).Namespace = name
+		if len(ns) > 0 {
+			tagErr.(*InvalidTagError).Namespace = ns + namespaceSeparator + name
+		}
+		vd.invalidTag(tagErr.(*InvalidTagError))
+	}
+
+	vd.ns = vd.ns[0:0]
+	vd.actualNs = vd.actualNs[0:0]
+
+	if len(ns) > 0 {
+		vd.ns = append(append(vd.ns, ns...), '.')
+		vd.actualNs = append(append(vd.actualNs, ns...), '.')
+	}
+
+	val := reflect.ValueOf(field)
+	vd.top = val
+	vd.startLimits(ctx)
+	vd.isPartial = false
+	vd.path = append(vd.path[0:0], path...)
+	vd.traverseField(ctx, val, val, vd.ns, vd.actualNs, &cField{name: name, altName: name, namesEqual: true}, ctag)
+	vd.path = vd.path[0:0]
+
+	if vd.abort != nil {
+		err = vd.abort
+		vd.abort = nil
+		vd.errs = nil
+	} else if len(vd.errs) > 0 {
+		err = vd.errs
+		vd.errs = nil
+	}
+	v.pool.Put(vd)
+	return
+}
+
+// VarPath validates a single variable using tag style validation, the same as Var, as the value at the
+// path within a larger document; so the namespaces, names and paths of the FieldErrors are those of the
+// value within the document, such as one being validated by the jsonschema package.
+// eg.
+//
+//	validate.VarPath(zip, "numeric", validator.Path{
+//		{Kind: validator.FieldSegment, Name: "addresses", StructName: "addresses"},
+//		{Kind: validator.IndexSegment, Index: 0},
+//		{Kind: validator.FieldSegment, Name: "zip", StructName: "zip"},
+//	}) // errors have the namespace "addresses[0].zip"
+//
+// The path's names are used for both the namespaces and struct namespaces.
+//
+// It returns nil or ValidationErrors as error.
+func (v *Validate) VarPath(field interface{}, tag string, path Path) error {
+	return v.VarPathCtx(context.Background(), field, tag, path)
+}
+
+// VarPathCtx validates a single variable at the path, the same as VarPath, and allows passing of contextual
+// validation information via context.Context.
+func (v *Validate) VarPathCtx(ctx context.Context, field interface{}, tag string, path Path) error {
+
+	// the value is named by the last field, along with any indexes or keys following it
+	last := 0
+
+	for i := len(path) - 1; i >= 0; i-- {
+		if path[i].Kind == FieldSegment {
+			last = i
+			break
+		}
+	}
+
+	return v.varField(ctx, field, tag, path[:last].String(), path[last:].String(), path)
+}
+
 // VarWithValue validates a single variable, against another variable/field's value using tag style validation
 // eg.
 // s1 := "abcd"
@@ -584,14 +1289,29 @@ func (v *Validate) VarWithValueCtx(ctx context.Context, field interface{}, other
 	if len(tag) == 0 || tag == skipValidationTag {
 		return nil
 	}
-	ctag := v.fetchCacheTag(tag)
-	otherVal := reflect.ValueOf(other)
 	vd := v.pool.Get().(*validate)
+
+	if !v.panicOnInvalidTag {
+		vd.ct = nil
+		defer vd.recoverInvalidTag(&err)
+	}
+
+	ctag, tagErr := v.fetchCacheTag(tag)
+	if tagErr != nil {
+		vd.invalidTag(tagErr.(*InvalidTagError))
+	}
+
+	otherVal := reflect.ValueOf(other)
 	vd.top = otherVal
+	vd.startLimits(ctx)
 	vd.isPartial = false
 	vd.traverseField(ctx, otherVal, reflect.ValueOf(field), vd.ns[0:0], vd.actualNs[0:0], defaultCField, ctag)
 
-	if len(vd.errs) > 0 {
+	if vd.abort != nil {
+		err = vd.abort
+		vd.abort = nil
+		vd.errs = nil
+	} else if len(vd.errs) > 0 {
 		err = vd.errs
 		vd.errs = nil
 	}
@@ -8,6 +8,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"math"
 	"path/filepath"
 	"reflect"
 	"strings"
@@ -1639,125 +1640,125 @@ func TestCrossNamespaceFieldValidation(t *testing.T) {
 		v: vd,
 	}
 
-	current, kind, ok := v.getStructFieldOKInternal(val, "Inner.CreatedAt")
+	current, kind, _, ok := v.getStructFieldOKInternal(val, "Inner.CreatedAt")
 	Equal(t, ok, true)
 	Equal(t, kind, reflect.Struct)
 	tm, ok := current.Interface().(time.Time)
 	Equal(t, ok, true)
 	Equal(t, tm, now)
 
-	current, kind, ok = v.getStructFieldOKInternal(val, "Inner.Slice[1]")
+	current, kind, _, ok = v.getStructFieldOKInternal(val, "Inner.Slice[1]")
 	Equal(t, ok, true)
 	Equal(t, kind, reflect.String)
 	Equal(t, current.String(), "val2")
 
-	current, _, ok = v.getStructFieldOKInternal(val, "Inner.CrazyNonExistantField")
+	current, _, _, ok = v.getStructFieldOKInternal(val, "Inner.CrazyNonExistantField")
 	Equal(t, ok, false)
 
-	current, _, ok = v.getStructFieldOKInternal(val, "Inner.Slice[101]")
+	current, _, _, ok = v.getStructFieldOKInternal(val, "Inner.Slice[101]")
 	Equal(t, ok, false)
 
-	current, kind, ok = v.getStructFieldOKInternal(val, "Inner.Map[key3]")
+	current, kind, _, ok = v.getStructFieldOKInternal(val, "Inner.Map[key3]")
 	Equal(t, ok, true)
 	Equal(t, kind, reflect.String)
 	Equal(t, current.String(), "val3")
 
-	current, kind, ok = v.getStructFieldOKInternal(val, "Inner.MapMap[key2][key2-1]")
+	current, kind, _, ok = v.getStructFieldOKInternal(val, "Inner.MapMap[key2][key2-1]")
 	Equal(t, ok, true)
 	Equal(t, kind, reflect.String)
 	Equal(t, current.String(), "val2")
 
-	current, kind, ok = v.getStructFieldOKInternal(val, "Inner.MapStructs[key2].Name")
+	current, kind, _, ok = v.getStructFieldOKInternal(val, "Inner.MapStructs[key2].Name")
 	Equal(t, ok, true)
 	Equal(t, kind, reflect.String)
 	Equal(t, current.String(), "name2")
 
-	current, kind, ok = v.getStructFieldOKInternal(val, "Inner.MapMapStruct[key3][key3-1].Name")
+	current, kind, _, ok = v.getStructFieldOKInternal(val, "Inner.MapMapStruct[key3][key3-1].Name")
 	Equal(t, ok, true)
 	Equal(t, kind, reflect.String)
 	Equal(t, current.String(), "name3")
 
-	current, kind, ok = v.getStructFieldOKInternal(val, "Inner.SliceSlice[2][0]")
+	current, kind, _, ok = v.getStructFieldOKInternal(val, "Inner.SliceSlice[2][0]")
 	Equal(t, ok, true)
 	Equal(t, kind, reflect.String)
 	Equal(t, current.String(), "7")
 
-	current, kind, ok = v.getStructFieldOKInternal(val, "Inner.SliceSliceStruct[2][1].Name")
+	current, kind, _, ok = v.getStructFieldOKInternal(val, "Inner.SliceSliceStruct[2][1].Name")
 	Equal(t, ok, true)
 	Equal(t, kind, reflect.String)
 	Equal(t, current.String(), "name8")
 
-	current, kind, ok = v.getStructFieldOKInternal(val, "Inner.SliceMap[1][key5]")
+	current, kind, _, ok = v.getStructFieldOKInternal(val, "Inner.SliceMap[1][key5]")
 	Equal(t, ok, true)
 	Equal(t, kind, reflect.String)
 	Equal(t, current.String(), "val5")
 
-	current, kind, ok = v.getStructFieldOKInternal(val, "Inner.MapSlice[key3][2]")
+	current, kind, _, ok = v.getStructFieldOKInternal(val, "Inner.MapSlice[key3][2]")
 	Equal(t, ok, true)
 	Equal(t, kind, reflect.String)
 	Equal(t, current.String(), "9")
 
-	current, kind, ok = v.getStructFieldOKInternal(val, "Inner.MapInt[2]")
+	current, kind, _, ok = v.getStructFieldOKInternal(val, "Inner.MapInt[2]")
 	Equal(t, ok, true)
 	Equal(t, kind, reflect.String)
 	Equal(t, current.String(), "val2")
 
-	current, kind, ok = v.getStructFieldOKInternal(val, "Inner.MapInt8[2]")
+	current, kind, _, ok = v.getStructFieldOKInternal(val, "Inner.MapInt8[2]")
 	Equal(t, ok, true)
 	Equal(t, kind, reflect.String)
 	Equal(t, current.String(), "val2")
 
-	current, kind, ok = v.getStructFieldOKInternal(val, "Inner.MapInt16[2]")
+	current, kind, _, ok = v.getStructFieldOKInternal(val, "Inner.MapInt16[2]")
 	Equal(t, ok, true)
 	Equal(t, kind, reflect.String)
 	Equal(t, current.String(), "val2")
 
-	current, kind, ok = v.getStructFieldOKInternal(val, "Inner.MapInt32[2]")
+	current, kind, _, ok = v.getStructFieldOKInternal(val, "Inner.MapInt32[2]")
 	Equal(t, ok, true)
 	Equal(t, kind, reflect.String)
 	Equal(t, current.String(), "val2")
 
-	current, kind, ok = v.getStructFieldOKInternal(val, "Inner.MapInt64[2]")
+	current, kind, _, ok = v.getStructFieldOKInternal(val, "Inner.MapInt64[2]")
 	Equal(t, ok, true)
 	Equal(t, kind, reflect.String)
 	Equal(t, current.String(), "val2")
 
-	current, kind, ok = v.getStructFieldOKInternal(val, "Inner.MapUint[2]")
+	current, kind, _, ok = v.getStructFieldOKInternal(val, "Inner.MapUint[2]")
 	Equal(t, ok, true)
 	Equal(t, kind, reflect.String)
 	Equal(t, current.String(), "val2")
 
-	current, kind, ok = v.getStructFieldOKInternal(val, "Inner.MapUint8[2]")
+	current, kind, _, ok = v.getStructFieldOKInternal(val, "Inner.MapUint8[2]")
 	Equal(t, ok, true)
 	Equal(t, kind, reflect.String)
 	Equal(t, current.String(), "val2")
 
-	current, kind, ok = v.getStructFieldOKInternal(val, "Inner.MapUint16[2]")
+	current, kind, _, ok = v.getStructFieldOKInternal(val, "Inner.MapUint16[2]")
 	Equal(t, ok, true)
 	Equal(t, kind, reflect.String)
 	Equal(t, current.String(), "val2")
 
-	current, kind, ok = v.getStructFieldOKInternal(val, "Inner.MapUint32[2]")
+	current, kind, _, ok = v.getStructFieldOKInternal(val, "Inner.MapUint32[2]")
 	Equal(t, ok, true)
 	Equal(t, kind, reflect.String)
 	Equal(t, current.String(), "val2")
 
-	current, kind, ok = v.getStructFieldOKInternal(val, "Inner.MapUint64[2]")
+	current, kind, _, ok = v.getStructFieldOKInternal(val, "Inner.MapUint64[2]")
 	Equal(t, ok, true)
 	Equal(t, kind, reflect.String)
 	Equal(t, current.String(), "val2")
 
-	current, kind, ok = v.getStructFieldOKInternal(val, "Inner.MapFloat32[3.03]")
+	current, kind, _, ok = v.getStructFieldOKInternal(val, "Inner.MapFloat32[3.03]")
 	Equal(t, ok, true)
 	Equal(t, kind, reflect.String)
 	Equal(t, current.String(), "val3")
 
-	current, kind, ok = v.getStructFieldOKInternal(val, "Inner.MapFloat64[2.02]")
+	current, kind, _, ok = v.getStructFieldOKInternal(val, "Inner.MapFloat64[2.02]")
 	Equal(t, ok, true)
 	Equal(t, kind, reflect.String)
 	Equal(t, current.String(), "val2")
 
-	current, kind, ok = v.getStructFieldOKInternal(val, "Inner.MapBool[true]")
+	current, kind, _, ok = v.getStructFieldOKInternal(val, "Inner.MapBool[true]")
 	Equal(t, ok, true)
 	Equal(t, kind, reflect.String)
 	Equal(t, current.String(), "val1")
@@ -1783,13 +1784,13 @@ func TestCrossNamespaceFieldValidation(t *testing.T) {
 
 	val = reflect.ValueOf(test)
 
-	current, kind, ok = v.getStructFieldOKInternal(val, "Inner.SliceStructs[2]")
+	current, kind, _, ok = v.getStructFieldOKInternal(val, "Inner.SliceStructs[2]")
 	Equal(t, ok, true)
 	Equal(t, kind, reflect.Ptr)
 	Equal(t, current.String(), "<*validator.SliceStruct Value>")
 	Equal(t, current.IsNil(), true)
 
-	current, kind, ok = v.getStructFieldOKInternal(val, "Inner.SliceStructs[2].Name")
+	current, kind, _, ok = v.getStructFieldOKInternal(val, "Inner.SliceStructs[2].Name")
 	Equal(t, ok, false)
 	Equal(t, kind, reflect.Ptr)
 	Equal(t, current.String(), "<*validator.SliceStruct Value>")
@@ -6747,14 +6748,14 @@ func TestMutipleRecursiveExtractStructCache(t *testing.T) {
 	name := "Recursive"
 	proceed := make(chan struct{})
 
-	sc := validate.extractStructCache(current, name)
+	sc, _ := validate.extractStructCache(current, name)
 	ptr := fmt.Sprintf("%p", sc)
 
 	for i := 0; i < 100; i++ {
 
 		go func() {
 			<-proceed
-			sc := validate.extractStructCache(current, name)
+			sc, _ := validate.extractStructCache(current, name)
 			Equal(t, ptr, fmt.Sprintf("%p", sc))
 		}()
 	}
@@ -8162,3 +8163,3258 @@ func TestStructLevelValidationsPointerPassing(t *testing.T) {
 	NotEqual(t, errs, nil)
 	AssertError(t, errs, "TestStruct.StringVal", "TestStruct.String", "StringVal", "String", "badvalueteststruct")
 }
+
+func TestRequiredIf(t *testing.T) {
+	type Inner struct {
+		Field *string
+	}
+
+	fieldVal := "test"
+	test := struct {
+		Inner   *Inner
+		FieldE  string            `validate:"omitempty" json:"field_e"`
+		FieldER string            `validate:"required_if=FieldE test" json:"field_er"`
+		Field1  string            `validate:"omitempty" json:"field_1"`
+		Field2  *string           `validate:"required_if=Field1 test" json:"field_2"`
+		Field3  map[string]string `validate:"required_if=Field2 test" json:"field_3"`
+		Field4  interface{}       `validate:"required_if=Field3 1" json:"field_4"`
+		Field5  int               `validate:"required_if=Inner.Field test" json:"field_5"`
+		Field6  uint              `validate:"required_if=Field5 1" json:"field_6"`
+		Field7  float32           `validate:"required_if=Field6 1" json:"field_7"`
+		Field8  float64           `validate:"required_if=Field7 1.0" json:"field_8"`
+		Field9  bool              `validate:"required_if=Field8 1.0" json:"field_9"`
+		Field10 string            `validate:"required_if=Field9 true" json:"field_10"`
+	}{
+		Inner:   &Inner{Field: &fieldVal},
+		Field2:  &fieldVal,
+		Field3:  map[string]string{"key": "val"},
+		Field4:  "test",
+		Field5:  2,
+		Field6:  1,
+		Field7:  1,
+		Field8:  1.0,
+		Field9:  true,
+		Field10: "set",
+	}
+
+	validate := New()
+
+	errs := validate.Struct(test)
+	Equal(t, errs, nil)
+
+	test2 := struct {
+		Inner   *Inner
+		Inner2  *Inner
+		FieldE  string            `validate:"omitempty" json:"field_e"`
+		FieldER string            `validate:"required_if=FieldE test" json:"field_er"`
+		Field1  string            `validate:"omitempty" json:"field_1"`
+		Field2  *string           `validate:"required_if=Field1 test" json:"field_2"`
+		Field3  map[string]string `validate:"required_if=Field2 test" json:"field_3"`
+		Field4  interface{}       `validate:"required_if=Field2 test" json:"field_4"`
+		Field5  string            `validate:"required_if=Field3 1" json:"field_5"`
+		Field6  string            `validate:"required_if=Inner.Field test" json:"field_6"`
+		Field7  string            `validate:"required_if=Inner2.Field test" json:"field_7"`
+		Field8  string            `validate:"required_if=Field1 test Field6 test" json:"field_8"`
+	}{
+		Inner:  &Inner{Field: &fieldVal},
+		Field1: "test",
+		Field2: &fieldVal,
+		Field6: "test",
+	}
+
+	errs = validate.Struct(test2)
+	NotEqual(t, errs, nil)
+
+	ve := errs.(ValidationErrors)
+	Equal(t, len(ve), 3)
+	AssertError(t, errs, "Field3", "Field3", "Field3", "Field3", "required_if")
+	AssertError(t, errs, "Field4", "Field4", "Field4", "Field4", "required_if")
+	AssertError(t, errs, "Field8", "Field8", "Field8", "Field8", "required_if")
+
+	fe := getError(errs, "Field8", "Field8")
+	Equal(t, fe.Param(), "Field1 test Field6 test")
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("test3 should have panicked!")
+		}
+	}()
+
+	test3 := struct {
+		Inner  *Inner
+		Field1 string `validate:"required_if=Inner.Field" json:"field_1"`
+	}{
+		Inner: &Inner{Field: &fieldVal},
+	}
+	_ = validate.Struct(test3)
+}
+
+func TestRequiredUnless(t *testing.T) {
+	type Inner struct {
+		Field *string
+	}
+
+	fieldVal := "test"
+	test := struct {
+		Inner   *Inner
+		FieldE  string            `validate:"omitempty" json:"field_e"`
+		FieldER string            `validate:"required_unless=FieldE test" json:"field_er"`
+		Field1  string            `validate:"omitempty" json:"field_1"`
+		Field2  *string           `validate:"required_unless=Field1 test" json:"field_2"`
+		Field3  map[string]string `validate:"required_unless=Field2 test" json:"field_3"`
+		Field4  interface{}       `validate:"required_unless=Field3 1" json:"field_4"`
+		Field5  int               `validate:"required_unless=Inner.Field test" json:"field_5"`
+		Field6  uint              `validate:"required_unless=Field5 2" json:"field_6"`
+		Field7  float32           `validate:"required_unless=Field6 0" json:"field_7"`
+		Field8  float64           `validate:"required_unless=Field7 0.0" json:"field_8"`
+		Field9  bool              `validate:"omitempty" json:"field_9"`
+		Field10 string            `validate:"required_unless=Field9 true" json:"field_10"`
+	}{
+		FieldE:  "test",
+		Field2:  &fieldVal,
+		Field3:  map[string]string{"key": "val"},
+		Field4:  "test",
+		Field5:  2,
+		Field10: "set",
+	}
+
+	validate := New()
+
+	errs := validate.Struct(test)
+	Equal(t, errs, nil)
+
+	test2 := struct {
+		Inner   *Inner
+		Inner2  *Inner
+		FieldE  string            `validate:"omitempty" json:"field_e"`
+		FieldER string            `validate:"required_unless=FieldE test" json:"field_er"`
+		Field1  string            `validate:"omitempty" json:"field_1"`
+		Field2  *string           `validate:"required_unless=Field1 test" json:"field_2"`
+		Field3  map[string]string `validate:"required_unless=Field2 test" json:"field_3"`
+		Field4  interface{}       `validate:"required_unless=Field2 test" json:"field_4"`
+		Field5  string            `validate:"required_unless=Field3 0" json:"field_5"`
+		Field6  string            `validate:"required_unless=Inner.Field test" json:"field_6"`
+		Field7  string            `validate:"required_unless=Inner2.Field test" json:"field_7"`
+	}{
+		Inner:  &Inner{Field: &fieldVal},
+		FieldE: "test",
+		Field1: "test",
+	}
+
+	errs = validate.Struct(test2)
+	NotEqual(t, errs, nil)
+
+	ve := errs.(ValidationErrors)
+	Equal(t, len(ve), 3)
+	AssertError(t, errs, "Field3", "Field3", "Field3", "Field3", "required_unless")
+	AssertError(t, errs, "Field4", "Field4", "Field4", "Field4", "required_unless")
+	AssertError(t, errs, "Field7", "Field7", "Field7", "Field7", "required_unless")
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("test3 should have panicked!")
+		}
+	}()
+
+	test3 := struct {
+		Inner  *Inner
+		Field1 string `validate:"required_unless=Inner.Field" json:"field_1"`
+	}{
+		Inner: &Inner{Field: &fieldVal},
+	}
+	_ = validate.Struct(test3)
+}
+
+func TestRequiredWith(t *testing.T) {
+	type Inner struct {
+		Field *string
+	}
+
+	fieldVal := "test"
+	test := struct {
+		Inner   *Inner
+		FieldE  string            `validate:"omitempty" json:"field_e"`
+		FieldER string            `validate:"required_with=FieldE" json:"field_er"`
+		Field1  string            `validate:"omitempty" json:"field_1"`
+		Field2  *string           `validate:"required_with=Field1" json:"field_2"`
+		Field3  map[string]string `validate:"required_with=Field2" json:"field_3"`
+		Field4  interface{}       `validate:"required_with=Field3" json:"field_4"`
+		Field5  string            `validate:"required_with=Inner.Field" json:"field_5"`
+	}{
+		Inner:  &Inner{Field: &fieldVal},
+		Field1: "test_field1",
+		Field2: &fieldVal,
+		Field3: map[string]string{"key": "val"},
+		Field4: "test",
+		Field5: "test",
+	}
+
+	validate := New()
+
+	errs := validate.Struct(test)
+	Equal(t, errs, nil)
+
+	test2 := struct {
+		Inner   *Inner
+		Inner2  *Inner
+		FieldE  string            `validate:"omitempty" json:"field_e"`
+		FieldER string            `validate:"required_with=FieldE" json:"field_er"`
+		Field1  string            `validate:"omitempty" json:"field_1"`
+		Field2  *string           `validate:"required_with=Field1" json:"field_2"`
+		Field3  map[string]string `validate:"required_with=Field2" json:"field_3"`
+		Field4  interface{}       `validate:"required_with=Field2" json:"field_4"`
+		Field5  string            `validate:"required_with=Field3" json:"field_5"`
+		Field6  string            `validate:"required_with=Inner.Field" json:"field_6"`
+		Field7  string            `validate:"required_with=Inner2.Field" json:"field_7"`
+	}{
+		Inner:  &Inner{Field: &fieldVal},
+		Field2: &fieldVal,
+	}
+
+	errs = validate.Struct(test2)
+	NotEqual(t, errs, nil)
+
+	ve := errs.(ValidationErrors)
+	Equal(t, len(ve), 3)
+	AssertError(t, errs, "Field3", "Field3", "Field3", "Field3", "required_with")
+	AssertError(t, errs, "Field4", "Field4", "Field4", "Field4", "required_with")
+	AssertError(t, errs, "Field6", "Field6", "Field6", "Field6", "required_with")
+}
+
+func TestRequiredWithAll(t *testing.T) {
+	type Inner struct {
+		Field *string
+	}
+
+	fieldVal := "test"
+	test := struct {
+		Inner   *Inner
+		FieldE  string            `validate:"omitempty" json:"field_e"`
+		FieldER string            `validate:"required_with_all=FieldE" json:"field_er"`
+		Field1  string            `validate:"omitempty" json:"field_1"`
+		Field2  *string           `validate:"required_with_all=Field1" json:"field_2"`
+		Field3  map[string]string `validate:"required_with_all=Field2" json:"field_3"`
+		Field4  interface{}       `validate:"required_with_all=Field3" json:"field_4"`
+		Field5  string            `validate:"required_with_all=Inner.Field" json:"field_5"`
+	}{
+		Inner:  &Inner{Field: &fieldVal},
+		Field1: "test_field1",
+		Field2: &fieldVal,
+		Field3: map[string]string{"key": "val"},
+		Field4: "test",
+		Field5: "test",
+	}
+
+	validate := New()
+
+	errs := validate.Struct(test)
+	Equal(t, errs, nil)
+
+	test2 := struct {
+		Inner   *Inner
+		Inner2  *Inner
+		FieldE  string            `validate:"omitempty" json:"field_e"`
+		FieldER string            `validate:"required_with_all=FieldE" json:"field_er"`
+		Field1  string            `validate:"omitempty" json:"field_1"`
+		Field2  *string           `validate:"required_with_all=Field1" json:"field_2"`
+		Field3  map[string]string `validate:"required_with_all=Field2" json:"field_3"`
+		Field4  interface{}       `validate:"required_with_all=Field1 FieldE" json:"field_4"`
+		Field5  string            `validate:"required_with_all=Inner.Field Field2" json:"field_5"`
+		Field6  string            `validate:"required_with_all=Field2 FieldE" json:"field_6"`
+	}{
+		Inner:  &Inner{Field: &fieldVal},
+		Field2: &fieldVal,
+	}
+
+	errs = validate.Struct(test2)
+	NotEqual(t, errs, nil)
+
+	ve := errs.(ValidationErrors)
+	Equal(t, len(ve), 2)
+	AssertError(t, errs, "Field3", "Field3", "Field3", "Field3", "required_with_all")
+	AssertError(t, errs, "Field5", "Field5", "Field5", "Field5", "required_with_all")
+}
+
+func TestRequiredWithout(t *testing.T) {
+	type Inner struct {
+		Field *string
+	}
+
+	fieldVal := "test"
+	test := struct {
+		Inner  *Inner
+		Field1 string            `validate:"omitempty" json:"field_1"`
+		Field2 *string           `validate:"required_without=Field1" json:"field_2"`
+		Field3 map[string]string `validate:"required_without=Field2" json:"field_3"`
+		Field4 interface{}       `validate:"required_without=Field3" json:"field_4"`
+		Field5 string            `validate:"required_without=Field3" json:"field_5"`
+	}{
+		Inner:  &Inner{Field: &fieldVal},
+		Field2: &fieldVal,
+		Field3: map[string]string{"key": "val"},
+		Field4: "test",
+		Field5: "test",
+	}
+
+	validate := New()
+
+	errs := validate.Struct(test)
+	Equal(t, errs, nil)
+
+	test2 := struct {
+		Inner   *Inner
+		Inner2  *Inner
+		Field1  string            `json:"field_1"`
+		Field2  *string           `validate:"required_without=Field1" json:"field_2"`
+		Field3  map[string]string `validate:"required_without=Field2" json:"field_3"`
+		Field4  interface{}       `validate:"required_without=Field3" json:"field_4"`
+		Field5  string            `validate:"required_without=Field3" json:"field_5"`
+		Field6  string            `validate:"required_without=Field1" json:"field_6"`
+		Field7  string            `validate:"required_without=Inner.Field" json:"field_7"`
+		Field8  string            `validate:"required_without=Inner.Field" json:"field_8"`
+		Field9  string            `validate:"required_without=Inner2.Field" json:"field_9"`
+		Field10 *string           `validate:"required_without=Field1,omitempty,min=3" json:"field_10"`
+	}{
+		Inner:  &Inner{},
+		Field3: map[string]string{"key": "val"},
+		Field4: "test",
+		Field5: "test",
+		Field6: "test",
+		Field7: "test",
+		Field8: "test",
+		Field9: "test",
+	}
+
+	errs = validate.Struct(&test2)
+	NotEqual(t, errs, nil)
+
+	ve := errs.(ValidationErrors)
+	Equal(t, len(ve), 2)
+	AssertError(t, errs, "Field2", "Field2", "Field2", "Field2", "required_without")
+	AssertError(t, errs, "Field10", "Field10", "Field10", "Field10", "required_without")
+
+	test2.Field1 = "test"
+	errs = validate.Struct(&test2)
+	Equal(t, errs, nil)
+
+	short := "ab"
+	test2.Field10 = &short
+	errs = validate.Struct(&test2)
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "Field10", "Field10", "Field10", "Field10", "min")
+}
+
+func TestRequiredWithoutAll(t *testing.T) {
+	fieldVal := "test"
+	test := struct {
+		Field1 string            `validate:"omitempty" json:"field_1"`
+		Field2 *string           `validate:"required_without_all=Field1" json:"field_2"`
+		Field3 map[string]string `validate:"required_without_all=Field2" json:"field_3"`
+		Field4 interface{}       `validate:"required_without_all=Field3" json:"field_4"`
+		Field5 string            `validate:"required_without_all=Field3" json:"field_5"`
+	}{
+		Field1: "",
+		Field2: &fieldVal,
+		Field3: map[string]string{"key": "val"},
+		Field4: "test",
+		Field5: "test",
+	}
+
+	validate := New()
+
+	errs := validate.Struct(test)
+	Equal(t, errs, nil)
+
+	test2 := struct {
+		Field1 string            `validate:"omitempty" json:"field_1"`
+		Field2 *string           `validate:"required_without_all=Field1" json:"field_2"`
+		Field3 map[string]string `validate:"required_without_all=Field2" json:"field_3"`
+		Field4 interface{}       `validate:"required_without_all=Field3" json:"field_4"`
+		Field5 string            `validate:"required_without_all=Field3" json:"field_5"`
+		Field6 string            `validate:"required_without_all=Field1 Field3" json:"field_6"`
+	}{
+		Field3: map[string]string{"key": "val"},
+		Field4: "test",
+		Field5: "test",
+	}
+
+	errs = validate.Struct(test2)
+	NotEqual(t, errs, nil)
+
+	ve := errs.(ValidationErrors)
+	Equal(t, len(ve), 1)
+	AssertError(t, errs, "Field2", "Field2", "Field2", "Field2", "required_without_all")
+}
+
+func TestExcludedIf(t *testing.T) {
+	validate := New()
+
+	type Payment struct {
+		Method   string  `validate:"required"`
+		Card     string  `validate:"excluded_if=Method invoice"`
+		CardCVV  *string `validate:"excluded_if=Method invoice"`
+		Discount int     `validate:"excluded_if=Method invoice Admin true"`
+		Admin    bool
+	}
+
+	cvv := "123"
+	test := Payment{
+		Method:  "card",
+		Card:    "4111111111111111",
+		CardCVV: &cvv,
+	}
+
+	errs := validate.Struct(test)
+	Equal(t, errs, nil)
+
+	test = Payment{
+		Method:   "invoice",
+		Discount: 10,
+	}
+
+	errs = validate.Struct(test)
+	Equal(t, errs, nil)
+
+	test = Payment{
+		Method:   "invoice",
+		Card:     "4111111111111111",
+		CardCVV:  &cvv,
+		Discount: 10,
+		Admin:    true,
+	}
+
+	errs = validate.Struct(test)
+	NotEqual(t, errs, nil)
+
+	ve := errs.(ValidationErrors)
+	Equal(t, len(ve), 3)
+	AssertError(t, errs, "Payment.Card", "Payment.Card", "Card", "Card", "excluded_if")
+	AssertError(t, errs, "Payment.CardCVV", "Payment.CardCVV", "CardCVV", "CardCVV", "excluded_if")
+	AssertError(t, errs, "Payment.Discount", "Payment.Discount", "Discount", "Discount", "excluded_if")
+
+	fe := getError(errs, "Payment.Card", "Payment.Card")
+	Equal(t, fe.Param(), "Method invoice")
+	Equal(t, fe.Value(), "4111111111111111")
+
+	fe = getError(errs, "Payment.Discount", "Payment.Discount")
+	Equal(t, fe.Param(), "Method invoice Admin true")
+
+	PanicMatches(t, func() {
+		_ = validate.Struct(struct {
+			Method string
+			Card   string `validate:"excluded_if=Method"`
+		}{})
+	}, "Bad param number for excluded_if Card")
+}
+
+func TestExcludedUnless(t *testing.T) {
+	validate := New()
+
+	type User struct {
+		Role         string
+		DiscountCode string `validate:"excluded_unless=Role customer Role partner"`
+	}
+
+	errs := validate.Struct(User{Role: "admin"})
+	Equal(t, errs, nil)
+
+	errs = validate.Struct(User{Role: "customer", DiscountCode: "SAVE10"})
+	Equal(t, errs, nil)
+
+	errs = validate.Struct(User{Role: "partner", DiscountCode: "SAVE10"})
+	Equal(t, errs, nil)
+
+	errs = validate.Struct(User{Role: "admin", DiscountCode: "SAVE10"})
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "User.DiscountCode", "User.DiscountCode", "DiscountCode", "DiscountCode", "excluded_unless")
+
+	fe := getError(errs, "User.DiscountCode", "User.DiscountCode")
+	Equal(t, fe.Param(), "Role customer Role partner")
+}
+
+func TestExcludedWith(t *testing.T) {
+	validate := New()
+
+	type Inner struct {
+		Field *string
+	}
+
+	fieldVal := "test"
+	type Test struct {
+		Inner  *Inner
+		Field1 string            `validate:"omitempty"`
+		Field2 *string           `validate:"excluded_with=Field1"`
+		Field3 map[string]string `validate:"excluded_with=Field2"`
+		Field4 interface{}       `validate:"excluded_with=Field3"`
+		Field5 string            `validate:"excluded_with=Inner.Field"`
+		Field6 string            `validate:"excluded_with=Field1 Inner.Field"`
+	}
+
+	test := Test{
+		Inner:  &Inner{},
+		Field3: map[string]string{"key": "val"},
+		Field5: "test",
+		Field6: "test",
+	}
+
+	errs := validate.Struct(test)
+	Equal(t, errs, nil)
+
+	test = Test{
+		Inner:  &Inner{Field: &fieldVal},
+		Field1: "test",
+		Field2: &fieldVal,
+		Field3: map[string]string{"key": "val"},
+		Field4: "test",
+		Field5: "test",
+		Field6: "test",
+	}
+
+	errs = validate.Struct(test)
+	NotEqual(t, errs, nil)
+
+	ve := errs.(ValidationErrors)
+	Equal(t, len(ve), 5)
+	AssertError(t, errs, "Test.Field2", "Test.Field2", "Field2", "Field2", "excluded_with")
+	AssertError(t, errs, "Test.Field3", "Test.Field3", "Field3", "Field3", "excluded_with")
+	AssertError(t, errs, "Test.Field4", "Test.Field4", "Field4", "Field4", "excluded_with")
+	AssertError(t, errs, "Test.Field5", "Test.Field5", "Field5", "Field5", "excluded_with")
+	AssertError(t, errs, "Test.Field6", "Test.Field6", "Field6", "Field6", "excluded_with")
+}
+
+func TestExcludedWithAll(t *testing.T) {
+	validate := New()
+
+	type Test struct {
+		Field1 string
+		Field2 *string
+		Field3 string `validate:"excluded_with_all=Field1 Field2"`
+	}
+
+	fieldVal := "test"
+
+	errs := validate.Struct(Test{Field1: "test", Field3: "test"})
+	Equal(t, errs, nil)
+
+	errs = validate.Struct(Test{Field1: "test", Field2: &fieldVal, Field3: "test"})
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "Test.Field3", "Test.Field3", "Field3", "Field3", "excluded_with_all")
+}
+
+func TestExcludedWithout(t *testing.T) {
+	validate := New()
+
+	type Test struct {
+		Field1 string
+		Field2 *string
+		Field3 string  `validate:"excluded_without=Field1 Field2"`
+		Field4 *string `validate:"excluded_without=Field1"`
+	}
+
+	fieldVal := "test"
+
+	errs := validate.Struct(Test{Field1: "test", Field2: &fieldVal, Field3: "test", Field4: &fieldVal})
+	Equal(t, errs, nil)
+
+	errs = validate.Struct(Test{Field2: &fieldVal})
+	Equal(t, errs, nil)
+
+	errs = validate.Struct(Test{Field1: "test", Field3: "test", Field4: &fieldVal})
+	NotEqual(t, errs, nil)
+
+	ve := errs.(ValidationErrors)
+	Equal(t, len(ve), 1)
+	AssertError(t, errs, "Test.Field3", "Test.Field3", "Field3", "Field3", "excluded_without")
+}
+
+func TestExcludedWithoutAll(t *testing.T) {
+	validate := New()
+
+	type Test struct {
+		Field1 string
+		Field2 *string
+		Field3 string `validate:"excluded_without_all=Field1 Field2"`
+	}
+
+	errs := validate.Struct(Test{Field1: "test", Field3: "test"})
+	Equal(t, errs, nil)
+
+	errs = validate.Struct(Test{Field3: "test"})
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "Test.Field3", "Test.Field3", "Field3", "Field3", "excluded_without_all")
+}
+
+func TestConditionalTagsSkipValueTags(t *testing.T) {
+	validate := New()
+
+	tests := []struct {
+		tag   string
+		name  string
+		email string
+		err   string
+	}{
+		{tag: "required_with=Name,min=3"},
+		{tag: "required_with=Name,min=3", name: "joeybloggs", err: "required_with"},
+		{tag: "required_with=Name,min=3", name: "joeybloggs", email: "ab", err: "min"},
+		{tag: "required_with=Name,min=3", email: "ab", err: "min"},
+		{tag: "required_with=Name,required", err: "required"},
+		{tag: "required_without=Name,min=3", name: "joeybloggs"},
+		{tag: "required_without=Name,required", name: "joeybloggs", err: "required"},
+		{tag: "required_without=Name,min=3", err: "required_without"},
+		{tag: "required_with_all=Name,min=3"},
+		{tag: "required_with_all=Name,min=3", name: "joeybloggs", err: "required_with_all"},
+		{tag: "required_without_all=Name,min=3", name: "joeybloggs"},
+		{tag: "required_without_all=Name,min=3", err: "required_without_all"},
+		{tag: "excluded_if=Name joeybloggs,min=3"},
+		{tag: "excluded_unless=Name joeybloggs,min=3"},
+		{tag: "excluded_with=Name,min=3", name: "joeybloggs"},
+		{tag: "excluded_with_all=Name,min=3", name: "joeybloggs"},
+		{tag: "excluded_without=Name,min=3"},
+		{tag: "excluded_without_all=Name,min=3"},
+		{tag: "excluded_without=Name,min=3", email: "a@b.co", err: "excluded_without"},
+		{tag: "excluded_with=Name,min=3", email: "ab", err: "min"},
+		{tag: "required_with=Name,required_without=Name,min=3", err: "required_without"},
+		{tag: "required_with=Name,email,required_without=Name", err: "required_without"},
+		{tag: "expr=Name == '',min=3", err: "min"},
+		{tag: "expr=Name == '',required", err: "required"},
+	}
+
+	for i, tt := range tests {
+
+		// a pointer, nil when empty, and a value must behave the same
+		for _, ptr := range []bool{true, false} {
+
+			email := reflect.ValueOf(tt.email)
+
+			if ptr {
+				email = reflect.Zero(reflect.PtrTo(email.Type()))
+
+				if len(tt.email) > 0 {
+					email = reflect.ValueOf(&tt.email)
+				}
+			}
+
+			typ := reflect.StructOf([]reflect.StructField{
+				{Name: "Name", Type: reflect.TypeOf("")},
+				{Name: "Email", Type: email.Type(), Tag: reflect.StructTag(`validate:"` + tt.tag + `"`)},
+			})
+
+			val := reflect.New(typ).Elem()
+			val.Field(0).SetString(tt.name)
+			val.Field(1).Set(email)
+
+			errs := validate.Struct(val.Interface())
+
+			if len(tt.err) == 0 {
+				if errs != nil {
+					t.Fatalf("Index: %d pointer: %t expected no error got %s", i, ptr, errs)
+				}
+				continue
+			}
+
+			NotEqual(t, errs, nil)
+			Equal(t, len(errs.(ValidationErrors)), 1)
+			Equal(t, errs.(ValidationErrors)[0].Tag(), tt.err)
+		}
+	}
+}
+
+func TestInvalidTagErrors(t *testing.T) {
+	validate := New()
+	validate.SetPanicOnInvalidTag(false)
+
+	type Test struct {
+		Name string `validate:"required,zzxxBadFunction"`
+	}
+
+	err := validate.Struct(Test{Name: "joeybloggs"})
+	NotEqual(t, err, nil)
+
+	tagErr, ok := err.(*InvalidTagError)
+	Equal(t, ok, true)
+	Equal(t, tagErr.Namespace, "Test.Name")
+	Equal(t, tagErr.Tag, "zzxxBadFunction")
+	Equal(t, tagErr.Reason, "Undefined validation function 'zzxxBadFunction' on field 'Name'")
+	Equal(t, tagErr.Error(), "validator: invalid tag 'zzxxBadFunction' on field 'Test.Name': Undefined validation function 'zzxxBadFunction' on field 'Name'")
+
+	// not cached, so must be reported every time
+	err = validate.Struct(&Test{})
+	NotEqual(t, err, nil)
+	_, ok = err.(*InvalidTagError)
+	Equal(t, ok, true)
+
+	type Inner struct {
+		Name string `validate:"rgb||len=13"`
+	}
+
+	type Outer struct {
+		Inners []Inner `validate:"dive"`
+	}
+
+	err = validate.Struct(Outer{Inners: []Inner{{}}})
+	NotEqual(t, err, nil)
+
+	tagErr = err.(*InvalidTagError)
+	Equal(t, tagErr.Namespace, "Outer.Inners[0].Name")
+	Equal(t, tagErr.Tag, "rgb||len=13")
+	Equal(t, tagErr.Reason, "Invalid validation tag on field 'Name'")
+
+	type BadDive struct {
+		Name string `validate:"dive,required"`
+	}
+
+	err = validate.Struct(BadDive{Name: "joeybloggs"})
+	NotEqual(t, err, nil)
+
+	tagErr = err.(*InvalidTagError)
+	Equal(t, tagErr.Namespace, "BadDive.Name")
+	Equal(t, tagErr.Tag, "dive")
+	Equal(t, tagErr.Reason, "dive error! can't dive on a non slice or map")
+
+	type BadKind struct {
+		Values []float64 `validate:"required,dive,oneof=1 2"`
+	}
+
+	err = validate.Struct(BadKind{Values: []float64{1}})
+	NotEqual(t, err, nil)
+
+	tagErr = err.(*InvalidTagError)
+	Equal(t, tagErr.Namespace, "BadKind.Values[0]")
+	Equal(t, tagErr.Tag, "oneof")
+	Equal(t, tagErr.Reason, "Bad field type float64")
+
+	err = validate.Var(1, "keys,eq=1,endkeys")
+	NotEqual(t, err, nil)
+
+	tagErr = err.(*InvalidTagError)
+	Equal(t, tagErr.Namespace, "")
+	Equal(t, tagErr.Tag, "keys")
+	Equal(t, tagErr.Reason, "'keys' tag must be immediately preceded by the 'dive' tag")
+	Equal(t, tagErr.Error(), "validator: invalid tag 'keys': 'keys' tag must be immediately preceded by the 'dive' tag")
+
+	err = validate.Var(1.0, "unique")
+	NotEqual(t, err, nil)
+
+	tagErr = err.(*InvalidTagError)
+	Equal(t, tagErr.Tag, "unique")
+	Equal(t, tagErr.Reason, "Bad field type float64")
+
+	err = validate.VarWithValue("a", "b", "zzxxBadFunction")
+	NotEqual(t, err, nil)
+
+	tagErr = err.(*InvalidTagError)
+	Equal(t, tagErr.Tag, "zzxxBadFunction")
+
+	// validation still works as normal after recovering from invalid tags
+	err = validate.Var("", "required")
+	NotEqual(t, err, nil)
+	AssertError(t, err, "", "", "", "", "required")
+
+	err = validate.StructPartial(BadDive{Name: "joeybloggs"}, "Name")
+	NotEqual(t, err, nil)
+	_, ok = err.(*InvalidTagError)
+	Equal(t, ok, true)
+
+	err = validate.StructExcept(BadDive{Name: "joeybloggs"})
+	NotEqual(t, err, nil)
+	_, ok = err.(*InvalidTagError)
+	Equal(t, ok, true)
+
+	err = validate.StructFiltered(BadDive{Name: "joeybloggs"}, func(ns []byte) bool { return false })
+	NotEqual(t, err, nil)
+	_, ok = err.(*InvalidTagError)
+	Equal(t, ok, true)
+
+	// panics not caused by invalid tags are left alone
+	err = validate.RegisterValidation("panics", func(fl FieldLevel) bool {
+		panic(fmt.Errorf("custom failure"))
+	})
+	Equal(t, err, nil)
+
+	PanicMatches(t, func() { _ = validate.Var("a", "panics") }, "custom failure")
+
+	// including string panics, which baked in validations use for invalid tags
+	err = validate.RegisterValidation("panics_string", func(fl FieldLevel) bool {
+		panic("custom string failure")
+	})
+	Equal(t, err, nil)
+
+	PanicMatches(t, func() { _ = validate.Var("a", "panics_string") }, "custom string failure")
+
+	type Custom struct {
+		Name string `validate:"panics_string"`
+	}
+
+	PanicMatches(t, func() { _ = validate.Struct(Custom{Name: "joeybloggs"}) }, "custom string failure")
+
+	// a custom validation replacing a baked in one isn't treated as baked in
+	other := New()
+	other.SetPanicOnInvalidTag(false)
+
+	err = other.RegisterValidation("min", func(fl FieldLevel) bool {
+		panic("Bad field type float64")
+	})
+	Equal(t, err, nil)
+
+	PanicMatches(t, func() { _ = other.Var(1.0, "min=1") }, "Bad field type float64")
+
+	type Valid struct {
+		Name string `validate:"required"`
+	}
+
+	validate.RegisterStructValidation(func(sl StructLevel) {
+		panic("struct level failure")
+	}, Valid{})
+
+	PanicMatches(t, func() { _ = validate.Struct(Valid{Name: "joeybloggs"}) }, "struct level failure")
+
+	// nor those of custom type funcs and modifiers, run after the baked in validations of other fields
+	type Wrapped struct{ Value string }
+
+	type Converted struct {
+		Name    string  `validate:"required"`
+		Wrapped Wrapped `validate:"required"`
+	}
+
+	validate.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		panic("custom type failure")
+	}, Wrapped{})
+
+	PanicMatches(t, func() { _ = validate.Struct(Converted{Name: "joeybloggs"}) }, "custom type failure")
+
+	err = validate.RegisterModifier("panics_mod", func(ctx context.Context, field reflect.Value, param string) {
+		panic("modifier failure")
+	})
+	Equal(t, err, nil)
+
+	type Modified struct {
+		Name  string `validate:"required"`
+		Inner struct {
+			Name string `mod:"panics_mod"`
+		}
+	}
+
+	PanicMatches(t, func() { _ = validate.Struct(&Modified{Name: "joeybloggs"}) }, "modifier failure")
+
+	validate.SetPanicOnInvalidTag(true)
+	PanicMatches(t, func() { _ = validate.Var(1.0, "unique") }, "Bad field type float64")
+}
+
+func TestCompile(t *testing.T) {
+
+	type Inner struct {
+		Name  string            `validate:"required,zzxxBadFunction"`
+		Tags  []string          `validate:"dive,required"`
+		Attrs map[string]string `validate:"dive,keys,min=1,endkeys,required"`
+	}
+
+	type Recursive struct {
+		Child *Recursive
+		Name  string `validate:"required"`
+	}
+
+	type Outer struct {
+		Inner     Inner
+		Inners    []*Inner          `validate:"dive"`
+		ByName    map[string]Inner  `validate:"required"`
+		Age       int               `validate:"dive,min=1"`
+		Ages      []int             `validate:"dive,dive,min=1"`
+		Nested    [][]int           `validate:"dive,dive,min=1"`
+		Bad       string            `validate:"rgb||len=13"`
+		Keys      map[string]string `validate:"keys,endkeys"`
+		Ignored   int               `validate:"-"`
+		Recursive Recursive
+		Any       interface{} `validate:"dive"`
+		Created   time.Time   `validate:"required"`
+	}
+
+	validate := New()
+
+	err := validate.Compile(reflect.TypeOf(Outer{}))
+	NotEqual(t, err, nil)
+
+	errs, ok := err.(InvalidTagErrors)
+	Equal(t, ok, true)
+	Equal(t, len(errs), 5)
+
+	// a struct's own invalid tags come first, followed by those found walking its fields
+	Equal(t, errs[0].Namespace, "Outer.Bad")
+	Equal(t, errs[0].Tag, "rgb||len=13")
+	Equal(t, errs[0].Reason, "Invalid validation tag on field 'Bad'")
+
+	Equal(t, errs[1].Namespace, "Outer.Keys")
+	Equal(t, errs[1].Tag, "keys")
+	Equal(t, errs[1].Reason, "'keys' tag must be immediately preceded by the 'dive' tag")
+
+	Equal(t, errs[2].Namespace, "Outer.Inner.Name")
+	Equal(t, errs[2].Tag, "zzxxBadFunction")
+	Equal(t, errs[2].Reason, "Undefined validation function 'zzxxBadFunction' on field 'Name'")
+
+	Equal(t, errs[3].Namespace, "Outer.Age")
+	Equal(t, errs[3].Tag, "dive")
+	Equal(t, errs[3].Reason, "dive error! can't dive on a non slice or map")
+
+	Equal(t, errs[4].Namespace, "Outer.Ages[]")
+	Equal(t, errs[4].Tag, "dive")
+	Equal(t, errs[4].Reason, "dive error! can't dive on a non slice or map")
+
+	Equal(t, err.Error(), "validator: invalid tag 'rgb||len=13' on field 'Outer.Bad': Invalid validation tag on field 'Bad'\n"+
+		"validator: invalid tag 'keys' on field 'Outer.Keys': '"+keysTag+"' tag must be immediately preceded by the 'dive' tag\n"+
+		"validator: invalid tag 'zzxxBadFunction' on field 'Outer.Inner.Name': Undefined validation function 'zzxxBadFunction' on field 'Name'\n"+
+		"validator: invalid tag 'dive' on field 'Outer.Age': dive error! can't dive on a non slice or map\n"+
+		"validator: invalid tag 'dive' on field 'Outer.Ages[]': dive error! can't dive on a non slice or map")
+
+	// structs containing invalid tags are never cached, valid ones are
+	_, ok = validate.structCache.Get(reflect.TypeOf(Outer{}))
+	Equal(t, ok, false)
+
+	_, ok = validate.structCache.Get(reflect.TypeOf(Inner{}))
+	Equal(t, ok, false)
+
+	_, ok = validate.structCache.Get(reflect.TypeOf(Recursive{}))
+	Equal(t, ok, true)
+
+	_, ok = validate.tagCache.Get("dive,dive,min=1")
+	Equal(t, ok, true)
+
+	type Valid struct {
+		Name  string              `json:"name" validate:"required"`
+		Items []*Recursive        `json:"items" validate:"required,dive"`
+		Map   map[string][]string `json:"map" validate:"dive,keys,min=1,endkeys,dive,required"`
+	}
+
+	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		return strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+	})
+
+	err = validate.RegisterStruct(Valid{}, &Recursive{})
+	Equal(t, err, nil)
+
+	_, ok = validate.structCache.Get(reflect.TypeOf(Valid{}))
+	Equal(t, ok, true)
+
+	type Named struct {
+		Age int `json:"age" validate:"dive"`
+	}
+
+	err = validate.RegisterStruct(Valid{}, &Named{})
+	NotEqual(t, err, nil)
+
+	errs = err.(InvalidTagErrors)
+	Equal(t, len(errs), 1)
+	Equal(t, errs[0].Namespace, "Named.age")
+
+	err = validate.RegisterStruct(1)
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "validator: (nil int)")
+
+	err = validate.RegisterStruct(nil)
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "validator: (nil)")
+
+	err = validate.Compile(reflect.TypeOf(time.Time{}))
+	NotEqual(t, err, nil)
+
+	err = validate.Compile(nil)
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "validator: (nil)")
+}
+
+type SelfValidating struct {
+	Name  string `validate:"required"`
+	Alias string
+}
+
+func (s *SelfValidating) ValidateStruct(sl StructLevel) {
+
+	if s.Alias == s.Name {
+		sl.ReportError(s.Alias, "Alias", "Alias", "nefield", "Name")
+	}
+}
+
+type SelfValidatingValue struct {
+	Min int
+	Max int
+}
+
+func (s SelfValidatingValue) ValidateStruct(sl StructLevel) {
+
+	if s.Min > s.Max {
+		sl.ReportError(s.Min, "Min", "Min", "ltefield", "Max")
+	}
+}
+
+type selfValidatingCtxKey struct{}
+
+type SelfValidatingCtx struct {
+	Role string
+}
+
+func (s *SelfValidatingCtx) ValidateStruct(sl StructLevel) {
+	sl.ReportError(s.Role, "Role", "Role", "never", "")
+}
+
+func (s *SelfValidatingCtx) ValidateStructCtx(ctx context.Context, sl StructLevel) {
+
+	if allowed, _ := ctx.Value(selfValidatingCtxKey{}).(string); s.Role != allowed {
+		sl.ReportError(s.Role, "Role", "Role", "role", allowed)
+	}
+}
+
+type EmbeddingSelfValidating struct {
+	SelfValidating
+}
+
+type ShadowingSelfValidating struct {
+	SelfValidating
+	Nickname string
+}
+
+func (s ShadowingSelfValidating) ValidateStruct(sl StructLevel) {
+
+	if s.Nickname == s.Name {
+		sl.ReportError(s.Nickname, "Nickname", "Nickname", "nefield", "Name")
+	}
+}
+
+type ShadowingPtrSelfValidating struct {
+	SelfValidating
+	Nickname string
+}
+
+func (s *ShadowingPtrSelfValidating) ValidateStruct(sl StructLevel) {
+	sl.ReportError(s.Nickname, "Nickname", "Nickname", "never", "")
+}
+
+type unexportedSelfValidating struct {
+	Name string
+}
+
+func (s unexportedSelfValidating) ValidateStruct(sl StructLevel) {
+	sl.ReportError(s.Name, "Name", "Name", "unexported", "")
+}
+
+type EmbeddingUnexported struct {
+	unexportedSelfValidating
+	Name string `validate:"required"`
+}
+
+func TestValidatable(t *testing.T) {
+
+	validate := New()
+
+	s := SelfValidating{Name: "joeybloggs", Alias: "joeybloggs"}
+
+	errs := validate.Struct(s)
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "SelfValidating.Alias", "SelfValidating.Alias", "Alias", "Alias", "nefield")
+
+	errs = validate.Struct(&s)
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "SelfValidating.Alias", "SelfValidating.Alias", "Alias", "Alias", "nefield")
+
+	s.Alias = "joey"
+
+	errs = validate.Struct(s)
+	Equal(t, errs, nil)
+
+	errs = validate.Struct(SelfValidatingValue{Min: 2, Max: 1})
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "SelfValidatingValue.Min", "SelfValidatingValue.Min", "Min", "Min", "ltefield")
+
+	type Outer struct {
+		Inner   SelfValidating
+		Inners  []*SelfValidating              `validate:"dive"`
+		Ranges  map[string]SelfValidatingValue `validate:"dive"`
+		Skipped SelfValidating                 `validate:"-"`
+	}
+
+	outer := Outer{
+		Inner:   SelfValidating{Name: "a", Alias: "a"},
+		Inners:  []*SelfValidating{{Name: "a", Alias: "b"}, {Alias: ""}},
+		Ranges:  map[string]SelfValidatingValue{"key": {Min: 5}},
+		Skipped: SelfValidating{Name: "a", Alias: "a"},
+	}
+
+	errs = validate.Struct(outer)
+	NotEqual(t, errs, nil)
+
+	ve := errs.(ValidationErrors)
+	Equal(t, len(ve), 4)
+	AssertError(t, errs, "Outer.Inner.Alias", "Outer.Inner.Alias", "Alias", "Alias", "nefield")
+	AssertError(t, errs, "Outer.Inners[1].Name", "Outer.Inners[1].Name", "Name", "Name", "required")
+	AssertError(t, errs, "Outer.Inners[1].Alias", "Outer.Inners[1].Alias", "Alias", "Alias", "nefield")
+	AssertError(t, errs, "Outer.Ranges[key].Min", "Outer.Ranges[key].Min", "Min", "Min", "ltefield")
+
+	// field validations run before the struct level validation
+	Equal(t, ve[1].Namespace(), "Outer.Inners[1].Name")
+	Equal(t, ve[2].Namespace(), "Outer.Inners[1].Alias")
+
+	// ValidateStructCtx takes precedence over ValidateStruct
+	ctx := context.WithValue(context.Background(), selfValidatingCtxKey{}, "admin")
+
+	errs = validate.StructCtx(ctx, SelfValidatingCtx{Role: "admin"})
+	Equal(t, errs, nil)
+
+	errs = validate.StructCtx(ctx, SelfValidatingCtx{Role: "user"})
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "SelfValidatingCtx.Role", "SelfValidatingCtx.Role", "Role", "Role", "role")
+
+	fe := errs.(ValidationErrors)[0]
+	Equal(t, fe.Param(), "admin")
+
+	// promoted methods are only called for the embedded struct
+	errs = validate.Struct(EmbeddingSelfValidating{SelfValidating{Name: "a", Alias: "a"}})
+	NotEqual(t, errs, nil)
+
+	ve = errs.(ValidationErrors)
+	Equal(t, len(ve), 1)
+	AssertError(t, errs, "EmbeddingSelfValidating.SelfValidating.Alias", "EmbeddingSelfValidating.SelfValidating.Alias", "Alias", "Alias", "nefield")
+
+	errs = validate.Struct(ShadowingSelfValidating{SelfValidating: SelfValidating{Name: "a", Alias: "a"}, Nickname: "a"})
+	NotEqual(t, errs, nil)
+
+	ve = errs.(ValidationErrors)
+	Equal(t, len(ve), 2)
+	AssertError(t, errs, "ShadowingSelfValidating.SelfValidating.Alias", "ShadowingSelfValidating.SelfValidating.Alias", "Alias", "Alias", "nefield")
+	AssertError(t, errs, "ShadowingSelfValidating.Nickname", "ShadowingSelfValidating.Nickname", "Nickname", "Nickname", "nefield")
+
+	// shadowing a method with the same receiver can't be told from inheriting it
+	errs = validate.Struct(ShadowingPtrSelfValidating{SelfValidating: SelfValidating{Name: "a", Alias: "a"}, Nickname: "a"})
+	NotEqual(t, errs, nil)
+
+	ve = errs.(ValidationErrors)
+	Equal(t, len(ve), 1)
+	AssertError(t, errs, "ShadowingPtrSelfValidating.SelfValidating.Alias", "ShadowingPtrSelfValidating.SelfValidating.Alias", "Alias", "Alias", "nefield")
+
+	// the methods of an embedded unexported type can't be called on it, so are called by the embedding struct
+	errs = validate.Struct(EmbeddingUnexported{Name: "a"})
+	NotEqual(t, errs, nil)
+
+	ve = errs.(ValidationErrors)
+	Equal(t, len(ve), 1)
+	AssertError(t, errs, "EmbeddingUnexported.Name", "EmbeddingUnexported.Name", "Name", "Name", "unexported")
+
+	// registered struct level validations run first
+	validate = New()
+	validate.RegisterStructValidation(func(sl StructLevel) {
+		sl.ReportError(nil, "Name", "Name", "registered", "")
+	}, SelfValidating{})
+
+	errs = validate.Struct(SelfValidating{Name: "a", Alias: "a"})
+	NotEqual(t, errs, nil)
+
+	ve = errs.(ValidationErrors)
+	Equal(t, len(ve), 2)
+	Equal(t, ve[0].Tag(), "registered")
+	Equal(t, ve[1].Tag(), "nefield")
+}
+
+func TestStructGroups(t *testing.T) {
+
+	type Address struct {
+		Street string `validate:"required" validate.patch:"omitempty,min=3"`
+	}
+
+	type User struct {
+		ID       string   `json:"id" validate:"required" validate.create:"isdefault"`
+		Password string   `validate:"-" validate.create:"required,min=8"`
+		Email    string   `validate:"required,email" validate.patch:"omitempty,email"`
+		Nickname string   `validate:"max=5" validate.admin:"-"`
+		Address  Address  `validate.patch:"-"`
+		Tags     []string `validate:"dive,required" validate.patch:""`
+	}
+
+	validate := New()
+	ctx := context.Background()
+
+	u := User{Nickname: "joeybloggs", Tags: []string{""}}
+
+	errs := validate.StructCtx(ctx, u)
+	NotEqual(t, errs, nil)
+
+	ve := errs.(ValidationErrors)
+	Equal(t, len(ve), 5)
+	AssertError(t, errs, "User.ID", "User.ID", "ID", "ID", "required")
+	AssertError(t, errs, "User.Email", "User.Email", "Email", "Email", "required")
+	AssertError(t, errs, "User.Nickname", "User.Nickname", "Nickname", "Nickname", "max")
+	AssertError(t, errs, "User.Address.Street", "User.Address.Street", "Street", "Street", "required")
+	AssertError(t, errs, "User.Tags[0]", "User.Tags[0]", "Tags[0]", "Tags[0]", "required")
+
+	// no groups is the same as StructCtx
+	errs = validate.StructGroups(ctx, u)
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 5)
+
+	u = User{ID: "1", Password: "short", Email: "joeybloggs@gmail.com", Address: Address{Street: "Main"}}
+
+	errs = validate.StructGroups(ctx, u, "create")
+	NotEqual(t, errs, nil)
+
+	ve = errs.(ValidationErrors)
+	Equal(t, len(ve), 2)
+	AssertError(t, errs, "User.ID", "User.ID", "ID", "ID", "isdefault")
+	AssertError(t, errs, "User.Password", "User.Password", "Password", "Password", "min")
+
+	u.ID = ""
+	u.Password = "longenough"
+
+	errs = validate.StructGroups(ctx, &u, "create")
+	Equal(t, errs, nil)
+
+	// the password is only validated on create
+	u.ID = "1"
+	u.Password = ""
+
+	errs = validate.Struct(u)
+	Equal(t, errs, nil)
+
+	u = User{ID: "1", Nickname: "joeybloggs", Tags: []string{""}}
+
+	errs = validate.StructGroups(ctx, u, "patch")
+	NotEqual(t, errs, nil)
+
+	ve = errs.(ValidationErrors)
+	Equal(t, len(ve), 1)
+	AssertError(t, errs, "User.Nickname", "User.Nickname", "Nickname", "Nickname", "max")
+
+	u.Email = "notanemail"
+
+	errs = validate.StructGroups(ctx, u, "patch")
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "User.Email", "User.Email", "Email", "Email", "email")
+
+	// the first group a field has a tag for is used
+	u = User{ID: "1", Nickname: "joeybloggs", Email: "joeybloggs@gmail.com", Address: Address{Street: "Main"}}
+
+	errs = validate.StructGroups(ctx, u, "admin", "create")
+	NotEqual(t, errs, nil)
+
+	ve = errs.(ValidationErrors)
+	Equal(t, len(ve), 2)
+	AssertError(t, errs, "User.ID", "User.ID", "ID", "ID", "isdefault")
+	AssertError(t, errs, "User.Password", "User.Password", "Password", "Password", "required")
+
+	errs = validate.StructGroups(ctx, 1, "create")
+	NotEqual(t, errs, nil)
+	Equal(t, errs.Error(), "validator: (nil int)")
+
+	// groups are not kept once validation is done
+	errs = validate.StructCtx(ctx, User{ID: "1", Email: "joeybloggs@gmail.com", Address: Address{Street: "Main"}})
+	Equal(t, errs, nil)
+
+	type Bad struct {
+		Name string `validate:"required" validate.create:"zzxxBadFunction"`
+	}
+
+	validate.SetPanicOnInvalidTag(false)
+
+	errs = validate.StructGroups(ctx, Bad{}, "update")
+	NotEqual(t, errs, nil)
+
+	tagErr, ok := errs.(*InvalidTagError)
+	Equal(t, ok, true)
+	Equal(t, tagErr.Namespace, "Bad.Name")
+	Equal(t, tagErr.Tag, "zzxxBadFunction")
+
+	err := validate.RegisterStruct(Bad{})
+	NotEqual(t, err, nil)
+	Equal(t, len(err.(InvalidTagErrors)), 1)
+	Equal(t, err.(InvalidTagErrors)[0].Namespace, "Bad.Name")
+}
+
+func TestExtractGroupTags(t *testing.T) {
+
+	type Test struct {
+		Field string `json:"field" validate:"required" validate.create:"isdefault" validate.update:"-" validate.:"min=1" validate.patch:"" validatecreate:"max=1"`
+	}
+
+	fld, _ := reflect.TypeOf(Test{}).FieldByName("Field")
+
+	groups := extractGroupTags(fld.Tag, "validate")
+	Equal(t, len(groups), 3)
+	Equal(t, groups["create"], "isdefault")
+	Equal(t, groups["update"], "-")
+	Equal(t, groups["patch"], "")
+
+	Equal(t, len(extractGroupTags(fld.Tag, "json")), 0)
+	Equal(t, len(extractGroupTags(`validate.create:"a\"b"`, "validate")), 1)
+	Equal(t, extractGroupTags(`validate.create:"a\"b"`, "validate")["create"], `a"b`)
+	Equal(t, len(extractGroupTags(`validate.create:"unterminated`, "validate")), 0)
+}
+
+func TestValidationErrorsJSON(t *testing.T) {
+
+	type Inner struct {
+		Ptr *string `json:"ptr" validate:"required"`
+	}
+
+	type Test struct {
+		Name  string            `json:"name" validate:"required"`
+		Age   uint8             `json:"age" validate:"gte=18"`
+		Ratio float64           `json:"ratio" validate:"lt=1"`
+		Tags  []string          `json:"tags" validate:"min=1"`
+		Meta  map[string]string `json:"meta" validate:"dive,len=2"`
+		Inner Inner             `json:"inner"`
+	}
+
+	validate := New()
+	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		return strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+	})
+
+	errs := validate.Struct(Test{Age: 17, Ratio: 1.5, Tags: []string{}, Meta: map[string]string{"a": "abc"}})
+	NotEqual(t, errs, nil)
+
+	ve := errs.(ValidationErrors)
+	Equal(t, len(ve), 6)
+
+	b, err := json.Marshal(ve)
+	Equal(t, err, nil)
+
+	var raw []map[string]interface{}
+
+	err = json.Unmarshal(b, &raw)
+	Equal(t, err, nil)
+	Equal(t, len(raw), 6)
+
+	Equal(t, raw[1], map[string]interface{}{
+		"namespace":        "Test.age",
+		"struct_namespace": "Test.Age",
+		"field":            "age",
+		"struct_field":     "Age",
+		"tag":              "gte",
+		"actual_tag":       "gte",
+		"param":            "18",
+		"kind":             "uint8",
+		"type":             "uint8",
+		"value":            float64(17),
+	})
+
+	// slices and pointers don't include their value
+	_, ok := raw[3]["value"]
+	Equal(t, ok, false)
+	Equal(t, raw[3]["kind"], "slice")
+	Equal(t, raw[3]["type"], "[]string")
+
+	_, ok = raw[5]["value"]
+	Equal(t, ok, false)
+	Equal(t, raw[5]["kind"], "ptr")
+	Equal(t, raw[5]["namespace"], "Test.inner.ptr")
+
+	_, ok = raw[0]["message"]
+	Equal(t, ok, false)
+
+	var actual ValidationErrors
+
+	err = json.Unmarshal(b, &actual)
+	Equal(t, err, nil)
+	Equal(t, len(actual), len(ve))
+
+	for i := 0; i < len(ve); i++ {
+
+		e, a := ve[i], actual[i]
+
+		Equal(t, a.Tag(), e.Tag())
+		Equal(t, a.ActualTag(), e.ActualTag())
+		Equal(t, a.Namespace(), e.Namespace())
+		Equal(t, a.StructNamespace(), e.StructNamespace())
+		Equal(t, a.Field(), e.Field())
+		Equal(t, a.StructField(), e.StructField())
+		Equal(t, a.Param(), e.Param())
+		Equal(t, a.Kind(), e.Kind())
+		Equal(t, a.(error).Error(), e.(error).Error())
+	}
+
+	Equal(t, actual.Error(), ve.Error())
+
+	// basic types, and their values, are reconstructed
+	Equal(t, actual[0].Value(), "")
+	Equal(t, actual[0].Type() == reflect.TypeOf(""), true)
+	Equal(t, actual[1].Value(), uint8(17))
+	Equal(t, actual[1].Type() == reflect.TypeOf(uint8(0)), true)
+	Equal(t, actual[2].Value(), 1.5)
+	Equal(t, actual[3].Value(), nil)
+	Equal(t, actual[3].Type(), nil)
+	Equal(t, actual[4].Value(), "abc")
+	Equal(t, actual[4].Namespace(), "Test.meta[a]")
+
+	// marshaling again is stable
+	b2, err := json.Marshal(actual)
+	Equal(t, err, nil)
+	Equal(t, string(b2), string(b))
+
+	b, err = json.Marshal(ValidationErrors(nil))
+	Equal(t, err, nil)
+	Equal(t, string(b), "[]")
+
+	b, err = json.Marshal(ve[0])
+	Equal(t, err, nil)
+	Equal(t, string(b), `{"namespace":"Test.name","struct_namespace":"Test.Name","field":"name","struct_field":"Name","tag":"required","actual_tag":"required","param":"","kind":"string","type":"string","value":""}`)
+
+	// translated
+	eng := en.New()
+	uni := ut.New(eng, eng)
+	trans, _ := uni.GetTranslator("en")
+
+	err = validate.RegisterTranslation("required", trans,
+		func(ut ut.Translator) error {
+			return ut.Add("required", "{0} is a required field", false)
+		}, func(ut ut.Translator, fe FieldError) string {
+			t, _ := ut.T(fe.Tag(), fe.Field())
+			return t
+		})
+	Equal(t, err, nil)
+
+	b, err = ve.MarshalJSONTranslated(trans)
+	Equal(t, err, nil)
+
+	raw = nil
+	err = json.Unmarshal(b, &raw)
+	Equal(t, err, nil)
+	Equal(t, raw[0]["message"], "name is a required field")
+	Equal(t, raw[1]["message"], ve[1].(error).Error())
+
+	actual = nil
+	err = json.Unmarshal(b, &actual)
+	Equal(t, err, nil)
+	Equal(t, actual[0].Translate(trans), actual[0].(error).Error())
+
+	// unsafe values
+	fe := validate.NewFieldError(FieldErrorOptions{Namespace: "Test.f", StructNamespace: "Test.F", Field: "f", StructField: "F", Tag: "gt", Param: "0", Value: math.NaN()})
+
+	b, err = json.Marshal(fe)
+	Equal(t, err, nil)
+	Equal(t, strings.Contains(string(b), `"value"`), false)
+
+	fe = validate.NewFieldError(FieldErrorOptions{Tag: "required"})
+
+	b, err = json.Marshal(ValidationErrors{fe})
+	Equal(t, err, nil)
+	Equal(t, string(b), `[{"namespace":"","struct_namespace":"","field":"","struct_field":"","tag":"required","actual_tag":"required","param":"","kind":"invalid","type":""}]`)
+
+	err = json.Unmarshal(b, &actual)
+	Equal(t, err, nil)
+	Equal(t, len(actual), 1)
+	Equal(t, actual[0].Kind(), reflect.Invalid)
+	Equal(t, actual[0].Value(), nil)
+
+	// named types keep their name
+	err = json.Unmarshal([]byte(`[{"namespace":"T.s","struct_namespace":"T.S","field":"s","struct_field":"S","tag":"oneof","actual_tag":"oneof","param":"a b","kind":"string","type":"main.Status","value":"c"}]`), &actual)
+	Equal(t, err, nil)
+	Equal(t, actual[0].Value(), "c")
+	Equal(t, actual[0].Type(), nil)
+
+	b, err = json.Marshal(actual)
+	Equal(t, err, nil)
+	Equal(t, strings.Contains(string(b), `"type":"main.Status"`), true)
+
+	// invalid
+	err = json.Unmarshal([]byte(`[{"namespace":"T.s","field":"x","kind":"string"}]`), &actual)
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "validator: field 'x' is not within namespace 'T.s'")
+
+	err = json.Unmarshal([]byte(`[{"namespace":"T.s","field":"s","kind":"unknown"}]`), &actual)
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "validator: unknown kind 'unknown'")
+
+	err = json.Unmarshal([]byte(`[{"namespace":"T.s","field":"s","kind":"int","type":"int","value":"a"}]`), &actual)
+	NotEqual(t, err, nil)
+
+	err = json.Unmarshal([]byte(`{}`), &actual)
+	NotEqual(t, err, nil)
+}
+
+func TestFieldErrorPath(t *testing.T) {
+
+	type Address struct {
+		Zip string `json:"zip" validate:"required"`
+	}
+
+	type User struct {
+		Name      string                    `json:"full name" validate:"required"`
+		Addresses []Address                 `json:"addresses" validate:"dive"`
+		Tags      map[string][]string       `json:"tags" validate:"dive,keys,min=5,endkeys,dive,required"`
+		Scores    map[int]int               `json:"scores" validate:"dive,gt=0"`
+		Nested    [][]*Address              `json:"nested" validate:"dive,dive,required"`
+		ByKey     map[string]Address        `json:"by_key" validate:"dive"`
+		Opt       *Address                  `json:"opt"`
+		Grid      [2]map[string]interface{} `json:"grid" validate:"dive,dive,required"`
+	}
+
+	validate := New()
+	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		return strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+	})
+
+	u := User{
+		Addresses: []Address{{Zip: "1"}, {}},
+		Tags:      map[string][]string{"a.b]": {"", "x"}},
+		Scores:    map[int]int{7: 0},
+		Nested:    [][]*Address{nil, {{Zip: "1"}, nil}},
+		ByKey:     map[string]Address{"k/~": {}},
+		Opt:       &Address{},
+		Grid:      [2]map[string]interface{}{nil, {"x": nil}},
+	}
+
+	errs := validate.Struct(u)
+	NotEqual(t, errs, nil)
+
+	ve := errs.(ValidationErrors)
+	Equal(t, len(ve), 9)
+
+	type expected struct {
+		ns, str, pointer, jsonPath string
+		path                       Path
+	}
+
+	tests := []expected{
+		{
+			ns: "User.full name", str: "full name", pointer: "/full name", jsonPath: "$['full name']",
+			path: Path{{Kind: FieldSegment, Name: "full name", StructName: "Name"}},
+		},
+		{
+			ns: "User.addresses[1].zip", str: "addresses[1].zip", pointer: "/addresses/1/zip", jsonPath: "$.addresses[1].zip",
+			path: Path{{Kind: FieldSegment, Name: "addresses", StructName: "Addresses"}, {Kind: IndexSegment, Index: 1}, {Kind: FieldSegment, Name: "zip", StructName: "Zip"}},
+		},
+		{
+			ns: "User.tags[a.b]", str: "tags[a.b]", pointer: "/tags/a.b]", jsonPath: "$.tags['a.b]']",
+		},
+		{
+			ns: "User.tags[a.b][0]", str: "tags[a.b]][0]", pointer: "/tags/a.b]/0", jsonPath: "$.tags['a.b]'][0]",
+		},
+		{
+			ns: "User.scores[7]", str: "scores[7]", pointer: "/scores/7", jsonPath: "$.scores['7']",
+		},
+		{
+			ns: "User.nested[1][1]", str: "nested[1][1]", pointer: "/nested/1/1", jsonPath: "$.nested[1][1]",
+		},
+		{
+			ns: "User.by_key[k/~].zip", str: "by_key[k/~].zip", pointer: "/by_key/k~1~0/zip", jsonPath: "$.by_key['k/~'].zip",
+		},
+		{
+			ns: "User.opt.zip", str: "opt.zip", pointer: "/opt/zip", jsonPath: "$.opt.zip",
+		},
+		{
+			ns: "User.grid[1][x]", str: "grid[1][x]", pointer: "/grid/1/x", jsonPath: "$.grid[1]['x']",
+		},
+	}
+
+	// the namespace of the map key is ambiguous
+	tests[2].ns = "User.tags[a.b]]"
+	tests[2].str = "tags[a.b]]"
+	tests[3].ns = "User.tags[a.b]][0]"
+
+	for i, tt := range tests {
+
+		fe := ve[i]
+
+		Equal(t, fe.Namespace(), tt.ns)
+		Equal(t, fe.Path().String(), tt.str)
+		Equal(t, fe.Path().JSONPointer(), tt.pointer)
+		Equal(t, fe.Path().JSONPath(), tt.jsonPath)
+
+		if tt.path != nil {
+			Equal(t, fe.Path(), tt.path)
+		}
+	}
+
+	// map keys hold their original value
+	path := ve[3].Path()
+	Equal(t, len(path), 3)
+	Equal(t, path[1].Kind, KeySegment)
+	Equal(t, path[1].Key.Interface(), "a.b]")
+	Equal(t, path[2].Kind, IndexSegment)
+	Equal(t, path[2].Index, 0)
+
+	path = ve[4].Path()
+	Equal(t, path[1].Kind, KeySegment)
+	Equal(t, path[1].Key.Interface(), 7)
+
+	// paths are not shared between errors
+	Equal(t, ve[1].Path()[1].Index, 1)
+	Equal(t, ve[5].Path()[2].Index, 1)
+
+	// Var
+	errs = validate.Var([]map[string]string{{"a": ""}}, "dive,dive,required")
+	NotEqual(t, errs, nil)
+
+	fe := errs.(ValidationErrors)[0]
+	Equal(t, fe.Namespace(), "[0][a]")
+	Equal(t, fe.Path().String(), "[0][a]")
+	Equal(t, fe.Path().JSONPointer(), "/0/a")
+	Equal(t, fe.Path().JSONPath(), "$[0]['a']")
+
+	errs = validate.Var("", "required")
+	NotEqual(t, errs, nil)
+
+	fe = errs.(ValidationErrors)[0]
+	Equal(t, len(fe.Path()), 0)
+	Equal(t, fe.Path().String(), "")
+	Equal(t, fe.Path().JSONPointer(), "")
+	Equal(t, fe.Path().JSONPath(), "$")
+
+	// struct level
+	validate = New()
+	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		return strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+	})
+	validate.RegisterStructValidation(func(sl StructLevel) {
+		sl.ReportError(sl.Current().Field(0).Interface(), "zip", "Zip", "zipcode", "")
+		sl.ReportError(nil, "codes[0]", "Codes[0]", "required", "")
+	}, Address{})
+
+	errs = validate.Struct(User{Name: "a", Addresses: []Address{{}}})
+	NotEqual(t, errs, nil)
+
+	ve = errs.(ValidationErrors)
+	Equal(t, len(ve), 3)
+	Equal(t, ve[1].Namespace(), "User.addresses[0].zip")
+	Equal(t, ve[1].Path(), Path{
+		{Kind: FieldSegment, Name: "addresses", StructName: "Addresses"},
+		{Kind: IndexSegment, Index: 0},
+		{Kind: FieldSegment, Name: "zip", StructName: "Zip"},
+	})
+	Equal(t, ve[2].Path().JSONPointer(), "/addresses/0/codes/0")
+	Equal(t, ve[2].Path()[2].StructName, "Codes")
+
+	// parsed from the namespaces
+	fe = validate.NewFieldError(FieldErrorOptions{Namespace: "User.tags[a][01][2].full name", StructNamespace: "User.Tags[a][01][2].Name", Field: "full name", StructField: "Name", Tag: "required"})
+	Equal(t, fe.Path().String(), "tags[a][01][2].full name")
+	Equal(t, fe.Path()[0], PathSegment{Kind: FieldSegment, Name: "tags", StructName: "Tags"})
+	Equal(t, fe.Path()[1].Key.Interface(), "a")
+	Equal(t, fe.Path()[2].Key.Interface(), "01")
+	Equal(t, fe.Path()[3], PathSegment{Kind: IndexSegment, Index: 2})
+	Equal(t, fe.Path()[4], PathSegment{Kind: FieldSegment, Name: "full name", StructName: "Name"})
+
+	fe = validate.NewFieldError(FieldErrorOptions{Namespace: "User.tags[a", StructNamespace: "User.Tags", Tag: "required"})
+	Equal(t, fe.Path().String(), "tags[a]")
+	Equal(t, fe.Path()[0].StructName, "tags")
+
+	fe = validate.NewFieldError(FieldErrorOptions{Namespace: "User", Field: "User", Tag: "required"})
+	Equal(t, len(fe.Path()), 0)
+	Equal(t, fe.StructNamespace(), "User")
+	Equal(t, fe.StructField(), "User")
+	Equal(t, fe.ActualTag(), "required")
+
+	Equal(t, Path{{Kind: FieldSegment, Name: "a'b\\"}, {Kind: KeySegment}}.JSONPath(), `$['a\'b\\']['']`)
+	Equal(t, Path{{Kind: FieldSegment, Name: "_a1"}, {Kind: FieldSegment, Name: "1a"}, {Kind: FieldSegment, Name: "é"}}.JSONPath(), "$._a1['1a'].é")
+}
+
+func TestMaxErrors(t *testing.T) {
+
+	type Row struct {
+		ID   int    `validate:"gt=0"`
+		Name string `validate:"required"`
+	}
+
+	type Upload struct {
+		Owner string         `validate:"required"`
+		Rows  []Row          `validate:"dive"`
+		Meta  map[string]int `validate:"dive,keys,min=2,endkeys,gt=0"`
+		Last  string         `validate:"required"`
+	}
+
+	upload := Upload{Rows: make([]Row, 100)}
+
+	validate := New()
+	ctx := context.Background()
+
+	errs := validate.Struct(upload)
+	NotEqual(t, errs, nil)
+
+	ve := errs.(ValidationErrors)
+	Equal(t, len(ve), 202)
+	Equal(t, ve.Truncated(), false)
+
+	errs = validate.StructWithOptions(ctx, upload, ValidateOptions{FailFast: true})
+	NotEqual(t, errs, nil)
+
+	ve = errs.(ValidationErrors)
+	Equal(t, len(ve), 1)
+	Equal(t, ve.Truncated(), true)
+	AssertError(t, errs, "Upload.Owner", "Upload.Owner", "Owner", "Owner", "required")
+
+	// stops within dive
+	errs = validate.StructWithOptions(ctx, upload, ValidateOptions{MaxErrors: 4})
+	NotEqual(t, errs, nil)
+
+	ve = errs.(ValidationErrors)
+	Equal(t, len(ve), 4)
+	Equal(t, ve.Truncated(), true)
+	AssertError(t, errs, "Upload.Rows[1].ID", "Upload.Rows[1].ID", "ID", "ID", "gt")
+	Equal(t, ve[3].Namespace(), "Upload.Rows[1].ID")
+
+	// kept when marshaled
+	b, err := json.Marshal(ve)
+	Equal(t, err, nil)
+	Equal(t, strings.Count(string(b), `"truncated":true`), 1)
+
+	var unmarshaled ValidationErrors
+
+	err = json.Unmarshal(b, &unmarshaled)
+	Equal(t, err, nil)
+	Equal(t, len(unmarshaled), 4)
+	Equal(t, unmarshaled.Truncated(), true)
+
+	// the last error being found isn't truncated
+	upload = Upload{Owner: "a", Last: ""}
+
+	errs = validate.StructWithOptions(ctx, upload, ValidateOptions{MaxErrors: 1})
+	NotEqual(t, errs, nil)
+	Equal(t, errs.(ValidationErrors).Truncated(), false)
+
+	upload = Upload{Owner: "a", Meta: map[string]int{"a": 1, "bb": 0}}
+
+	errs = validate.StructWithOptions(ctx, upload, ValidateOptions{MaxErrors: 1})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 1)
+	Equal(t, errs.(ValidationErrors).Truncated(), true)
+
+	// the key error stops the value being validated
+	errs = validate.StructWithOptions(ctx, Upload{Owner: "a", Last: "a", Meta: map[string]int{"a": 0}}, ValidateOptions{MaxErrors: 1})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 1)
+	AssertError(t, errs, "Upload.Meta[a]", "Upload.Meta[a]", "Meta[a]", "Meta[a]", "min")
+
+	// instance wide
+	validate.SetMaxErrors(3)
+
+	upload = Upload{Rows: make([]Row, 100)}
+
+	errs = validate.Struct(upload)
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 3)
+	Equal(t, errs.(ValidationErrors).Truncated(), true)
+
+	errs = validate.StructWithOptions(ctx, upload, ValidateOptions{MaxErrors: -1})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 202)
+	Equal(t, errs.(ValidationErrors).Truncated(), false)
+
+	errs = validate.StructWithOptions(ctx, upload, ValidateOptions{})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 3)
+
+	// options are not kept
+	validate.SetMaxErrors(0)
+
+	errs = validate.StructWithOptions(ctx, upload, ValidateOptions{MaxErrors: 2})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 2)
+
+	errs = validate.Struct(upload)
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 202)
+
+	errs = validate.StructWithOptions(ctx, 1, ValidateOptions{})
+	NotEqual(t, errs, nil)
+	Equal(t, errs.Error(), "validator: (nil int)")
+
+	// Var
+	errs = validate.VarWithOptions(ctx, make([]string, 1000), "dive,required", ValidateOptions{FailFast: true})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 1)
+	Equal(t, errs.(ValidationErrors).Truncated(), true)
+	Equal(t, errs.(ValidationErrors)[0].Namespace(), "[0]")
+
+	errs = validate.VarWithOptions(ctx, make([]string, 1000), "dive,required", ValidateOptions{})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 1000)
+
+	errs = validate.VarWithOptions(ctx, "", "", ValidateOptions{})
+	Equal(t, errs, nil)
+
+	// struct level
+	validate = New()
+	validate.RegisterStructValidation(func(sl StructLevel) {
+		sl.ReportError(nil, "A", "A", "a", "")
+		sl.ReportError(nil, "B", "B", "b", "")
+		sl.ReportValidationErrors("", "", ValidationErrors{
+			validate.NewFieldError(FieldErrorOptions{Namespace: "C", Field: "C", Tag: "c"}),
+			validate.NewFieldError(FieldErrorOptions{Namespace: "D", Field: "D", Tag: "d"}),
+		})
+	}, Row{})
+
+	errs = validate.StructWithOptions(ctx, Row{ID: 1, Name: "a"}, ValidateOptions{MaxErrors: 3})
+	NotEqual(t, errs, nil)
+
+	ve = errs.(ValidationErrors)
+	Equal(t, len(ve), 3)
+	Equal(t, ve.Truncated(), true)
+	Equal(t, ve[2].Namespace(), "Row.C")
+
+	errs = validate.StructWithOptions(ctx, Row{ID: 1, Name: "a"}, ValidateOptions{MaxErrors: 1})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 1)
+
+	errs = validate.StructWithOptions(ctx, Row{Name: "a"}, ValidateOptions{MaxErrors: 1})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 1)
+	Equal(t, errs.(ValidationErrors).Truncated(), true)
+	AssertError(t, errs, "Row.ID", "Row.ID", "ID", "ID", "gt")
+}
+
+func TestCycles(t *testing.T) {
+
+	type Node struct {
+		Name string `validate:"required"`
+		Next *Node
+	}
+
+	type Child struct {
+		Name   string `validate:"required"`
+		Parent interface{}
+	}
+
+	type Parent struct {
+		Name     string   `validate:"required"`
+		Children []*Child `validate:"dive"`
+	}
+
+	type Tree struct {
+		Name     string `validate:"required"`
+		Children []Tree `validate:"dive"`
+	}
+
+	validate := New()
+
+	a := &Node{}
+	b := &Node{Name: "b", Next: a}
+	a.Next = b
+
+	errs := validate.Struct(a)
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 1)
+	AssertError(t, errs, "Node.Name", "Node.Name", "Name", "Name", "required")
+
+	errs = validate.Struct(b)
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 1)
+	AssertError(t, errs, "Node.Next.Name", "Node.Next.Name", "Name", "Name", "required")
+
+	// a value is not addressable, so it's pointed to copy is validated again
+	errs = validate.Struct(*a)
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 2)
+	AssertError(t, errs, "Node.Name", "Node.Name", "Name", "Name", "required")
+	AssertError(t, errs, "Node.Next.Next.Name", "Node.Next.Next.Name", "Name", "Name", "required")
+
+	a.Next = a
+
+	errs = validate.Struct(a)
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 1)
+
+	p := &Parent{}
+	p.Children = []*Child{{Parent: p}, {Name: "b", Parent: p}}
+
+	errs = validate.Struct(p)
+	NotEqual(t, errs, nil)
+
+	ve := errs.(ValidationErrors)
+	Equal(t, len(ve), 2)
+	AssertError(t, errs, "Parent.Name", "Parent.Name", "Name", "Name", "required")
+	AssertError(t, errs, "Parent.Children[0].Name", "Parent.Children[0].Name", "Name", "Name", "required")
+
+	// shared, but not cyclic, structs are validated on each path
+	shared := &Child{}
+	p = &Parent{Name: "p", Children: []*Child{shared, shared}}
+
+	errs = validate.Struct(p)
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 2)
+	AssertError(t, errs, "Parent.Children[1].Name", "Parent.Children[1].Name", "Name", "Name", "required")
+
+	// cycle through a slice
+	trees := make([]Tree, 1)
+	trees[0].Children = trees
+
+	errs = validate.Var(trees, "dive")
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 1)
+	AssertError(t, errs, "[0].Name", "[0].Name", "Name", "Name", "required")
+
+	Equal(t, mayCycle(reflect.TypeOf(Node{})), true)
+	Equal(t, mayCycle(reflect.TypeOf(Child{})), true)
+	Equal(t, mayCycle(reflect.TypeOf(Parent{})), true)
+	Equal(t, mayCycle(reflect.TypeOf(Tree{})), true)
+	Equal(t, mayCycle(reflect.TypeOf(struct {
+		Nodes map[string]*Node
+		Time  time.Time
+	}{})), false)
+	Equal(t, mayCycle(reflect.TypeOf(struct{ M map[*Tree]int }{})), false)
+	Equal(t, mayCycle(reflect.TypeOf(struct{ Any interface{} }{})), true)
+}
+
+func TestMaxDepth(t *testing.T) {
+
+	type Node struct {
+		Name string `validate:"required"`
+		Next *Node
+	}
+
+	var head *Node
+
+	for i := 0; i < 10; i++ {
+		head = &Node{Name: "n", Next: head}
+	}
+
+	validate := New()
+	ctx := context.Background()
+
+	errs := validate.Struct(head)
+	Equal(t, errs, nil)
+
+	validate.SetMaxDepth(5)
+
+	errs = validate.Struct(head)
+	NotEqual(t, errs, nil)
+
+	depthErr, ok := errs.(*MaxDepthError)
+	Equal(t, ok, true)
+	Equal(t, depthErr.MaxDepth, 5)
+	Equal(t, depthErr.Namespace, "Node.Next.Next.Next.Next.Next")
+	Equal(t, depthErr.Error(), "validator: maximum depth of 5 exceeded at 'Node.Next.Next.Next.Next.Next'")
+
+	// errors found before the maximum depth is exceeded are not returned
+	head.Name = ""
+
+	errs = validate.Struct(head)
+	NotEqual(t, errs, nil)
+
+	_, ok = errs.(*MaxDepthError)
+	Equal(t, ok, true)
+
+	errs = validate.StructWithOptions(ctx, head, ValidateOptions{MaxDepth: -1})
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "Node.Name", "Node.Name", "Name", "Name", "required")
+
+	errs = validate.StructWithOptions(ctx, head, ValidateOptions{MaxDepth: 10})
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "Node.Name", "Node.Name", "Name", "Name", "required")
+
+	errs = validate.StructWithOptions(ctx, head, ValidateOptions{MaxDepth: 9})
+	NotEqual(t, errs, nil)
+
+	depthErr, ok = errs.(*MaxDepthError)
+	Equal(t, ok, true)
+	Equal(t, depthErr.MaxDepth, 9)
+
+	// the validate instance can be reused after aborting
+	head.Name = "n"
+	validate.SetMaxDepth(0)
+
+	errs = validate.Struct(head)
+	Equal(t, errs, nil)
+
+	errs = validate.VarWithOptions(ctx, []*Node{head}, "dive", ValidateOptions{MaxDepth: 1})
+	NotEqual(t, errs, nil)
+
+	depthErr, ok = errs.(*MaxDepthError)
+	Equal(t, ok, true)
+	Equal(t, depthErr.Namespace, "[0].Next")
+}
+
+func TestLimits(t *testing.T) {
+
+	type Row struct {
+		Email string `validate:"required,email"`
+		Color string `validate:"omitempty,rgb|hexcolor"`
+	}
+
+	type Upload struct {
+		Name string            `validate:"required"`
+		Rows []Row             `validate:"dive"`
+		Tags map[string]string `validate:"dive,required"`
+	}
+
+	rows := make([]Row, 10)
+	for i := range rows {
+		rows[i].Email = "joeybloggs@gmail.com"
+	}
+
+	upload := Upload{Name: "a", Rows: rows, Tags: map[string]string{"a": "a", "b": "b"}}
+
+	validate := New()
+	ctx := context.Background()
+
+	errs := validate.Struct(upload)
+	Equal(t, errs, nil)
+
+	validate.SetLimits(Limits{MaxDiveElements: 10})
+
+	errs = validate.Struct(upload)
+	Equal(t, errs, nil)
+
+	validate.SetLimits(Limits{MaxDiveElements: 9})
+
+	errs = validate.Struct(upload)
+	NotEqual(t, errs, nil)
+
+	limitErr, ok := errs.(*LimitError)
+	Equal(t, ok, true)
+	Equal(t, limitErr.Limit, LimitDiveElements)
+	Equal(t, limitErr.Namespace, "Upload.Rows")
+	Equal(t, limitErr.Error(), "validator: limit on dive elements exceeded at 'Upload.Rows'")
+	Equal(t, limitErr.Unwrap(), nil)
+
+	validate.SetLimits(Limits{MaxDiveElements: 1})
+
+	errs = validate.Var(map[string]string{"a": "a", "b": "b"}, "dive,required")
+	NotEqual(t, errs, nil)
+	Equal(t, errs.Error(), "validator: limit on dive elements exceeded")
+
+	// Name, Rows, 10 Rows and their 2 fields, Tags and 2 Tags
+	validate.SetLimits(Limits{MaxFields: 35})
+
+	errs = validate.Struct(upload)
+	Equal(t, errs, nil)
+
+	validate.SetLimits(Limits{MaxFields: 34})
+
+	errs = validate.Struct(upload)
+	NotEqual(t, errs, nil)
+
+	limitErr, ok = errs.(*LimitError)
+	Equal(t, ok, true)
+	Equal(t, limitErr.Limit, LimitFields)
+	Equal(t, strings.HasPrefix(limitErr.Namespace, "Upload.Tags["), true)
+
+	// the count is per validation
+	errs = validate.Var(rows, "dive")
+	Equal(t, errs, nil)
+
+	errs = validate.Var(rows, "dive")
+	Equal(t, errs, nil)
+
+	validate.SetLimits(Limits{MaxRegexInput: 20})
+
+	errs = validate.Struct(upload)
+	Equal(t, errs, nil)
+
+	upload.Rows[3].Email = "joey.bloggs@gmail.com"
+
+	errs = validate.Struct(upload)
+	NotEqual(t, errs, nil)
+
+	limitErr, ok = errs.(*LimitError)
+	Equal(t, ok, true)
+	Equal(t, limitErr.Limit, LimitRegexInput)
+	Equal(t, limitErr.Namespace, "Upload.Rows[3].Email")
+
+	upload.Rows[3].Email = "joeybloggs@gmail.com"
+	upload.Rows[4].Color = strings.Repeat("f", 21)
+
+	errs = validate.Struct(upload)
+	NotEqual(t, errs, nil)
+
+	limitErr, ok = errs.(*LimitError)
+	Equal(t, ok, true)
+	Equal(t, limitErr.Limit, LimitRegexInput)
+	Equal(t, limitErr.Namespace, "Upload.Rows[4].Color")
+
+	// only regex validations are limited
+	errs = validate.Var(strings.Repeat("a", 100), "required,max=200")
+	Equal(t, errs, nil)
+
+	errs = validate.Var(strings.Repeat("a", 100), "alpha")
+	NotEqual(t, errs, nil)
+	Equal(t, errs.(*LimitError).Limit, LimitRegexInput)
+
+	// unless overridden
+	overridden := New()
+	overridden.SetLimits(Limits{MaxRegexInput: 20})
+
+	err := overridden.RegisterValidation("alpha", func(fl FieldLevel) bool { return true })
+	Equal(t, err, nil)
+
+	errs = overridden.Var(strings.Repeat("a", 100), "alpha")
+	Equal(t, errs, nil)
+
+	upload.Rows[4].Color = ""
+
+	// deadline
+	validate.SetLimits(Limits{Timeout: time.Hour})
+
+	errs = validate.Struct(upload)
+	Equal(t, errs, nil)
+
+	deadlineCtx, cancel := context.WithDeadline(ctx, time.Now().Add(-time.Second))
+	defer cancel()
+
+	errs = validate.StructCtx(deadlineCtx, upload)
+	NotEqual(t, errs, nil)
+
+	limitErr, ok = errs.(*LimitError)
+	Equal(t, ok, true)
+	Equal(t, limitErr.Limit, LimitDeadline)
+	Equal(t, limitErr.Namespace, "Upload.Name")
+	Equal(t, limitErr.Unwrap(), context.DeadlineExceeded)
+
+	validate.SetLimits(Limits{Timeout: time.Millisecond})
+	validate.RegisterValidation("slow", func(fl FieldLevel) bool {
+		time.Sleep(50 * time.Microsecond)
+		return true
+	})
+
+	errs = validate.Var(make([]string, checkInterval+1), "dive,slow")
+	NotEqual(t, errs, nil)
+
+	limitErr, ok = errs.(*LimitError)
+	Equal(t, ok, true)
+	Equal(t, limitErr.Limit, LimitDeadline)
+	Equal(t, limitErr.Namespace, "[63]")
+
+	// no limits, the context's deadline is still honored
+	validate.SetLimits(Limits{})
+
+	errs = validate.StructCtx(deadlineCtx, upload)
+	NotEqual(t, errs, nil)
+
+	_, ok = errs.(*ContextError)
+	Equal(t, ok, true)
+
+	Equal(t, Limit(99).String(), "unknown")
+	Equal(t, LimitFields.String(), "fields")
+}
+
+func TestContextCancellation(t *testing.T) {
+
+	type Row struct {
+		Name string `validate:"required,cancel"`
+	}
+
+	type Upload struct {
+		Name string `validate:"required"`
+		Rows []Row  `validate:"dive"`
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	visited := 0
+	cancelAt := 0
+
+	validate := New()
+	validate.RegisterValidationCtx("cancel", func(ctx context.Context, fl FieldLevel) bool {
+
+		visited++
+
+		if visited == cancelAt {
+			cancel()
+		}
+
+		return true
+	})
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i].Name = "a"
+	}
+
+	errs := validate.StructCtx(context.Background(), Upload{Name: "a", Rows: rows})
+	Equal(t, errs, nil)
+	Equal(t, visited, 1000)
+
+	visited = 0
+	cancelAt = 10
+
+	errs = validate.StructCtx(ctx, Upload{Rows: rows})
+	NotEqual(t, errs, nil)
+
+	ctxErr, ok := errs.(*ContextError)
+	Equal(t, ok, true)
+	Equal(t, ctxErr.Err, context.Canceled)
+	Equal(t, ctxErr.Unwrap(), context.Canceled)
+	Equal(t, ctxErr.Namespace, "Upload.Rows[31]")
+	Equal(t, ctxErr.Error(), "validator: context canceled at 'Upload.Rows[31]'")
+
+	// stopped at the next check
+	Equal(t, visited, 31)
+
+	errs = validate.VarCtx(ctx, rows, "dive")
+	NotEqual(t, errs, nil)
+
+	ctxErr, ok = errs.(*ContextError)
+	Equal(t, ok, true)
+	Equal(t, ctxErr.Namespace, "")
+	Equal(t, ctxErr.Error(), "validator: context canceled")
+	Equal(t, visited, 31)
+
+	errs = validate.StructWithOptions(ctx, Upload{}, ValidateOptions{})
+	NotEqual(t, errs, nil)
+
+	_, ok = errs.(*ContextError)
+	Equal(t, ok, true)
+
+	// the validate instance can be reused once aborted
+	errs = validate.Struct(Upload{})
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "Upload.Name", "Upload.Name", "Name", "Name", "required")
+
+	deadlineCtx, cancelDeadline := context.WithTimeout(context.Background(), -time.Second)
+	defer cancelDeadline()
+
+	errs = validate.StructCtx(deadlineCtx, Upload{})
+	NotEqual(t, errs, nil)
+
+	ctxErr, ok = errs.(*ContextError)
+	Equal(t, ok, true)
+	Equal(t, ctxErr.Err, context.DeadlineExceeded)
+	Equal(t, ctxErr.Namespace, "Upload.Name")
+}
+
+func TestModifiers(t *testing.T) {
+
+	type Address struct {
+		City string `mod:"trim,title" validate:"required"`
+	}
+
+	type User struct {
+		Name      string            `mod:"trim" validate:"required"`
+		Confirm   string            `mod:"trim" validate:"eqfield=Name"`
+		Email     string            `mod:"trim,lcase" validate:"email"`
+		Code      string            `mod:"ltrim=0,ucase"`
+		Suffix    string            `mod:"rtrim"`
+		Key       string            `mod:"snake"`
+		JSONName  string            `mod:"camel"`
+		Composed  string            `mod:"nfc"`
+		Role      string            `mod:"default=user" validate:"oneof=user admin"`
+		Retries   int               `mod:"default=3"`
+		Ratio     float64           `mod:"default=0.5"`
+		Enabled   bool              `mod:"default=true"`
+		Timeout   time.Duration     `mod:"default=1m30s"`
+		Nickname  *string           `mod:"default=anon"`
+		Optional  *string           `mod:"trim"`
+		Tags      []string          `mod:"dive,trim,lcase" validate:"dive,alpha"`
+		Labels    map[string]string `mod:"dive,ucase"`
+		Addresses []*Address        `validate:"dive"`
+		Ignored   string            `mod:"trim" validate:"-"`
+	}
+
+	validate := New()
+
+	u := &User{
+		Name:      "  joeybloggs ",
+		Confirm:   "joeybloggs\t",
+		Email:     " Joey@Example.COM ",
+		Code:      "007ab",
+		Suffix:    "x \n",
+		Key:       "HTTPServer name",
+		JSONName:  "http_server-Name",
+		Composed:  "Cafe\u0301",
+		Retries:   5,
+		Tags:      []string{" Go ", "RUST"},
+		Labels:    map[string]string{"a": "x"},
+		Addresses: []*Address{{City: " new york "}, nil},
+		Ignored:   " - ",
+	}
+
+	errs := validate.Struct(u)
+	Equal(t, errs, nil)
+
+	Equal(t, u.Name, "joeybloggs")
+	Equal(t, u.Confirm, "joeybloggs")
+	Equal(t, u.Email, "joey@example.com")
+	Equal(t, u.Code, "7AB")
+	Equal(t, u.Suffix, "x")
+	Equal(t, u.Key, "http_server_name")
+	Equal(t, u.JSONName, "httpServerName")
+	Equal(t, u.Composed, "Caf\u00e9")
+	Equal(t, u.Role, "user")
+	Equal(t, u.Retries, 5)
+	Equal(t, u.Ratio, 0.5)
+	Equal(t, u.Enabled, true)
+	Equal(t, u.Timeout, 90*time.Second)
+	NotEqual(t, u.Nickname, nil)
+	Equal(t, *u.Nickname, "anon")
+	Equal(t, u.Optional, nil)
+	Equal(t, u.Tags, []string{"go", "rust"})
+	Equal(t, u.Labels, map[string]string{"a": "X"})
+	Equal(t, u.Addresses[0].City, "New York")
+	Equal(t, u.Ignored, "-")
+
+	// the modified values are validated
+	u = &User{Name: "   ", Confirm: " a ", Addresses: []*Address{{City: " "}}}
+
+	errs = validate.Struct(u)
+	NotEqual(t, errs, nil)
+
+	ve := errs.(ValidationErrors)
+	Equal(t, len(ve), 4)
+	AssertError(t, errs, "User.Name", "User.Name", "Name", "Name", "required")
+	AssertError(t, errs, "User.Confirm", "User.Confirm", "Confirm", "Confirm", "eqfield")
+	AssertError(t, errs, "User.Email", "User.Email", "Email", "Email", "email")
+	AssertError(t, errs, "User.Addresses[0].City", "User.Addresses[0].City", "City", "City", "required")
+
+	// a value can't be modified, so is validated as is
+	errs = validate.Struct(User{Name: " a", Confirm: " a", Role: "user", Email: "a@b.co"})
+	Equal(t, errs, nil)
+
+	// only the fields validated are modified
+	u = &User{Name: " a ", Confirm: " b "}
+
+	errs = validate.StructPartial(u, "Name")
+	Equal(t, errs, nil)
+	Equal(t, u.Name, "a")
+	Equal(t, u.Confirm, " b ")
+
+	// custom modifiers
+	type Custom struct {
+		Value string `mod:"repeat=xx,trim=x0x2C" validate:"len=6"`
+	}
+
+	validate = New()
+
+	err := validate.RegisterModifier("repeat", func(ctx context.Context, field reflect.Value, param string) {
+		n := len(param)
+		field.SetString(strings.Repeat(field.String(), n))
+	})
+	Equal(t, err, nil)
+
+	c := &Custom{Value: ",ab,"}
+
+	errs = validate.Struct(c)
+	Equal(t, errs, nil)
+	Equal(t, c.Value, "ab,,ab")
+
+	Equal(t, validate.RegisterModifier("", trimMod).Error(), "Modifier name cannot be empty")
+	Equal(t, validate.RegisterModifier("nil", nil).Error(), "Function cannot be empty")
+	PanicMatches(t, func() { _ = validate.RegisterModifier("dive", trimMod) }, "Tag 'dive' either contains restricted characters or is the same as a restricted tag needed for normal operation")
+
+	// custom tag name
+	type Renamed struct {
+		Name string `clean:"trim"`
+	}
+
+	validate = New()
+	validate.SetModTagName("clean")
+
+	r := &Renamed{Name: " a "}
+
+	errs = validate.Struct(r)
+	Equal(t, errs, nil)
+	Equal(t, r.Name, "a")
+
+	// invalid modifiers
+	type Undefined struct {
+		Name string `mod:"trim,zzxx"`
+	}
+
+	type BadKind struct {
+		Age int `mod:"trim"`
+	}
+
+	type BadDive struct {
+		Name string `mod:"dive,trim"`
+	}
+
+	type BadDefault struct {
+		Age int `mod:"default=abc"`
+	}
+
+	validate = New()
+	validate.SetPanicOnInvalidTag(false)
+
+	errs = validate.Struct(&Undefined{})
+	tagErr, ok := errs.(*InvalidTagError)
+	Equal(t, ok, true)
+	Equal(t, tagErr.Namespace, "Undefined.Name")
+	Equal(t, tagErr.Tag, "zzxx")
+	Equal(t, tagErr.Reason, "Undefined modifier 'zzxx' on field 'Name'")
+
+	errs = validate.Struct(&BadKind{})
+	tagErr, ok = errs.(*InvalidTagError)
+	Equal(t, ok, true)
+	Equal(t, tagErr.Namespace, "BadKind.Age")
+	Equal(t, tagErr.Reason, "modifier 'trim' can't be applied to kind int")
+
+	errs = validate.Struct(&BadDive{})
+	tagErr, ok = errs.(*InvalidTagError)
+	Equal(t, ok, true)
+	Equal(t, tagErr.Namespace, "BadDive.Name")
+	Equal(t, tagErr.Reason, "dive error! can't dive on a non slice or map")
+
+	errs = validate.Struct(&BadDefault{})
+	tagErr, ok = errs.(*InvalidTagError)
+	Equal(t, ok, true)
+	Equal(t, tagErr.Namespace, "BadDefault.Age")
+	Equal(t, tagErr.Tag, "default=abc")
+	Equal(t, tagErr.Reason, "invalid param 'abc' for modifier 'default' on kind int")
+
+	type Outer struct {
+		Inner Undefined
+		Bad   string `mod:"trim,,lcase"`
+	}
+
+	err = New().Compile(reflect.TypeOf(Outer{}))
+	NotEqual(t, err, nil)
+
+	tagErrs, ok := err.(InvalidTagErrors)
+	Equal(t, ok, true)
+	Equal(t, len(tagErrs), 2)
+	Equal(t, tagErrs[0].Namespace, "Outer.Bad")
+	Equal(t, tagErrs[0].Reason, "Invalid modifier tag on field 'Bad'")
+	Equal(t, tagErrs[1].Namespace, "Outer.Inner.Name")
+	Equal(t, tagErrs[1].Reason, "Undefined modifier 'zzxx' on field 'Name'")
+
+	PanicMatches(t, func() { _ = New().Struct(&BadKind{}) }, "modifier 'trim' can't be applied to kind int")
+}
+
+func TestSetDefaults(t *testing.T) {
+
+	type Server struct {
+		Host    string        `mod:"default=localhost"`
+		Port    int           `mod:"default=8080" validate:"min=1024"`
+		Timeout time.Duration `mod:"default=30s"`
+	}
+
+	type Config struct {
+		Name     string    `mod:"trim,default=app" validate:"required"`
+		Started  time.Time `mod:"default=2020-01-02T03:04:05Z"`
+		Origins  []string  `mod:"default=a.com|b.com"`
+		Weights  []float64 `mod:"default=0.5|1"`
+		Level    *int      `mod:"default=3"`
+		Server   Server
+		Backup   *Server
+		Missing  *Server
+		Replicas []Server
+		ByName   map[string]Server
+	}
+
+	validate := New()
+
+	c := &Config{
+		Name:     "  ",
+		Weights:  []float64{2},
+		Server:   Server{Port: 9000},
+		Backup:   &Server{},
+		Replicas: []Server{{Host: "r1"}, {}},
+		ByName:   map[string]Server{"a": {}},
+	}
+
+	err := validate.SetDefaults(c)
+	Equal(t, err, nil)
+
+	// only the defaults are applied
+	Equal(t, c.Name, "  ")
+	Equal(t, c.Started.Equal(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)), true)
+	Equal(t, c.Origins, []string{"a.com", "b.com"})
+	Equal(t, c.Weights, []float64{2})
+	NotEqual(t, c.Level, nil)
+	Equal(t, *c.Level, 3)
+	Equal(t, c.Server, Server{Host: "localhost", Port: 9000, Timeout: 30 * time.Second})
+	Equal(t, *c.Backup, Server{Host: "localhost", Port: 8080, Timeout: 30 * time.Second})
+	Equal(t, c.Missing, nil)
+	Equal(t, c.Replicas[0], Server{Host: "r1", Port: 8080, Timeout: 30 * time.Second})
+	Equal(t, c.Replicas[1].Host, "localhost")
+	Equal(t, c.ByName["a"], Server{})
+
+	// Struct applies the defaults, along with the other modifiers, before validating
+	c = &Config{Name: "  ", Server: Server{Port: 80}}
+
+	errs := validate.Struct(c)
+	NotEqual(t, errs, nil)
+	Equal(t, c.Name, "app")
+	Equal(t, len(errs.(ValidationErrors)), 1)
+	AssertError(t, errs, "Config.Server.Port", "Config.Server.Port", "Port", "Port", "min")
+
+	// cycles are not followed
+	type Node struct {
+		Name string `mod:"default=node"`
+		Next *Node
+	}
+
+	n := &Node{}
+	n.Next = n
+
+	err = validate.SetDefaults(n)
+	Equal(t, err, nil)
+	Equal(t, n.Name, "node")
+
+	// invalid values
+	err = validate.SetDefaults(Server{})
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "validator: (nil validator.Server)")
+
+	var nilServer *Server
+
+	err = validate.SetDefaults(nilServer)
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "validator: (nil *validator.Server)")
+
+	err = validate.SetDefaults(&time.Time{})
+	NotEqual(t, err, nil)
+
+	type BadDefault struct {
+		Started time.Time `mod:"default=yesterday"`
+		Other   Server    `mod:"default=x"`
+	}
+
+	type BadElem struct {
+		Ports []int `mod:"default=1|x"`
+	}
+
+	validate.SetPanicOnInvalidTag(false)
+
+	err = validate.SetDefaults(&BadDefault{})
+	tagErr, ok := err.(*InvalidTagError)
+	Equal(t, ok, true)
+	Equal(t, tagErr.Namespace, "BadDefault.Started")
+	Equal(t, tagErr.Reason, "invalid param 'yesterday' for modifier 'default' on kind struct")
+
+	err = validate.SetDefaults(&BadDefault{Started: time.Now()})
+	tagErr, ok = err.(*InvalidTagError)
+	Equal(t, ok, true)
+	Equal(t, tagErr.Namespace, "BadDefault.Other")
+	Equal(t, tagErr.Reason, "modifier 'default' can't be applied to kind struct")
+
+	err = validate.SetDefaults(&BadElem{})
+	tagErr, ok = err.(*InvalidTagError)
+	Equal(t, ok, true)
+	Equal(t, tagErr.Tag, "default=1|x")
+	Equal(t, tagErr.Reason, "invalid param 'x' for modifier 'default' on kind int")
+
+	PanicMatches(t, func() { _ = New().SetDefaults(&BadElem{}) }, "invalid param 'x' for modifier 'default' on kind int")
+}
+
+func TestValidateMap(t *testing.T) {
+
+	validate := New()
+
+	rules := map[string]interface{}{
+		"name":  "required,min=2",
+		"email": "required,email",
+		"tags":  "omitempty,dive,alpha",
+		"age":   "omitempty,gte=18",
+		"profile": map[string]interface{}{
+			"bio": "omitempty,max=10",
+			"links": map[string]interface{}{
+				"url": "url",
+			},
+		},
+		"addresses": map[string]interface{}{
+			"zip": "required,numeric",
+		},
+	}
+
+	data := map[string]interface{}{
+		"name":  "joeybloggs",
+		"email": "joey@bloggs.com",
+		"tags":  []interface{}{"go", "rust"},
+		"profile": map[string]interface{}{
+			"bio":   "gopher",
+			"links": []map[string]interface{}{{"url": "https://go.dev"}},
+		},
+		"addresses": []interface{}{
+			map[string]interface{}{"zip": "12345"},
+		},
+	}
+
+	errs := validate.ValidateMap(data, rules)
+	Equal(t, len(errs), 0)
+
+	data = map[string]interface{}{
+		"name":  "j",
+		"tags":  []interface{}{"go", "c++"},
+		"age":   17,
+		"extra": "ignored",
+		"profile": map[string]interface{}{
+			"bio":   "a very long bio",
+			"links": []map[string]interface{}{{"url": "https://go.dev"}, {"url": "nope"}},
+		},
+		"addresses": []interface{}{
+			map[string]interface{}{"zip": "12345"},
+			map[string]interface{}{"zip": "abc"},
+			"not a map",
+		},
+	}
+
+	errs = validate.ValidateMapCtx(context.Background(), data, rules)
+	Equal(t, len(errs), 6)
+
+	AssertError(t, errs["name"].(error), "name", "name", "name", "name", "min")
+	AssertError(t, errs["email"].(error), "email", "email", "email", "email", "required")
+	AssertError(t, errs["tags"].(error), "tags[1]", "tags[1]", "tags[1]", "tags[1]", "alpha")
+	AssertError(t, errs["age"].(error), "age", "age", "age", "age", "gte")
+
+	profile := errs["profile"].(map[string]interface{})
+	Equal(t, len(profile), 2)
+	AssertError(t, profile["bio"].(error), "profile.bio", "profile.bio", "bio", "bio", "max")
+
+	links := profile["links"].(map[string]interface{})
+	Equal(t, len(links), 1)
+
+	link := links["1"].(map[string]interface{})
+	AssertError(t, link["url"].(error), "profile.links[1].url", "profile.links[1].url", "url", "url", "url")
+
+	fe := link["url"].(ValidationErrors)[0]
+	Equal(t, fe.Value(), "nope")
+	Equal(t, fe.Path().String(), "profile.links[1].url")
+	Equal(t, fe.Path().JSONPointer(), "/profile/links/1/url")
+
+	addresses := errs["addresses"].(map[string]interface{})
+	Equal(t, len(addresses), 2)
+	AssertError(t, addresses["1"].(map[string]interface{})["zip"].(error), "addresses[1].zip", "addresses[1].zip", "zip", "zip", "numeric")
+	Equal(t, addresses["2"].(error).Error(), "validator: 'addresses[2]' is not a map to dive")
+
+	fe = errs["tags"].(ValidationErrors)[0]
+	Equal(t, fe.Path().JSONPointer(), "/tags/1")
+
+	// missing keys are validated as nil and missing nested maps as empty
+	errs = validate.ValidateMap(map[string]interface{}{"name": "joeybloggs", "email": "joey@bloggs.com"}, rules)
+	Equal(t, len(errs), 2)
+	AssertError(t, errs["profile"].(map[string]interface{})["links"].(map[string]interface{})["url"].(error), "profile.links.url", "profile.links.url", "url", "url", "url")
+	AssertError(t, errs["addresses"].(map[string]interface{})["zip"].(error), "addresses.zip", "addresses.zip", "zip", "zip", "required")
+
+	errs = validate.ValidateMap(map[string]interface{}{"profile": "flat"}, map[string]interface{}{"profile": map[string]interface{}{"bio": "max=10"}})
+	Equal(t, len(errs), 1)
+	Equal(t, errs["profile"].(error).Error(), "validator: 'profile' is not a map to dive")
+
+	// invalid rules
+	PanicMatches(t, func() { validate.ValidateMap(data, map[string]interface{}{"name": 1}) }, "Invalid rule for key 'name', must be a tag or a map of rules")
+	PanicMatches(t, func() { validate.ValidateMap(data, map[string]interface{}{"name": "zzxx"}) }, "Undefined validation function 'zzxx' on field ''")
+
+	validate.SetPanicOnInvalidTag(false)
+
+	errs = validate.ValidateMap(data, map[string]interface{}{"profile": map[string]interface{}{"bio": 1, "name": "zzxx"}})
+	Equal(t, len(errs), 1)
+
+	profile = errs["profile"].(map[string]interface{})
+
+	tagErr, ok := profile["bio"].(*InvalidTagError)
+	Equal(t, ok, true)
+	Equal(t, tagErr.Namespace, "profile.bio")
+	Equal(t, tagErr.Tag, "1")
+
+	tagErr, ok = profile["name"].(*InvalidTagError)
+	Equal(t, ok, true)
+	Equal(t, tagErr.Namespace, "profile.name")
+	Equal(t, tagErr.Tag, "zzxx")
+}
+
+func TestValidateMapSiblings(t *testing.T) {
+
+	validate := New()
+
+	// a missing key runs the tags running on nil
+	errs := validate.ValidateMap(map[string]interface{}{}, map[string]interface{}{"b": "required_with=a"})
+	Equal(t, len(errs), 0)
+
+	errs = validate.ValidateMap(map[string]interface{}{"a": "x"}, map[string]interface{}{"b": "required_with=a"})
+	Equal(t, len(errs), 1)
+	AssertError(t, errs["b"].(error), "b", "b", "b", "b", "required_with")
+
+	errs = validate.ValidateMap(map[string]interface{}{"a": "x"}, map[string]interface{}{"b": "required_without=a"})
+	Equal(t, len(errs), 0)
+
+	errs = validate.ValidateMap(map[string]interface{}{}, map[string]interface{}{"b": "required_without=a,email"})
+	Equal(t, len(errs), 1)
+	AssertError(t, errs["b"].(error), "b", "b", "b", "b", "required_without")
+
+	errs = validate.ValidateMap(map[string]interface{}{"a": "x", "b": "x"}, map[string]interface{}{"a": "eqfield=b"})
+	Equal(t, len(errs), 0)
+
+	errs = validate.ValidateMap(map[string]interface{}{"a": "x", "b": "y"}, map[string]interface{}{"a": "eqfield=b"})
+	Equal(t, len(errs), 1)
+	AssertError(t, errs["a"].(error), "a", "a", "a", "a", "eqfield")
+
+	errs = validate.ValidateMap(map[string]interface{}{"a": "x"}, map[string]interface{}{"a": "eqfield=b"})
+	Equal(t, len(errs), 1)
+	AssertError(t, errs["a"].(error), "a", "a", "a", "a", "eqfield")
+
+	// nested maps are the parent of their keys
+	data := map[string]interface{}{
+		"phone": "555",
+		"contact": map[string]interface{}{
+			"phone": "555",
+			"email": "joey@bloggs.com",
+			"name":  map[string]interface{}{"first": "joey"},
+		},
+	}
+
+	rules := map[string]interface{}{
+		"contact": map[string]interface{}{
+			"email":   "required_with=phone,email",
+			"confirm": "required_with=email,eqfield=email",
+			"first":   "eqfield=name.first",
+		},
+	}
+
+	errs = validate.ValidateMap(data, rules)
+	Equal(t, len(errs), 1)
+
+	contact := errs["contact"].(map[string]interface{})
+	Equal(t, len(contact), 2)
+	AssertError(t, contact["confirm"].(error), "contact.confirm", "contact.confirm", "confirm", "confirm", "required_with")
+	AssertError(t, contact["first"].(error), "contact.first", "contact.first", "first", "first", "eqfield")
+}
+
+func TestValidateMapLimits(t *testing.T) {
+
+	validate := New()
+
+	data := make(map[string]interface{})
+	rules := make(map[string]interface{})
+
+	for i := 0; i < 10; i++ {
+		data[fmt.Sprintf("key%d", i)] = ""
+		rules[fmt.Sprintf("key%d", i)] = "required"
+	}
+
+	errs := validate.ValidateMap(data, rules)
+	Equal(t, len(errs), 10)
+
+	// the limits, and maximum number of errors, are of the whole validation
+	validate.SetLimits(Limits{MaxFields: 2})
+
+	errs = validate.ValidateMap(data, rules)
+	Equal(t, len(errs), 3)
+	AssertError(t, errs["key0"].(error), "key0", "key0", "key0", "key0", "required")
+	AssertError(t, errs["key1"].(error), "key1", "key1", "key1", "key1", "required")
+
+	limitErr, ok := errs["key2"].(*LimitError)
+	Equal(t, ok, true)
+	Equal(t, limitErr.Limit, LimitFields)
+	Equal(t, limitErr.Namespace, "key2")
+
+	validate.SetLimits(Limits{})
+	validate.SetMaxErrors(3)
+
+	errs = validate.ValidateMap(data, rules)
+	Equal(t, len(errs), 3)
+	Equal(t, errs["key0"].(ValidationErrors).Truncated(), false)
+	Equal(t, errs["key2"].(ValidationErrors).Truncated(), true)
+
+	// the pooled validation's errors aren't shared with the returned ones
+	errs = validate.ValidateMap(data, rules)
+	Equal(t, len(errs["key0"].(ValidationErrors)), 1)
+}
+
+func TestStructRules(t *testing.T) {
+
+	type Item struct {
+		SKU string
+		Qty int `validate:"min=1"`
+	}
+
+	type Order struct {
+		Name  string `validate:"max=1"`
+		Email string `validate:"email"`
+		Note  string `validate:"required"`
+		Items []Item
+		notes string
+	}
+
+	validate := New()
+
+	err := validate.RegisterStructRules(&Order{}, map[string]string{
+		"Name":  "required,max=64",
+		"Items": "required,dive",
+		"Note":  "-",
+	})
+	Equal(t, err, nil)
+
+	err = validate.RegisterStructRules(Item{}, map[string]string{"SKU": "required,alphanum"})
+	Equal(t, err, nil)
+
+	// rules take precedence over the tags, fields without rules keep their tags
+	errs := validate.Struct(Order{Name: "joeybloggs", Email: "joey@bloggs.com", Items: []Item{{SKU: "abc1", Qty: 1}}})
+	Equal(t, errs, nil)
+
+	errs = validate.Struct(Order{Email: "nope", Items: []Item{{SKU: "a-b"}}})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 4)
+	AssertError(t, errs, "Order.Name", "Order.Name", "Name", "Name", "required")
+	AssertError(t, errs, "Order.Email", "Order.Email", "Email", "Email", "email")
+	AssertError(t, errs, "Order.Items[0].SKU", "Order.Items[0].SKU", "SKU", "SKU", "alphanum")
+	AssertError(t, errs, "Order.Items[0].Qty", "Order.Items[0].Qty", "Qty", "Qty", "min")
+
+	errs = validate.Struct(Order{Name: "joeybloggs", Email: "joey@bloggs.com"})
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "Order.Items", "Order.Items", "Items", "Items", "required")
+
+	// registering again, after the type has been validated, replaces the rules
+	err = validate.RegisterStructRules(Order{}, map[string]string{"Email": "-"})
+	Equal(t, err, nil)
+
+	errs = validate.Struct(Order{Name: "joeybloggs", Email: "nope", Note: "a"})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 1)
+	AssertError(t, errs, "Order.Name", "Order.Name", "Name", "Name", "max")
+
+	// as do rules registered the first time after the type has been validated
+	type Customer struct {
+		Name string
+	}
+
+	errs = validate.Struct(Customer{})
+	Equal(t, errs, nil)
+
+	err = validate.RegisterStructRules(Customer{}, map[string]string{"Name": "required"})
+	Equal(t, err, nil)
+
+	errs = validate.Struct(Customer{})
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "Customer.Name", "Customer.Name", "Name", "Name", "required")
+
+	// invalid rules
+	err = validate.RegisterStructRules(Order{}, map[string]string{"Missing": "required"})
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "validator: validator.Order has no exported field 'Missing'")
+
+	err = validate.RegisterStructRules(Order{}, map[string]string{"notes": "required"})
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "validator: validator.Order has no exported field 'notes'")
+
+	err = validate.RegisterStructRules(Order{}, map[string]string{"Name": "zzxx"})
+	NotEqual(t, err, nil)
+
+	tagErr, ok := err.(*InvalidTagError)
+	Equal(t, ok, true)
+	Equal(t, tagErr.Namespace, "Order.Name")
+	Equal(t, tagErr.Reason, "Undefined validation function 'zzxx' on field 'Name'")
+
+	err = validate.RegisterStructRules("string", map[string]string{})
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "validator: (nil string)")
+
+	// loading rules
+	type Address struct {
+		Zip string
+	}
+
+	type Person struct {
+		Name    string
+		Address Address
+	}
+
+	validate = New()
+
+	err = validate.LoadStructRules([]byte(`{
+		"Person": {"Name": "required"},
+		"validator.Address": {"Zip": "required,numeric"}
+	}`), nil, Person{}, &Address{})
+	Equal(t, err, nil)
+
+	errs = validate.Struct(Person{Address: Address{Zip: "abc"}})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 2)
+	AssertError(t, errs, "Person.Name", "Person.Name", "Name", "Name", "required")
+	AssertError(t, errs, "Person.Address.Zip", "Person.Address.Zip", "Zip", "Zip", "numeric")
+
+	// any unmarshal function, eg. a YAML library's, can be used
+	unmarshal := func(data []byte, v interface{}) error {
+		*v.(*map[string]map[string]string) = map[string]map[string]string{string(data): {"Zip": "required"}}
+		return nil
+	}
+
+	validate = New()
+
+	err = validate.LoadStructRules([]byte("Address"), unmarshal, Address{})
+	Equal(t, err, nil)
+
+	errs = validate.Struct(Address{})
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "Address.Zip", "Address.Zip", "Zip", "Zip", "required")
+
+	err = validate.LoadStructRules([]byte(`{"Other": {"Zip": "required"}}`), nil, Address{})
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "validator: rules for unknown type 'Other'")
+
+	err = validate.LoadStructRules([]byte(`{"Address": {"Zip": "required"}}`), nil, Address{}, ut.Translator(nil), struct{ Address }{})
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "validator: (nil)")
+
+	err = validate.LoadStructRules([]byte(`{"Address": {"Zip": "required"}}`), nil, Address{}, TestString{})
+	Equal(t, err, nil)
+
+	err = validate.LoadStructRules([]byte(`{"Zip": "required"}`), nil, Address{})
+	NotEqual(t, err, nil)
+
+	{
+		type Address struct {
+			City string
+		}
+
+		err = validate.LoadStructRules([]byte(`{"Address": {"City": "required"}}`), nil, Address{}, Person{}.Address)
+		NotEqual(t, err, nil)
+		Equal(t, strings.HasPrefix(err.Error(), "validator: rules for type 'Address' match both "), true)
+	}
+}
+
+func TestExprTag(t *testing.T) {
+
+	type Customer struct {
+		CreditLimit float64
+	}
+
+	type Order struct {
+		StartDate time.Time
+		EndDate   time.Time `validate:"expr=EndDate >= StartDate + 24h"`
+		Qty       int       `validate:"expr=Qty > 0 && Qty * UnitPrice <= Customer.CreditLimit"`
+		UnitPrice float64
+		Customer  *Customer
+		Items     []string `validate:"expr=len(Items) >= 1 || Draft"`
+		Draft     bool
+		Code      string        `validate:"expr=len(Code) == 3 && Code != 'xyz'"`
+		Timeout   time.Duration `validate:"expr=Timeout == 0 || (Timeout >= 1s && Timeout % 1s == 0)"`
+		Coupon    *string       `validate:"expr=Coupon == nil || len(Coupon) > 2"`
+		Expires   *time.Time    `validate:"expr=Expires == nil || Expires > now()"`
+	}
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	future := time.Now().Add(time.Hour)
+	coupon := "SAVE10"
+
+	valid := Order{
+		StartDate: start,
+		EndDate:   start.Add(48 * time.Hour),
+		Qty:       3,
+		UnitPrice: 9.99,
+		Customer:  &Customer{CreditLimit: 100},
+		Items:     []string{"a"},
+		Code:      "abc",
+		Timeout:   2 * time.Second,
+		Coupon:    &coupon,
+		Expires:   &future,
+	}
+
+	validate := New()
+
+	errs := validate.Struct(valid)
+	Equal(t, errs, nil)
+
+	invalid := valid
+	invalid.EndDate = start.Add(time.Hour)
+	invalid.Qty = 11
+	invalid.Items = nil
+	invalid.Code = "xyz"
+	invalid.Timeout = 1500 * time.Millisecond
+
+	coupon = "A"
+	invalid.Coupon = &coupon
+
+	past := time.Now().Add(-time.Hour)
+	invalid.Expires = &past
+
+	errs = validate.Struct(invalid)
+	NotEqual(t, errs, nil)
+
+	ve := errs.(ValidationErrors)
+	Equal(t, len(ve), 7)
+	AssertError(t, errs, "Order.EndDate", "Order.EndDate", "EndDate", "EndDate", "expr")
+	AssertError(t, errs, "Order.Qty", "Order.Qty", "Qty", "Qty", "expr")
+	AssertError(t, errs, "Order.Items", "Order.Items", "Items", "Items", "expr")
+	AssertError(t, errs, "Order.Code", "Order.Code", "Code", "Code", "expr")
+	AssertError(t, errs, "Order.Timeout", "Order.Timeout", "Timeout", "Timeout", "expr")
+	AssertError(t, errs, "Order.Coupon", "Order.Coupon", "Coupon", "Coupon", "expr")
+	AssertError(t, errs, "Order.Expires", "Order.Expires", "Expires", "Expires", "expr")
+
+	Equal(t, ve[0].Param(), "EndDate >= StartDate + 24h")
+	Equal(t, ve[1].Param(), "Qty > 0 && Qty * UnitPrice <= Customer.CreditLimit")
+
+	// nil pointers along a field's path are nil, failing the comparison
+	invalid = valid
+	invalid.Customer = nil
+
+	errs = validate.Struct(invalid)
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "Order.Qty", "Order.Qty", "Qty", "Qty", "expr")
+
+	// the draft needs no items, and or'ed with other tags
+	type Draft struct {
+		Items []string `validate:"expr=len(Items) > 0 || Draft,omitempty"`
+		Draft bool
+		Name  string `validate:"expr=Name == 'a'|eq=b"`
+	}
+
+	errs = validate.Struct(Draft{Draft: true, Name: "b"})
+	Equal(t, errs, nil)
+
+	errs = validate.Struct(Draft{Name: "c"})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 2)
+	Equal(t, splitExprOr("eq=a|expr=A || B|eq=b"), []string{"eq=a", "expr=A || B", "eq=b"})
+
+	tests := []struct {
+		expr     string
+		expected interface{}
+	}{
+		{expr: "1 + 2 * 3", expected: int64(7)},
+		{expr: "(1 + 2) * 3", expected: int64(9)},
+		{expr: "7 / 2", expected: int64(3)},
+		{expr: "7 / 2.0", expected: 3.5},
+		{expr: "7 % 4", expected: int64(3)},
+		{expr: "-2 - -3", expected: int64(1)},
+		{expr: "1.5 + 1", expected: 2.5},
+		{expr: "'a' + \"b\"", expected: "ab"},
+		{expr: "'a' < 'b'", expected: true},
+		{expr: "!true || false", expected: false},
+		{expr: "1 == 1.0", expected: true},
+		{expr: "1 != 2 && 2 <= 2 && 3 >= 2 && 3 > 2 && 1 < 2", expected: true},
+		{expr: "1h30m + 30m", expected: 2 * time.Hour},
+		{expr: "2h / 2", expected: time.Hour},
+		{expr: "2h / 30m", expected: int64(4)},
+		{expr: "3 * 500ms", expected: 1500 * time.Millisecond},
+		{expr: "1µs == 1000ns", expected: true},
+		{expr: "len('héllo')", expected: int64(5)},
+		{expr: "nil == nil", expected: true},
+		{expr: "now() - now() <= 0", expected: true},
+	}
+
+	for _, tt := range tests {
+
+		node, err := compileExpr(tt.expr)
+		Equal(t, err, nil)
+		Equal(t, node.eval(reflect.Value{}), tt.expected)
+	}
+
+	// invalid expressions are reported when the tag is parsed
+	badExprs := []struct {
+		expr   string
+		reason string
+	}{
+		{expr: "", reason: "unexpected end of expression"},
+		{expr: "1 +", reason: "unexpected end of expression"},
+		{expr: "(1", reason: "missing ')'"},
+		{expr: "1 2", reason: "unexpected '2'"},
+		{expr: "'a", reason: "unterminated string"},
+		{expr: "1 # 2", reason: "unexpected '#'"},
+		{expr: "1x", reason: "invalid duration '1x'"},
+		{expr: "1.2.3", reason: "invalid number '1.2.3'"},
+		{expr: "foo()", reason: "undefined function 'foo'"},
+		{expr: "len()", reason: "'len' takes 1 argument(s)"},
+		{expr: "len(1", reason: "missing ')' calling 'len'"},
+		{expr: "A..B", reason: "invalid field 'A..B'"},
+		{expr: ")", reason: "unexpected ')'"},
+	}
+
+	for _, tt := range badExprs {
+
+		_, err := compileExpr(tt.expr)
+		NotEqual(t, err, nil)
+		Equal(t, err.Error(), tt.reason)
+	}
+
+	type BadExpr struct {
+		Name string `validate:"expr=Name =="`
+	}
+
+	validate.SetPanicOnInvalidTag(false)
+
+	errs = validate.Struct(BadExpr{})
+	tagErr, ok := errs.(*InvalidTagError)
+	Equal(t, ok, true)
+	Equal(t, tagErr.Namespace, "BadExpr.Name")
+	Equal(t, tagErr.Tag, "expr=Name ==")
+	Equal(t, tagErr.Reason, "Invalid expression 'Name ==': unexpected end of expression on field 'Name'")
+
+	// the fields, and the operands, of expressions are checked against the struct when its tags are parsed
+	type BadEval struct {
+		Name    string        `validate:"expr=Name + 1 > 0"`
+		Age     int           `validate:"expr=Missing > 0"`
+		Count   int           `validate:"expr=Count"`
+		Created string        `validate:"expr=Created.Day > 1"`
+		Tags    []string      `validate:"expr=Tags == Tags"`
+		Timeout time.Duration `validate:"expr=Timeout > 1.5"`
+		Draft   bool          `validate:"required,expr=!len(Name)"`
+		Nested  *BadEval      `validate:"expr=Nested.Timeout + Nested.Name > 0"`
+		Keys    []string      `validate:"dive,keys,expr=Draft && Age"`
+	}
+
+	err := validate.Compile(reflect.TypeOf(BadEval{}))
+	NotEqual(t, err, nil)
+
+	evalErrs := []struct {
+		ns     string
+		tag    string
+		reason string
+	}{
+		{ns: "BadEval.Name", tag: "expr=Name + 1 > 0", reason: "invalid operation string + int on field 'Name'"},
+		{ns: "BadEval.Age", tag: "expr=Missing > 0", reason: "undefined field 'Missing' on field 'Age'"},
+		{ns: "BadEval.Count", tag: "expr=Count", reason: "evaluates to int, not a bool on field 'Count'"},
+		{ns: "BadEval.Created", tag: "expr=Created.Day > 1", reason: "can't get field 'Created.Day' of string on field 'Created'"},
+		{ns: "BadEval.Tags", tag: "expr=Tags == Tags", reason: "can't compare []string on field 'Tags'"},
+		{ns: "BadEval.Timeout", tag: "expr=Timeout > 1.5", reason: "invalid operation time.Duration > float on field 'Timeout'"},
+		{ns: "BadEval.Draft", tag: "expr=!len(Name)", reason: "'!' requires bools, not int on field 'Draft'"},
+		{ns: "BadEval.Nested", tag: "expr=Nested.Timeout + Nested.Name > 0", reason: "invalid operation time.Duration + string on field 'Nested'"},
+		{ns: "BadEval.Keys", tag: "expr=Draft && Age", reason: "'&&' requires bools, not int on field 'Keys'"},
+	}
+
+	tagErrs := err.(InvalidTagErrors)
+	Equal(t, len(tagErrs), len(evalErrs))
+
+	for i, tt := range evalErrs {
+		Equal(t, tagErrs[i].Namespace, tt.ns)
+		Equal(t, tagErrs[i].Tag, tt.tag)
+		Equal(t, tagErrs[i].Reason, "Invalid expression '"+tt.tag[len("expr="):]+"': "+tt.reason)
+	}
+
+	errs = validate.Struct(BadEval{})
+	tagErr, ok = errs.(*InvalidTagError)
+	Equal(t, ok, true)
+	Equal(t, tagErr.Namespace, "BadEval.Name")
+
+	// fields within interfaces are only known once evaluated, so are reported like other misused validations
+	type AnyEval struct {
+		Any   interface{}
+		Name  string `validate:"expr=Any.Name == Name"`
+		Count int    `validate:"expr=Any + 1 > Count"`
+	}
+
+	errs = validate.StructPartial(AnyEval{Any: AnyEval{Name: "a"}, Name: "a"}, "Name")
+	Equal(t, errs, nil)
+
+	errs = validate.StructPartial(AnyEval{Any: "a"}, "Count")
+	tagErr, ok = errs.(*InvalidTagError)
+	Equal(t, ok, true)
+	Equal(t, tagErr.Namespace, "AnyEval.Count")
+	Equal(t, tagErr.Tag, "expr")
+	Equal(t, tagErr.Reason, "expr: invalid operation string + int")
+
+	// values that can't be evaluated, rather than misused validations, fail the validation
+	type Ratios struct {
+		Divisor  int
+		Ratio    int           `validate:"expr=Ratio / Divisor > 1"`
+		Rem      int           `validate:"expr=Rem % Divisor == 0"`
+		Sum      int64         `validate:"expr=Sum + 1 > 0"`
+		Diff     int64         `validate:"expr=Diff - 1 < 0"`
+		Product  int64         `validate:"expr=Product * 2 > 0"`
+		Negated  int64         `validate:"expr=-Negated > 0"`
+		Quotient int64         `validate:"expr=Quotient / -1 > 0"`
+		Timeout  time.Duration `validate:"expr=Timeout + 1ns > 0"`
+		Unsigned uint64        `validate:"expr=Unsigned + 1 > 0"`
+	}
+
+	errs = validate.Struct(Ratios{
+		Sum:      math.MaxInt64,
+		Diff:     math.MinInt64,
+		Product:  math.MaxInt64,
+		Negated:  math.MinInt64,
+		Quotient: math.MinInt64,
+		Timeout:  math.MaxInt64,
+		Unsigned: math.MaxUint64,
+	})
+	NotEqual(t, errs, nil)
+
+	ve = errs.(ValidationErrors)
+	Equal(t, len(ve), 8)
+
+	for i, fld := range []string{"Ratio", "Rem", "Sum", "Diff", "Product", "Negated", "Quotient", "Timeout"} {
+		AssertError(t, errs, "Ratios."+fld, "Ratios."+fld, fld, fld, "expr")
+		Equal(t, ve[i].Field(), fld)
+	}
+
+	errs = validate.Struct(Ratios{Divisor: 2, Ratio: 4, Rem: 4, Sum: 1, Diff: -1, Product: 1, Negated: -1, Quotient: -1, Timeout: 1})
+	Equal(t, errs, nil)
+}
+
+func TestStructFields(t *testing.T) {
+
+	type Inner struct {
+		Name string `validate:"required"`
+	}
+
+	type Test struct {
+		Name    string            `validate:"required,min=1,max=32"`
+		Email   string            `validate:"omitempty,email"`
+		Color   string            `validate:"iscolor"`
+		Website string            `validate:"url|email"`
+		Labels  map[string]string `validate:"max=4,dive,keys,min=2,endkeys,required"`
+		Inner   Inner             `validate:"structonly"`
+		Skipped string            `validate:"-" mod:"trim"`
+		None    int
+		private string
+	}
+
+	validate := New()
+
+	fields, err := validate.StructFields(reflect.TypeOf(&Test{}))
+	Equal(t, err, nil)
+	Equal(t, len(fields), 8)
+
+	Equal(t, fields[0].Field.Name, "Name")
+	Equal(t, fields[0].Name, "Name")
+	Equal(t, fields[0].Rules, &Rules{Validations: [][]Validation{{{Tag: "required"}}, {{Tag: "min", Param: "1"}}, {{Tag: "max", Param: "32"}}}})
+	Equal(t, fields[1].Rules, &Rules{OmitEmpty: true, Validations: [][]Validation{{{Tag: "email"}}}})
+	Equal(t, fields[2].Rules, &Rules{Validations: [][]Validation{{
+		{Tag: "hexcolor", Alias: "iscolor"},
+		{Tag: "rgb", Alias: "iscolor"},
+		{Tag: "rgba", Alias: "iscolor"},
+		{Tag: "hsl", Alias: "iscolor"},
+		{Tag: "hsla", Alias: "iscolor"},
+	}}})
+	Equal(t, fields[3].Rules, &Rules{Validations: [][]Validation{{{Tag: "url"}, {Tag: "email"}}}})
+	Equal(t, fields[4].Rules, &Rules{
+		Validations: [][]Validation{{{Tag: "max", Param: "4"}}},
+		Keys:        &Rules{Validations: [][]Validation{{{Tag: "min", Param: "2"}}}},
+		Dive:        &Rules{Validations: [][]Validation{{{Tag: "required"}}}},
+	})
+	Equal(t, fields[5].Rules, &Rules{StructOnly: true})
+	Equal(t, fields[6].Skip, true)
+	Equal(t, fields[7].Field.Name, "None")
+	Equal(t, fields[7].Rules, &Rules{})
+
+	_, err = validate.StructFields(reflect.TypeOf(""))
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "validator: (nil string)")
+
+	type Bad struct {
+		Name string `validate:"bad"`
+	}
+
+	_, err = validate.StructFields(reflect.TypeOf(Bad{}))
+	NotEqual(t, err, nil)
+	Equal(t, err.(*InvalidTagError).Namespace, "Bad.Name")
+
+	rules, err := validate.ParseTag("required,dive,min=1")
+	Equal(t, err, nil)
+	Equal(t, rules, &Rules{
+		Validations: [][]Validation{{{Tag: "required"}}},
+		Dive:        &Rules{Validations: [][]Validation{{{Tag: "min", Param: "1"}}}},
+	})
+
+	rules, err = validate.ParseTag("")
+	Equal(t, err, nil)
+	Equal(t, rules, &Rules{})
+
+	_, err = validate.ParseTag("bad")
+	NotEqual(t, err, nil)
+	Equal(t, err.(*InvalidTagError).Tag, "bad")
+}
+
+func TestVarPath(t *testing.T) {
+
+	validate := New()
+
+	path := Path{
+		{Kind: FieldSegment, Name: "addresses", StructName: "addresses"},
+		{Kind: IndexSegment, Index: 0},
+		{Kind: FieldSegment, Name: "zip", StructName: "zip"},
+	}
+
+	err := validate.VarPath("1234", "numeric,len=5", path)
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 1)
+	AssertError(t, errs, "addresses[0].zip", "addresses[0].zip", "zip", "zip", "len")
+	Equal(t, errs[0].Path(), path)
+	Equal(t, errs[0].Path().JSONPointer(), "/addresses/0/zip")
+
+	err = validate.VarPath("a", "len=2", path[:2])
+	NotEqual(t, err, nil)
+	AssertError(t, err.(ValidationErrors), "addresses[0]", "addresses[0]", "addresses[0]", "addresses[0]", "len")
+
+	err = validate.VarPath("a", "len=2", Path{{Kind: IndexSegment, Index: 1}})
+	NotEqual(t, err, nil)
+	AssertError(t, err.(ValidationErrors), "[1]", "[1]", "[1]", "[1]", "len")
+
+	err = validate.VarPath(nil, "required", path)
+	NotEqual(t, err, nil)
+	AssertError(t, err.(ValidationErrors), "addresses[0].zip", "addresses[0].zip", "zip", "zip", "required")
+
+	err = validate.VarPath("12345", "numeric,len=5", path)
+	Equal(t, err, nil)
+}